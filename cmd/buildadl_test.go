@@ -267,6 +267,18 @@ func TestBuildADLLanguages(t *testing.T) {
 			wantKey:  "typescript:",
 			wrongKey: "rust:",
 		},
+		{
+			name:     "python",
+			ans:      answers{Name: "a", Language: "python", PythonName: "a", PythonVersion: "3.13"},
+			wantKey:  "python:",
+			wrongKey: "typescript:",
+		},
+		{
+			name:     "kotlin",
+			ans:      answers{Name: "a", Language: "kotlin", KotlinPackage: "com.example.a", JvmVersion: "21"},
+			wantKey:  "kotlin:",
+			wrongKey: "python:",
+		},
 		{
 			name:     "go default for unknown",
 			ans:      answers{Name: "a", Language: "elixir", GoModule: "github.com/example/a", GoVersion: "1.26.4"},
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/inference-gateway/adl-cli/internal/registry"
+	"github.com/inference-gateway/adl-cli/internal/schema"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// bundleCmd represents the bundle command
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Package an ADL file with its skills for offline builds",
+	Long: `Package an ADL file together with adl.lock and the content of every skill it
+resolves to, so projects can be generated on machines without network access.
+
+  adl bundle create agent.yaml -o agent.bundle.tar.gz   # where the registries are reachable
+  adl bundle import agent.bundle.tar.gz                 # seed the skills cache, or
+  adl generate --bundle agent.bundle.tar.gz             # seed it and generate offline
+
+Bare skills need no content and local directory skills are read from disk,
+so neither is part of a bundle.`,
+}
+
+var bundleCreateCmd = &cobra.Command{
+	Use:   "create [adl-file]",
+	Short: "Resolve the skills of an ADL file and package them into a bundle",
+	Long: `Resolve the skills of an ADL file (default agent.yaml) exactly as 'adl generate'
+would, verifying them against the adl.lock next to it, and write the ADL file,
+the lock and every skill's files into a gzipped tarball. The same inputs always
+produce the same bundle. adl.lock itself is left untouched; skills missing from
+it are only locked in the bundle.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runBundleCreate,
+}
+
+var bundleImportCmd = &cobra.Command{
+	Use:   "import <bundle>",
+	Short: "Seed the skills cache from a bundle",
+	Long: `Verify the skills of a bundle against its digests and store them in the skills
+cache, replacing the entries there, so 'adl generate --offline' finds them.
+With --output, the bundled ADL file and adl.lock are written to that directory
+as well.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBundleImport,
+}
+
+var (
+	bundleOutput    string
+	bundleExtractTo string
+)
+
+func init() {
+	rootCmd.AddCommand(bundleCmd)
+	bundleCmd.AddCommand(bundleCreateCmd, bundleImportCmd)
+
+	bundleCreateCmd.Flags().StringVarP(&bundleOutput, "output", "o", "", "Bundle file to write (default <adl-file name>.bundle.tar.gz)")
+	bundleImportCmd.Flags().StringVarP(&bundleExtractTo, "output", "o", "", "Also write the bundled ADL file and adl.lock to this directory")
+}
+
+func runBundleCreate(cmd *cobra.Command, args []string) error {
+	file := "agent.yaml"
+	if len(args) == 1 {
+		file = args[0]
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read ADL file: %w", err)
+	}
+	var adl schema.ADL
+	if err := yaml.Unmarshal(data, &adl); err != nil {
+		return fmt.Errorf("failed to parse ADL file: %w", err)
+	}
+
+	resolver, err := newSkillsResolver()
+	if err != nil {
+		return fmt.Errorf("failed to initialize skills resolver: %w", err)
+	}
+	resolver.BaseDir = filepath.Dir(file)
+	lock, err := registry.LoadLock(registry.LockPath(file))
+	if err != nil {
+		return err
+	}
+	resolver.Lock = lock
+	skills, err := resolver.BundleSkills(context.Background(), adl.Spec.Skills)
+	if err != nil {
+		return err
+	}
+	lock.Retain(adl.Spec.Skills)
+
+	out := bundleOutput
+	if out == "" {
+		base := filepath.Base(file)
+		out = strings.TrimSuffix(base, filepath.Ext(base)) + ".bundle.tar.gz"
+	}
+	b := &registry.Bundle{ManifestName: filepath.Base(file), Manifest: data, Lock: lock, Skills: skills}
+	if err := writeBundle(out, b); err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	for _, s := range skills {
+		_, _ = fmt.Fprintf(w, "📦 %s@%s (%d files)\n", s.ID, cmp.Or(s.Ref, "latest"), len(s.Files))
+	}
+	_, _ = fmt.Fprintf(w, "✅ Bundled '%s' and %d skills into '%s'\n", file, len(skills), out)
+	return nil
+}
+
+// writeBundle writes b to path, removing the partial file on failure.
+func writeBundle(path string, b *registry.Bundle) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle: %w", err)
+	}
+	err = b.Write(f)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write bundle: %w", closeErr)
+	}
+	if err != nil {
+		_ = os.Remove(path)
+	}
+	return err
+}
+
+func runBundleImport(cmd *cobra.Command, args []string) error {
+	w := cmd.OutOrStdout()
+	b, err := importBundle(w, args[0])
+	if err != nil {
+		return err
+	}
+	if bundleExtractTo == "" {
+		return nil
+	}
+	if b.ManifestName != filepath.Base(b.ManifestName) || b.ManifestName == "." || b.ManifestName == ".." {
+		return fmt.Errorf("bundle names its ADL file %q, which is not a plain file name", b.ManifestName)
+	}
+
+	files := map[string][]byte{b.ManifestName: b.Manifest}
+	if b.Lock != nil {
+		data, err := b.Lock.Marshal()
+		if err != nil {
+			return err
+		}
+		files[registry.LockFile] = data
+	}
+	for name := range files {
+		if _, err := os.Stat(filepath.Join(bundleExtractTo, name)); err == nil {
+			return fmt.Errorf("'%s' already exists in '%s'; refusing to overwrite it", name, bundleExtractTo)
+		}
+	}
+	if err := os.MkdirAll(bundleExtractTo, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		path := filepath.Join(bundleExtractTo, name)
+		if err := os.WriteFile(path, files[name], 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		_, _ = fmt.Fprintf(w, "📝 Wrote '%s'\n", path)
+	}
+	return nil
+}
+
+// importBundle reads the bundle at path and seeds the skills cache with
+// its skills.
+func importBundle(w io.Writer, path string) (*registry.Bundle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bundle: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	b, err := registry.ReadBundle(f)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bundle '%s': %w", path, err)
+	}
+	cache, err := newSkillsCache()
+	if err != nil {
+		return nil, err
+	}
+	if err := b.Seed(cache); err != nil {
+		return nil, err
+	}
+	_, _ = fmt.Fprintf(w, "📦 Imported %d skills from '%s' into '%s'\n", len(b.Skills), path, cache.Dir())
+	return b, nil
+}
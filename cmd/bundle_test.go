@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/inference-gateway/adl-cli/internal/registry"
+)
+
+func TestBundleCreateAndImport(t *testing.T) {
+	useTestSkillsRegistry(t)
+	dir := t.TempDir()
+	file := filepath.Join(dir, "agent.yaml")
+	manifest := skillsTestADL + "    - id: data-analysis\n      version: ^1.0\n"
+	if err := os.WriteFile(file, []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	bundleCreateCmd.SetOut(&out)
+	bundleImportCmd.SetOut(&out)
+	defer bundleCreateCmd.SetOut(nil)
+	defer bundleImportCmd.SetOut(nil)
+	defer func() { bundleOutput, bundleExtractTo = "", "" }()
+
+	bundleOutput = filepath.Join(dir, "agent.bundle.tar.gz")
+	if err := runBundleCreate(bundleCreateCmd, []string{file}); err != nil {
+		t.Fatalf("bundle create failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "📦 data-analysis@1.4.0 (1 files)") || !strings.Contains(out.String(), "1 skills into") {
+		t.Errorf("unexpected create output:\n%s", out.String())
+	}
+	if _, err := os.Stat(registry.LockPath(file)); !os.IsNotExist(err) {
+		t.Errorf("bundle create must not write adl.lock, got %v", err)
+	}
+
+	// Import into an empty cache, as on an air-gapped machine.
+	cache, err := registry.NewCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := newSkillsCache
+	newSkillsCache = func() (*registry.Cache, error) { return cache, nil }
+	defer func() { newSkillsCache = original }()
+
+	out.Reset()
+	bundleExtractTo = filepath.Join(t.TempDir(), "project")
+	if err := runBundleImport(bundleImportCmd, []string{bundleOutput}); err != nil {
+		t.Fatalf("bundle import failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Imported 1 skills") {
+		t.Errorf("unexpected import output:\n%s", out.String())
+	}
+	if files, ok, err := cache.Get("data-analysis", "1.4.0"); err != nil || !ok || !strings.Contains(string(files[registry.SkillFile]), "version: 1.4.0") {
+		t.Errorf("expected the bundled skill in the cache, got ok=%v err=%v", ok, err)
+	}
+	if data, err := os.ReadFile(filepath.Join(bundleExtractTo, "agent.yaml")); err != nil || string(data) != manifest {
+		t.Errorf("expected the bundled ADL file to be extracted, got err=%v", err)
+	}
+	lock, err := registry.LoadLock(filepath.Join(bundleExtractTo, registry.LockFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry, ok := lock.Skill("data-analysis"); !ok || entry.Version != "1.4.0" {
+		t.Errorf("expected the bundled lock to pin data-analysis@1.4.0, got %+v", lock.Skills)
+	}
+
+	if err := runBundleImport(bundleImportCmd, []string{bundleOutput}); err == nil || !strings.Contains(err.Error(), "refusing to overwrite") {
+		t.Errorf("expected extracting over existing files to fail, got %v", err)
+	}
+}
+
+func TestBundleImport_RejectsManifestPaths(t *testing.T) {
+	cache, err := registry.NewCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := newSkillsCache
+	newSkillsCache = func() (*registry.Cache, error) { return cache, nil }
+	defer func() { newSkillsCache = original }()
+
+	var out bytes.Buffer
+	bundleImportCmd.SetOut(&out)
+	defer bundleImportCmd.SetOut(nil)
+	defer func() { bundleExtractTo = "" }()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "agent.bundle.tar.gz")
+	if err := writeBundle(path, &registry.Bundle{ManifestName: "sub/agent.yaml", Manifest: []byte("kind: Agent\n")}); err != nil {
+		t.Fatal(err)
+	}
+	bundleExtractTo = filepath.Join(dir, "project")
+	if err := runBundleImport(bundleImportCmd, []string{path}); err == nil || !strings.Contains(err.Error(), "not a plain file name") {
+		t.Errorf("expected a nested ADL file name to be rejected, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(bundleExtractTo, "sub")); !os.IsNotExist(err) {
+		t.Errorf("nothing must be extracted, stat err = %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/inference-gateway/adl-cli/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+// cacheCmd represents the cache command
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect and clean the skills cache",
+	Long: `Inspect and clean the skills cache under ~/.adl/skills-cache, where every
+fetched skill is kept as <id>@<ref>/.
+
+Releases and commits are immutable and used from the cache as is. Moving refs
+(branches and unpinned registry skills) are revalidated once their entry is
+older than ADL_SKILLS_CACHE_TTL (default 1h): the ref's current commit or the
+registry's ETag is compared with the cached one and the skill is downloaded
+again only if it changed. 'adl generate --refresh' skips the cache for moving
+refs altogether.`,
+}
+
+var cacheListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List cached skills with their age and size",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheList,
+}
+
+var cacheCleanCmd = &cobra.Command{
+	Use:   "clean [id...]",
+	Short: "Remove every cached skill, or only the given skills",
+	RunE:  runCacheClean,
+}
+
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove cached skills not fetched or revalidated recently",
+	Args:  cobra.NoArgs,
+	RunE:  runCachePrune,
+}
+
+var cacheOlderThan string
+
+// newSkillsCache opens the skills cache; tests replace it to use a
+// temporary directory.
+var newSkillsCache = func() (*registry.Cache, error) { return registry.NewCache("") }
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheListCmd, cacheCleanCmd, cachePruneCmd)
+
+	cachePruneCmd.Flags().StringVar(&cacheOlderThan, "older-than", "30d", "Remove entries older than this age (e.g. 12h, 30d)")
+}
+
+func runCacheList(cmd *cobra.Command, args []string) error {
+	cache, err := newSkillsCache()
+	if err != nil {
+		return err
+	}
+	entries, err := cache.List()
+	if err != nil {
+		return err
+	}
+	w := cmd.OutOrStdout()
+	if len(entries) == 0 {
+		_, _ = fmt.Fprintf(w, "The skills cache '%s' is empty\n", cache.Dir())
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tREF\tFETCHED\tFILES\tSIZE")
+	var total int64
+	for _, e := range entries {
+		total += e.Size
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s ago\t%d\t%s\n", e.ID, e.Ref, formatAge(time.Since(e.FetchedAt)), e.Files, formatSize(e.Size))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(w, "\n%d entries, %s in '%s'\n", len(entries), formatSize(total), cache.Dir())
+	return nil
+}
+
+func runCacheClean(cmd *cobra.Command, args []string) error {
+	cache, err := newSkillsCache()
+	if err != nil {
+		return err
+	}
+	entries, err := cache.List()
+	if err != nil {
+		return err
+	}
+	removed := 0
+	for _, e := range entries {
+		if len(args) > 0 && !slices.Contains(args, e.ID) {
+			continue
+		}
+		if err := cache.Remove(e.ID, e.Ref); err != nil {
+			return err
+		}
+		removed++
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "🗑️  Removed %d cached skill entries from '%s'\n", removed, cache.Dir())
+	return nil
+}
+
+func runCachePrune(cmd *cobra.Command, args []string) error {
+	age, err := parseAge(cacheOlderThan)
+	if err != nil {
+		return err
+	}
+	cache, err := newSkillsCache()
+	if err != nil {
+		return err
+	}
+	pruned, err := cache.Prune(time.Now().Add(-age))
+	if err != nil {
+		return err
+	}
+	w := cmd.OutOrStdout()
+	var freed int64
+	for _, e := range pruned {
+		freed += e.Size
+		_, _ = fmt.Fprintf(w, "🗑️  Removed %s@%s (fetched %s ago)\n", e.ID, e.Ref, formatAge(time.Since(e.FetchedAt)))
+	}
+	_, _ = fmt.Fprintf(w, "✅ Pruned %d entries older than %s, freeing %s\n", len(pruned), cacheOlderThan, formatSize(freed))
+	return nil
+}
+
+// parseAge parses a Go duration, also accepting whole days ("30d").
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err == nil && n >= 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age %q: use a duration such as 12h or 30d", s)
+	}
+	return d, nil
+}
+
+// formatAge renders a duration in its largest whole unit.
+func formatAge(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	}
+	return fmt.Sprintf("%ds", int(d/time.Second))
+}
+
+func formatSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/inference-gateway/adl-cli/internal/registry"
+)
+
+func TestCacheCommands(t *testing.T) {
+	cache, err := registry.NewCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := newSkillsCache
+	newSkillsCache = func() (*registry.Cache, error) { return cache, nil }
+	defer func() { newSkillsCache = original }()
+
+	for id, ref := range map[string]string{"pdf": "main", "csv": "1.0.0", "triage": "latest"} {
+		if err := cache.Put(id, ref, map[string][]byte{"SKILL.md": []byte("---\nname: " + id + "\n---\n")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	for _, c := range cacheCmd.Commands() {
+		c.SetOut(&out)
+		defer c.SetOut(nil)
+	}
+
+	if err := runCacheList(cacheListCmd, nil); err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 6 || !strings.HasPrefix(lines[0], "ID") || !strings.HasPrefix(lines[1], "csv") || !strings.Contains(lines[5], "3 entries") {
+		t.Errorf("unexpected list output:\n%s", out.String())
+	}
+
+	out.Reset()
+	cacheOlderThan = "1h"
+	if err := runCachePrune(cachePruneCmd, nil); err != nil {
+		t.Fatalf("prune failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Pruned 0 entries") {
+		t.Errorf("fresh entries must not be pruned:\n%s", out.String())
+	}
+	cacheOlderThan = "0s"
+	time.Sleep(10 * time.Millisecond)
+	if err := runCachePrune(cachePruneCmd, nil); err != nil {
+		t.Fatalf("prune failed: %v", err)
+	}
+	if entries, _ := cache.List(); len(entries) != 0 {
+		t.Errorf("expected every entry to be pruned, got %+v", entries)
+	}
+
+	cacheOlderThan = "soon"
+	if err := runCachePrune(cachePruneCmd, nil); err == nil {
+		t.Error("expected an invalid age to fail")
+	}
+	cacheOlderThan = "30d"
+
+	if err := cache.Put("pdf", "main", map[string][]byte{"SKILL.md": []byte("x")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Put("csv", "1.0.0", map[string][]byte{"SKILL.md": []byte("x")}); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if err := runCacheClean(cacheCleanCmd, []string{"pdf"}); err != nil {
+		t.Fatalf("clean failed: %v", err)
+	}
+	if entries, _ := cache.List(); len(entries) != 1 || entries[0].ID != "csv" {
+		t.Errorf("expected only pdf to be removed, got %+v", entries)
+	}
+	if err := runCacheClean(cacheCleanCmd, nil); err != nil {
+		t.Fatalf("clean failed: %v", err)
+	}
+	if entries, _ := cache.List(); len(entries) != 0 {
+		t.Errorf("expected an empty cache, got %+v", entries)
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	for in, want := range map[string]time.Duration{"30d": 30 * 24 * time.Hour, "12h": 12 * time.Hour, "90m": 90 * time.Minute} {
+		if got, err := parseAge(in); err != nil || got != want {
+			t.Errorf("parseAge(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/inference-gateway/adl-cli/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Preview what generate would change without writing files",
+	Long: `Render the project from an Agent Definition Language (ADL) file into memory
+and print a unified diff against the output directory, followed by a summary
+of created, modified, unchanged and ignored files. Nothing is written to disk
+and no post-generation commands run.
+
+The diff shows the result of regenerating with --overwrite; files matching
+.adl-ignore are listed as ignored. 'adl generate --dry-run' is an alias.`,
+	RunE: runDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	addGenerateFlags(diffCmd)
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	absADLFile, absOutputDir, err := validateForGeneration()
+	if err != nil {
+		return err
+	}
+
+	config := generatorConfig()
+	config.DryRun = true
+	gen := generator.New(config)
+	if err := gen.Generate(absADLFile, absOutputDir); err != nil {
+		return fmt.Errorf("generation failed: %w", err)
+	}
+
+	changes, err := generator.Compare(absOutputDir, gen.Files())
+	if err != nil {
+		return err
+	}
+
+	prev, err := generator.LoadManifest(absOutputDir)
+	if err != nil {
+		return err
+	}
+	orphans, err := generator.FindOrphans(absOutputDir, prev, gen.Files(), gen.Scope())
+	if err != nil {
+		return err
+	}
+
+	printChanges(cmd.OutOrStdout(), changes, orphans, overwrite)
+	return nil
+}
+
+// printChanges writes the unified diff of every created or modified file,
+// lists every path that is not unchanged (including orphans recorded in
+// .adl/generated.json that the ADL no longer produces) and ends with a
+// one-line summary.
+func printChanges(w io.Writer, changes []generator.FileChange, orphans []generator.Orphan, overwrite bool) {
+	counts := make(map[generator.FileStatus]int)
+	printedDiff := false
+	for _, c := range changes {
+		counts[c.Status]++
+		if c.Diff != "" {
+			_, _ = fmt.Fprint(w, c.Diff)
+			printedDiff = true
+		}
+	}
+
+	if printedDiff {
+		_, _ = fmt.Fprintln(w)
+	}
+	for _, c := range changes {
+		if c.Status == generator.FileUnchanged {
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "  %-9s %s\n", c.Status, c.Path)
+	}
+	for _, o := range orphans {
+		note := "generate --prune would delete it"
+		if o.Modified {
+			note = "has local edits, --prune keeps it"
+		}
+		_, _ = fmt.Fprintf(w, "  %-9s %s (%s)\n", "orphaned", o.Path, note)
+	}
+
+	_, _ = fmt.Fprintf(w, "Summary: %d created, %d modified, %d unchanged, %d ignored",
+		counts[generator.FileCreated], counts[generator.FileModified],
+		counts[generator.FileUnchanged], counts[generator.FileIgnored])
+	if len(orphans) > 0 {
+		_, _ = fmt.Fprintf(w, ", %d orphaned", len(orphans))
+	}
+	_, _ = fmt.Fprintln(w)
+	if counts[generator.FileModified] > 0 && !overwrite {
+		_, _ = fmt.Fprintf(w, "Note: without --overwrite, generate skips the %d modified file(s)\n", counts[generator.FileModified])
+	}
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiffCommand(t *testing.T) {
+	tempDir := t.TempDir()
+	outputPath := filepath.Join(tempDir, "test-output")
+
+	adlContent := `apiVersion: adl.inference-gateway.com/v1
+kind: Agent
+metadata:
+  name: diff-agent
+  description: Diff agent
+  version: 1.0.0
+spec:
+  capabilities:
+    streaming: true
+    pushNotifications: false
+    stateTransitionHistory: false
+  server:
+    port: 8080
+  language:
+    go:
+      module: github.com/test/diff-agent
+      version: "1.26.4"
+`
+	adlPath := filepath.Join(tempDir, "agent.yaml")
+	if err := os.WriteFile(adlPath, []byte(adlContent), 0644); err != nil {
+		t.Fatalf("failed to write ADL file: %v", err)
+	}
+
+	originalADLFile := adlFile
+	originalOutputDir := outputDir
+	defer func() {
+		adlFile = originalADLFile
+		outputDir = originalOutputDir
+		diffCmd.SetOut(nil)
+	}()
+
+	adlFile = adlPath
+	outputDir = outputPath
+
+	var out bytes.Buffer
+	diffCmd.SetOut(&out)
+	if err := runDiff(diffCmd, []string{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(outputPath); !os.IsNotExist(err) {
+		t.Errorf("diff must not create the output directory")
+	}
+
+	got := out.String()
+	if !strings.Contains(got, "--- /dev/null\n+++ b/main.go\n") {
+		t.Errorf("expected a creation diff for main.go, got:\n%s", got)
+	}
+	if !strings.Contains(got, "  created   go.mod\n") {
+		t.Errorf("expected go.mod in the file list, got:\n%s", got)
+	}
+	if !strings.Contains(got, "Summary: ") || !strings.Contains(got, " created, 0 modified, 0 unchanged, 0 ignored") {
+		t.Errorf("unexpected summary line, got:\n%s", got)
+	}
+}
+
+func TestGenerateCheck(t *testing.T) {
+	tempDir := t.TempDir()
+	outputPath := filepath.Join(tempDir, "test-output")
+
+	adlContent := `apiVersion: adl.inference-gateway.com/v1
+kind: Agent
+metadata:
+  name: check-agent
+  description: Check agent
+  version: 1.0.0
+spec:
+  capabilities:
+    streaming: true
+    pushNotifications: false
+    stateTransitionHistory: false
+  server:
+    port: 8080
+  language:
+    rust:
+      packageName: check-agent
+      version: "1.94.1"
+      edition: "2024"
+`
+	adlPath := filepath.Join(tempDir, "agent.yaml")
+	if err := os.WriteFile(adlPath, []byte(adlContent), 0644); err != nil {
+		t.Fatalf("failed to write ADL file: %v", err)
+	}
+
+	originalADLFile := adlFile
+	originalOutputDir := outputDir
+	originalOverwrite := overwrite
+	defer func() {
+		adlFile = originalADLFile
+		outputDir = originalOutputDir
+		overwrite = originalOverwrite
+		generateCmd.SetOut(nil)
+	}()
+
+	adlFile = adlPath
+	outputDir = outputPath
+	overwrite = true
+
+	if err := runGenerate(generateCmd, []string{}); err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	var out bytes.Buffer
+	generateCmd.SetOut(&out)
+	if err := runCheck(generateCmd, []string{}); err != nil {
+		t.Fatalf("expected freshly generated output to pass the check, got: %v\n%s", err, out.String())
+	}
+
+	updated := strings.Replace(adlContent, "port: 8080", "port: 9090", 1)
+	if err := os.WriteFile(adlPath, []byte(updated), 0644); err != nil {
+		t.Fatalf("failed to update ADL file: %v", err)
+	}
+
+	out.Reset()
+	if err := runCheck(generateCmd, []string{}); err == nil {
+		t.Fatal("expected the check to fail after the ADL changed")
+	}
+	if !strings.Contains(out.String(), "  stale     Dockerfile\n") {
+		t.Errorf("expected Dockerfile to be listed as stale, got:\n%s", out.String())
+	}
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+	"github.com/spf13/cobra"
+)
+
+// explainCmd represents the explain command
+var explainCmd = &cobra.Command{
+	Use:   "explain [path]",
+	Short: "Describe a field of the ADL schema",
+	Long: `Describe a field of the Agent Definition Language (ADL) schema: its type,
+whether it is required, allowed values, default and description. The path is
+a dotted manifest path; list indexes are optional and map values are
+addressed by any key.
+
+Examples:
+  adl explain spec.telemetry.traces.exporter
+  adl explain spec.tools.inject
+  adl explain spec.services.database
+
+Without a path the document root is described.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runExplain,
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+}
+
+func runExplain(cmd *cobra.Command, args []string) error {
+	path := ""
+	if len(args) > 0 {
+		path = args[0]
+	}
+
+	field, err := schema.Explain(path)
+	if err != nil {
+		cmd.SilenceUsage = true
+		return err
+	}
+	printSchemaField(cmd.OutOrStdout(), field)
+	return nil
+}
+
+func printSchemaField(w io.Writer, f *schema.SchemaField) {
+	title := f.Path
+	if title == "" {
+		title = "(root)"
+	}
+	_, _ = fmt.Fprintf(w, "%s\n\n", title)
+
+	row := func(label, value string) {
+		_, _ = fmt.Fprintf(w, "  %-10s %s\n", label+":", value)
+	}
+	row("Type", f.Type)
+	row("Required", map[bool]string{true: "yes", false: "no"}[f.Required])
+	if f.Const != nil {
+		row("Value", schemaValue(f.Const))
+	}
+	if len(f.Enum) > 0 {
+		values := make([]string, len(f.Enum))
+		for i, v := range f.Enum {
+			values[i] = schemaValue(v)
+		}
+		row("Values", strings.Join(values, ", "))
+	}
+	if f.Default != nil {
+		row("Default", schemaValue(f.Default))
+	}
+	if f.Pattern != "" {
+		row("Pattern", f.Pattern)
+	}
+	if f.Format != "" {
+		row("Format", f.Format)
+	}
+	if f.Minimum != nil {
+		row("Minimum", fmt.Sprint(*f.Minimum))
+	}
+	if f.Maximum != nil {
+		row("Maximum", fmt.Sprint(*f.Maximum))
+	}
+	if len(f.OneOf) > 0 {
+		row("One of", strings.Join(f.OneOf, " | "))
+	}
+
+	if f.Description != "" {
+		_, _ = fmt.Fprintf(w, "\n%s\n", wrapText(f.Description, 78, "  "))
+	}
+
+	if len(f.Fields) > 0 {
+		width := 0
+		for _, p := range f.Fields {
+			width = max(width, len(p.Name))
+		}
+		_, _ = fmt.Fprintf(w, "\n  Fields:\n")
+		for _, p := range f.Fields {
+			required := ""
+			if p.Required {
+				required = " (required)"
+			}
+			_, _ = fmt.Fprintf(w, "    %-*s  %s%s\n", width, p.Name, p.Type, required)
+		}
+	}
+}
+
+// schemaValue renders a JSON value from the schema (enum entries, defaults)
+// the way it would be written in a manifest.
+func schemaValue(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// wrapText word-wraps s to width columns, prefixing every line with indent.
+func wrapText(s string, width int, indent string) string {
+	var b strings.Builder
+	line := indent
+	for _, word := range strings.Fields(s) {
+		if line != indent && len(line)+1+len(word) > width {
+			b.WriteString(line + "\n")
+			line = indent
+		}
+		if line != indent {
+			line += " "
+		}
+		line += word
+	}
+	b.WriteString(line)
+	return b.String()
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestExplainCommand(t *testing.T) {
+	var out bytes.Buffer
+	explainCmd.SetOut(&out)
+	defer explainCmd.SetOut(nil)
+
+	if err := runExplain(explainCmd, []string{"spec.server.authz.mode"}); err != nil {
+		t.Fatalf("runExplain() failed: %v", err)
+	}
+	for _, want := range []string{
+		"spec.server.authz.mode",
+		"Type:      string",
+		"Required:  no",
+		"Values:    allow-all, deny-all, custom",
+		"Default:   allow-all",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := runExplain(explainCmd, []string{"spec.telemetry"}); err != nil {
+		t.Fatalf("runExplain() failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Fields:") || !strings.Contains(out.String(), "traces") {
+		t.Errorf("expected the telemetry fields to be listed:\n%s", out.String())
+	}
+
+	if err := runExplain(explainCmd, []string{"spec.nope"}); err == nil {
+		t.Error("expected an error for an unknown path")
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	got := wrapText("one two three four", 11, "  ")
+	if got != "  one two\n  three\n  four" {
+		t.Errorf("wrapText() = %q", got)
+	}
+}
@@ -29,60 +29,123 @@ var (
 	enableFlox         bool
 	enableDevContainer bool
 	offlineMode        bool
+	refreshSkills      bool
+	bundleFile         string
+	targetLanguage     string
+	overlayDir         string
+	dryRun             bool
+	pruneOrphans       bool
+	checkOnly          bool
 )
 
 func init() {
 	rootCmd.AddCommand(generateCmd)
 
-	generateCmd.Flags().StringVarP(&adlFile, "file", "f", "agent.yaml", "ADL file to generate from")
-	generateCmd.Flags().StringVarP(&outputDir, "output", "o", ".", "Output directory for generated code")
-	generateCmd.Flags().StringVarP(&template, "template", "t", "minimal", "Template to use (minimal)")
-	generateCmd.Flags().BoolVar(&overwrite, "overwrite", false, "Overwrite existing files")
-	generateCmd.Flags().BoolVar(&generateCI, "ci", false, "Generate CI workflow configuration")
-	generateCmd.Flags().BoolVar(&generateCD, "cd", false, "Generate CD pipeline configuration with semantic-release")
-	generateCmd.Flags().StringVar(&deploymentType, "deployment", "", "Deployment type (kubernetes, cloudrun, defaults to empty for no deployment)")
-	generateCmd.Flags().BoolVar(&enableFlox, "flox", false, "Enable Flox environment")
-	generateCmd.Flags().BoolVar(&enableDevContainer, "devcontainer", false, "Enable DevContainer environment")
-	generateCmd.Flags().BoolVar(&offlineMode, "offline", false, "Skip the skills registry; require every non-bare skill to already be in the local cache")
+	addGenerateFlags(generateCmd)
+	generateCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Render into memory and print a unified diff against the output directory without writing files")
+	generateCmd.Flags().BoolVar(&pruneOrphans, "prune", false, "Delete previously generated files the ADL no longer produces (files with local edits are kept)")
+	generateCmd.Flags().BoolVar(&checkOnly, "check", false, "Exit non-zero and list the differing files if the output directory is not up to date with the ADL file (for CI)")
+	generateCmd.MarkFlagsMutuallyExclusive("check", "dry-run")
+	generateCmd.MarkFlagsMutuallyExclusive("check", "prune")
+	generateCmd.MarkFlagsMutuallyExclusive("dry-run", "prune")
 }
 
-func runGenerate(cmd *cobra.Command, args []string) error {
+// addGenerateFlags registers the flags shared by every command that
+// renders a project from an ADL file (generate, diff).
+func addGenerateFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&adlFile, "file", "f", "agent.yaml", "ADL file to generate from")
+	cmd.Flags().StringVarP(&outputDir, "output", "o", ".", "Output directory for generated code")
+	cmd.Flags().StringVarP(&template, "template", "t", "minimal", "Template to use (minimal)")
+	cmd.Flags().BoolVar(&overwrite, "overwrite", false, "Overwrite existing files")
+	cmd.Flags().BoolVar(&generateCI, "ci", false, "Generate CI workflow configuration")
+	cmd.Flags().BoolVar(&generateCD, "cd", false, "Generate CD pipeline configuration with semantic-release")
+	cmd.Flags().StringVar(&deploymentType, "deployment", "", "Deployment type (kubernetes, cloudrun, defaults to empty for no deployment)")
+	cmd.Flags().BoolVar(&enableFlox, "flox", false, "Enable Flox environment")
+	cmd.Flags().BoolVar(&enableDevContainer, "devcontainer", false, "Enable DevContainer environment")
+	cmd.Flags().BoolVar(&offlineMode, "offline", false, "Skip the skills registry; require every non-bare skill to already be in the local cache")
+	cmd.Flags().BoolVar(&refreshSkills, "refresh", false, "Re-fetch skills on moving refs (branches, unpinned registry skills) instead of using the skills cache")
+	cmd.Flags().StringVar(&bundleFile, "bundle", "", "Seed the skills cache from a bundle created by 'adl bundle create' and resolve skills offline")
+	cmd.Flags().StringVar(&targetLanguage, "language", "", "Generate only this spec.language entry, in its subdirectory when several are defined (go/rust/typescript/python/kotlin)")
+	cmd.Flags().StringVar(&overlayDir, "templates-dir", "", "Template overlay directory whose templates replace the embedded ones (see 'adl templates')")
+	cmd.MarkFlagsMutuallyExclusive("offline", "refresh")
+	cmd.MarkFlagsMutuallyExclusive("bundle", "refresh")
+}
+
+// generatorConfig builds the generator configuration from the shared
+// generate flags.
+func generatorConfig() generator.Config {
+	return generator.Config{
+		Template:           template,
+		Overwrite:          overwrite,
+		Version:            version,
+		GenerateCI:         generateCI,
+		GenerateCD:         generateCD,
+		DeploymentType:     deploymentType,
+		EnableFlox:         enableFlox,
+		EnableDevContainer: enableDevContainer,
+		Offline:            offlineMode || bundleFile != "",
+		Refresh:            refreshSkills,
+		Language:           targetLanguage,
+		TemplatesDir:       overlayDir,
+		UserTemplatesDir:   userTemplatesDir(),
+		ADLFile:            adlFile,
+		OutputDir:          outputDir,
+	}
+}
+
+// validateForGeneration checks that adlFile exists and passes schema
+// validation, printing warnings to stderr, and seeds the skills cache
+// from --bundle. It returns the absolute ADL file and output directory
+// paths.
+func validateForGeneration() (string, string, error) {
 	if _, err := os.Stat(adlFile); os.IsNotExist(err) {
-		return fmt.Errorf("ADL file '%s' does not exist", adlFile)
+		return "", "", fmt.Errorf("ADL file '%s' does not exist", adlFile)
 	}
 
 	absADLFile, err := filepath.Abs(adlFile)
 	if err != nil {
-		return fmt.Errorf("failed to resolve ADL file path: %w", err)
+		return "", "", fmt.Errorf("failed to resolve ADL file path: %w", err)
 	}
 
 	absOutputDir, err := filepath.Abs(outputDir)
 	if err != nil {
-		return fmt.Errorf("failed to resolve output directory path: %w", err)
+		return "", "", fmt.Errorf("failed to resolve output directory path: %w", err)
 	}
 
 	validator := schema.NewValidator()
 	warnings, err := validator.ValidateFile(adlFile)
 	if err != nil {
-		return fmt.Errorf("ADL validation failed: %w", err)
+		return "", "", fmt.Errorf("ADL validation failed: %w", err)
 	}
 	for _, w := range warnings {
 		fmt.Fprintf(os.Stderr, "⚠️  %s\n", w)
 	}
 
-	gen := generator.New(generator.Config{
-		Template:           template,
-		Overwrite:          overwrite,
-		Version:            version,
-		GenerateCI:         generateCI,
-		GenerateCD:         generateCD,
-		DeploymentType:     deploymentType,
-		EnableFlox:         enableFlox,
-		EnableDevContainer: enableDevContainer,
-		Offline:            offlineMode,
-		ADLFile:            adlFile,
-		OutputDir:          outputDir,
-	})
+	if bundleFile != "" {
+		if _, err := importBundle(os.Stderr, bundleFile); err != nil {
+			return "", "", err
+		}
+	}
+
+	return absADLFile, absOutputDir, nil
+}
+
+func runGenerate(cmd *cobra.Command, args []string) error {
+	if dryRun {
+		return runDiff(cmd, args)
+	}
+	if checkOnly {
+		return runCheck(cmd, args)
+	}
+
+	absADLFile, absOutputDir, err := validateForGeneration()
+	if err != nil {
+		return err
+	}
+
+	config := generatorConfig()
+	config.Prune = pruneOrphans
+	gen := generator.New(config)
 
 	fmt.Printf("Generating A2A agent from '%s' to '%s'\n", absADLFile, absOutputDir)
 	fmt.Printf("Using template: %s\n", template)
@@ -119,3 +182,57 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// runCheck renders the project into memory and fails when the committed
+// output differs from it, so CI catches ADL edits that were not followed
+// by a regeneration. Nothing is written to disk.
+func runCheck(cmd *cobra.Command, args []string) error {
+	absADLFile, absOutputDir, err := validateForGeneration()
+	if err != nil {
+		return err
+	}
+
+	config := generatorConfig()
+	config.DryRun = true
+	gen := generator.New(config)
+	if err := gen.Generate(absADLFile, absOutputDir); err != nil {
+		return fmt.Errorf("generation failed: %w", err)
+	}
+
+	prev, err := generator.LoadManifest(absOutputDir)
+	if err != nil {
+		return err
+	}
+	stale, err := generator.Check(absOutputDir, gen.Files(), prev)
+	if err != nil {
+		return err
+	}
+	orphans, err := generator.FindOrphans(absOutputDir, prev, gen.Files(), gen.Scope())
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	for _, o := range orphans {
+		_, _ = fmt.Fprintf(w, "🧹 Orphaned file no longer produced by the ADL: %s\n", o.Path)
+	}
+	if len(stale) == 0 {
+		_, _ = fmt.Fprintf(w, "✅ Generated files in '%s' are up to date with '%s'\n", absOutputDir, absADLFile)
+		return nil
+	}
+
+	for _, c := range stale {
+		_, _ = fmt.Fprint(w, c.Diff)
+	}
+	_, _ = fmt.Fprintln(w)
+	for _, c := range stale {
+		status := "stale"
+		if c.Status == generator.FileCreated {
+			status = "missing"
+		}
+		_, _ = fmt.Fprintf(w, "  %-9s %s\n", status, c.Path)
+	}
+
+	cmd.SilenceUsage = true
+	return fmt.Errorf("%d generated file(s) are out of date with %s; run 'adl generate --overwrite' and commit the result", len(stale), adlFile)
+}
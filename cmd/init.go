@@ -70,13 +70,17 @@ func init() {
 	initCmd.Flags().Bool("history", false, "Enable state transition history")
 	initCmd.Flags().Int("port", 0, "Server port")
 	initCmd.Flags().Bool("debug", false, "Enable debug mode")
-	initCmd.Flags().String("language", "", "Programming language (go/rust/typescript)")
+	initCmd.Flags().String("language", "", "Programming language (go/rust/typescript/python/kotlin)")
 	initCmd.Flags().String("go-module", "", "Go module path")
 	initCmd.Flags().String("go-version", "", "Go version")
 	initCmd.Flags().String("rust-package-name", "", "Rust package name")
 	initCmd.Flags().String("rust-version", "", "Rust version")
 	initCmd.Flags().String("rust-edition", "", "Rust edition")
 	initCmd.Flags().String("typescript-name", "", "TypeScript package name")
+	initCmd.Flags().String("python-name", "", "Python package name")
+	initCmd.Flags().String("python-version", "", "Python version")
+	initCmd.Flags().String("kotlin-package", "", "Kotlin package name")
+	initCmd.Flags().String("jvm-version", "", "JVM toolchain version")
 	initCmd.Flags().Bool("flox", false, "Enable Flox environment")
 	initCmd.Flags().Bool("devcontainer", false, "Enable DevContainer environment")
 	initCmd.Flags().Bool("docker-compose", false, "Enable Docker Compose environment")
@@ -339,6 +343,16 @@ type adlData struct {
 				Edition     string       `yaml:"edition"`
 				Vendor      *vendorBlock `yaml:"vendor,omitempty"`
 			} `yaml:"rust,omitempty"`
+			Python *struct {
+				PackageName   string       `yaml:"packageName"`
+				PythonVersion string       `yaml:"pythonVersion"`
+				Vendor        *vendorBlock `yaml:"vendor,omitempty"`
+			} `yaml:"python,omitempty"`
+			Kotlin *struct {
+				PackageName string       `yaml:"packageName"`
+				JvmVersion  string       `yaml:"jvmVersion"`
+				Vendor      *vendorBlock `yaml:"vendor,omitempty"`
+			} `yaml:"kotlin,omitempty"`
 		} `yaml:"language,omitempty"`
 		SCM *struct {
 			Provider            string `yaml:"provider"`
@@ -468,6 +482,10 @@ type answers struct {
 	RustVersion     string
 	RustEdition     string
 	TSPackageName   string
+	PythonName      string
+	PythonVersion   string
+	KotlinPackage   string
+	JvmVersion      string
 
 	FloxEnabled          bool
 	DevcontainerEnabled  bool
@@ -691,6 +709,16 @@ func buildADL(ans answers) *adlData {
 			Edition     string       `yaml:"edition"`
 			Vendor      *vendorBlock `yaml:"vendor,omitempty"`
 		} `yaml:"rust,omitempty"`
+		Python *struct {
+			PackageName   string       `yaml:"packageName"`
+			PythonVersion string       `yaml:"pythonVersion"`
+			Vendor        *vendorBlock `yaml:"vendor,omitempty"`
+		} `yaml:"python,omitempty"`
+		Kotlin *struct {
+			PackageName string       `yaml:"packageName"`
+			JvmVersion  string       `yaml:"jvmVersion"`
+			Vendor      *vendorBlock `yaml:"vendor,omitempty"`
+		} `yaml:"kotlin,omitempty"`
 	}{}
 
 	switch ans.Language {
@@ -716,6 +744,26 @@ func buildADL(ans answers) *adlData {
 			NodeVersion: "24",
 			Vendor:      &vendorBlock{Deps: []string{}, Devdeps: []string{}},
 		}
+	case "python":
+		adl.Spec.Language.Python = &struct {
+			PackageName   string       `yaml:"packageName"`
+			PythonVersion string       `yaml:"pythonVersion"`
+			Vendor        *vendorBlock `yaml:"vendor,omitempty"`
+		}{
+			PackageName:   ans.PythonName,
+			PythonVersion: ans.PythonVersion,
+			Vendor:        &vendorBlock{Deps: []string{}, Devdeps: []string{}},
+		}
+	case "kotlin":
+		adl.Spec.Language.Kotlin = &struct {
+			PackageName string       `yaml:"packageName"`
+			JvmVersion  string       `yaml:"jvmVersion"`
+			Vendor      *vendorBlock `yaml:"vendor,omitempty"`
+		}{
+			PackageName: ans.KotlinPackage,
+			JvmVersion:  ans.JvmVersion,
+			Vendor:      &vendorBlock{Deps: []string{}, Devdeps: []string{}},
+		}
 	default:
 		adl.Spec.Language.Go = &struct {
 			Module  string       `yaml:"module"`
@@ -1150,6 +1198,12 @@ func collectAnswersNonInteractive(projectName string, useDefaults bool) answers
 		ans.RustEdition = promptWithConfig("rust-edition", useDefaults, "Rust edition", "2024")
 	case "typescript":
 		ans.TSPackageName = promptWithConfig("typescript-name", useDefaults, "TypeScript package name", ans.Name)
+	case "python":
+		ans.PythonName = promptWithConfig("python-name", useDefaults, "Python package name", ans.Name)
+		ans.PythonVersion = promptWithConfig("python-version", useDefaults, "Python version", "3.13")
+	case "kotlin":
+		ans.KotlinPackage = promptWithConfig("kotlin-package", useDefaults, "Kotlin package", getDefaultKotlinPackage(ans.Name))
+		ans.JvmVersion = promptWithConfig("jvm-version", useDefaults, "JVM version", "21")
 	default:
 		ans.GoModule = promptWithConfig("go-module", useDefaults, "Go module", getDefaultGoModule(ans.Name))
 		ans.GoVersion = promptWithConfig("go-version", useDefaults, "Go version", "1.26.4")
@@ -1288,6 +1342,16 @@ func getDefaultGoModule(projectName string) string {
 	return fmt.Sprintf("github.com/%s/%s", owner, projectName)
 }
 
+// getDefaultKotlinPackage derives a Kotlin package from the project name,
+// e.g. "com.example.weather_agent" for "weather-agent".
+func getDefaultKotlinPackage(projectName string) string {
+	name := strings.ToLower(strings.NewReplacer("-", "_", ".", "_", " ", "_").Replace(projectName))
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		name = "agent" + name
+	}
+	return "com.example." + name
+}
+
 // echoRow prints a resolved field as a styled "label › value" row. It is used
 // only on the non-interactive (--defaults / flag-driven) path; the interactive
 // readline branches below never call it.
@@ -439,6 +439,8 @@ func collectLanguage(ans *answers) {
 					huh.NewOption("TypeScript", "typescript"),
 					huh.NewOption("Go", "go"),
 					huh.NewOption("Rust", "rust"),
+					huh.NewOption("Python", "python"),
+					huh.NewOption("Kotlin", "kotlin"),
 				).
 				Value(&language),
 		})
@@ -470,6 +472,35 @@ func collectLanguage(ans *answers) {
 			runFields([]huh.Field{huh.NewInput().Title("TypeScript package name").Value(&pkg)})
 		}
 		ans.TSPackageName = pkg
+	case "python":
+		pkg, pkgLocked := wzString("python-name", ans.Name)
+		ver, verLocked := wzString("python-version", "3.13")
+		var fields []huh.Field
+		if !pkgLocked {
+			fields = append(fields, huh.NewInput().Title("Python package name").Value(&pkg))
+		}
+		if !verLocked {
+			fields = append(fields, huh.NewInput().Title("Python version").Value(&ver))
+		}
+		runFields(fields)
+		ans.PythonName = pkg
+		ans.PythonVersion = ver
+	case "kotlin":
+		pkg, pkgLocked := wzString("kotlin-package", getDefaultKotlinPackage(ans.Name))
+		ver, verLocked := wzString("jvm-version", "21")
+		var fields []huh.Field
+		if !pkgLocked {
+			fields = append(fields, huh.NewInput().
+				Title("Kotlin package").
+				Description("e.g. com.example.agent").
+				Value(&pkg))
+		}
+		if !verLocked {
+			fields = append(fields, huh.NewInput().Title("JVM version").Value(&ver))
+		}
+		runFields(fields)
+		ans.KotlinPackage = pkg
+		ans.JvmVersion = ver
 	default:
 		module, moduleLocked := wzString("go-module", getDefaultGoModule(ans.Name))
 		ver, verLocked := wzString("go-version", "1.26.4")
@@ -946,6 +977,10 @@ func printInitSummary(adl *adlData, adlFile, projectDir string) {
 			lang = "rust"
 		case adl.Spec.Language.TypeScript != nil:
 			lang = "typescript"
+		case adl.Spec.Language.Python != nil:
+			lang = "python"
+		case adl.Spec.Language.Kotlin != nil:
+			lang = "kotlin"
 		}
 	}
 	agentType := "minimal"
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+	"github.com/spf13/cobra"
+)
+
+// lintCmd represents the lint command
+var lintCmd = &cobra.Command{
+	Use:   "lint [adl-file]",
+	Short: "Check an ADL file for risky or ineffective configuration",
+	Long: `Validate an Agent Definition Language (ADL) file and run opinionated lint
+rules on it: unrestricted bash or fetch built-ins, allow-all authorization
+behind authentication, skills without the read built-in, MCP servers the
+agent cannot reach, and more. Run 'adl lint --list-rules' for the full list.
+
+The command exits non-zero when any finding is reported. Disable a rule for
+the whole manifest with a comment anywhere in the file:
+
+  # adl-lint-disable bash-unrestricted, fetch-unrestricted
+
+or for a single line by adding '# adl-lint-disable-line <rule>' to it.
+--fix applies the autofix of every rule that has one and rewrites the file
+in place, preserving comments.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runLint,
+}
+
+var (
+	lintFormat    string
+	lintFix       bool
+	lintListRules bool
+)
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+
+	lintCmd.Flags().StringVar(&lintFormat, "format", formatText, "Output format: text, json or sarif")
+	lintCmd.Flags().BoolVar(&lintFix, "fix", false, "Apply available autofixes and rewrite the file in place")
+	lintCmd.Flags().BoolVar(&lintListRules, "list-rules", false, "List the lint rules and exit")
+}
+
+func runLint(cmd *cobra.Command, args []string) error {
+	w := cmd.OutOrStdout()
+	if lintListRules {
+		for _, r := range schema.LintRules() {
+			fix := ""
+			if r.Fix != nil {
+				fix = " (autofix)"
+			}
+			_, _ = fmt.Fprintf(w, "%s [%s]%s\n  %s\n  %s\n\n", r.ID, r.Severity, fix, r.Summary, r.Rationale)
+		}
+		return nil
+	}
+
+	if err := checkFormat(lintFormat); err != nil {
+		return err
+	}
+
+	adlFile := "agent.yaml"
+	if len(args) > 0 {
+		adlFile = args[0]
+	}
+
+	info, err := os.Stat(adlFile)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("ADL file '%s' does not exist", adlFile)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to stat ADL file: %w", err)
+	}
+
+	data, err := os.ReadFile(adlFile)
+	if err != nil {
+		return fmt.Errorf("failed to read ADL file: %w", err)
+	}
+
+	validator := schema.NewValidator()
+	diags := validator.Lint(data)
+
+	if lintFix && !schema.HasErrors(diags) {
+		fixed, rules, err := schema.LintFix(data, diags)
+		if err != nil {
+			return fmt.Errorf("failed to fix '%s': %w", adlFile, err)
+		}
+		if len(rules) > 0 {
+			if err := os.WriteFile(adlFile, fixed, info.Mode().Perm()); err != nil {
+				return fmt.Errorf("failed to write ADL file: %w", err)
+			}
+			for _, id := range rules {
+				fmt.Fprintf(os.Stderr, "🔧 Fixed %s in '%s'\n", id, adlFile)
+			}
+			diags = validator.Lint(fixed)
+		}
+	}
+
+	if err := writeDiagnostics(w, lintFormat, adlFile, diags); err != nil {
+		return err
+	}
+
+	if len(diags) > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d lint finding(s) in '%s'", len(diags), adlFile)
+	}
+	if lintFormat == formatText {
+		_, _ = fmt.Fprintf(w, "✅ '%s' has no lint findings\n", adlFile)
+	}
+	return nil
+}
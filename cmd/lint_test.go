@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLintCommand(t *testing.T) {
+	adlContent := `apiVersion: adl.inference-gateway.com/v1
+kind: Agent
+metadata:
+  name: lint-agent
+  description: Lint agent
+  version: 1.0.0
+spec:
+  capabilities:
+    streaming: true
+    pushNotifications: false
+    stateTransitionHistory: false
+  agent:
+    provider: deepseek
+    model: deepseek-v4-flash
+  server:
+    port: 8080
+  language:
+    go:
+      module: github.com/test/lint-agent
+      version: "1.26.4"
+  skills:
+    - id: triage
+      bare: true
+      name: triage
+      description: How to triage incidents
+`
+	adlPath := filepath.Join(t.TempDir(), "agent.yaml")
+	if err := os.WriteFile(adlPath, []byte(adlContent), 0644); err != nil {
+		t.Fatalf("failed to write ADL file: %v", err)
+	}
+
+	originalFormat, originalFix := lintFormat, lintFix
+	defer func() {
+		lintFormat, lintFix = originalFormat, originalFix
+		lintCmd.SetOut(nil)
+	}()
+	lintFormat = formatText
+
+	var out bytes.Buffer
+	lintCmd.SetOut(&out)
+
+	lintFix = false
+	if err := runLint(lintCmd, []string{adlPath}); err == nil {
+		t.Fatal("expected lint to fail on skills without the read built-in")
+	}
+	if !strings.Contains(out.String(), "agent.yaml:21:3: warning: ") || !strings.Contains(out.String(), "[skills-need-read]") {
+		t.Errorf("unexpected lint output:\n%s", out.String())
+	}
+
+	out.Reset()
+	lintFix = true
+	if err := runLint(lintCmd, []string{adlPath}); err != nil {
+		t.Fatalf("expected --fix to resolve every finding, got: %v\n%s", err, out.String())
+	}
+	data, err := os.ReadFile(adlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "- id: read") {
+		t.Errorf("expected the read built-in to be added:\n%s", data)
+	}
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/inference-gateway/adl-cli/internal/lsp"
+	"github.com/spf13/cobra"
+)
+
+// lspCmd represents the lsp command
+var lspCmd = &cobra.Command{
+	Use:   "lsp",
+	Short: "Run the ADL language server over stdio",
+	Long: `Run a Language Server Protocol server for ADL files over stdin/stdout.
+Point your editor's LSP client at 'adl lsp' for YAML files named agent.yaml
+to get:
+
+  - diagnostics from 'adl validate' and 'adl lint' as you type
+  - completion of keys and of allowed values (providers, deployment types,
+    reserved tool IDs, service types, ...)
+  - hover documentation from the schema descriptions
+  - go-to-definition from spec.tools[].inject entries to spec.services`,
+	Args: cobra.NoArgs,
+	RunE: runLSP,
+}
+
+func init() {
+	rootCmd.AddCommand(lspCmd)
+
+	// Many LSP clients pass --stdio unconditionally; it is the only transport.
+	lspCmd.Flags().Bool("stdio", true, "Communicate over stdin/stdout (the only supported transport)")
+	_ = lspCmd.Flags().MarkHidden("stdio")
+}
+
+func runLSP(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	return lsp.NewServer(os.Stdin, os.Stdout).Run()
+}
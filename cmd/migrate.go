@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+	"github.com/spf13/cobra"
+)
+
+// migrateCmd represents the migrate command
+var migrateCmd = &cobra.Command{
+	Use:   "migrate [adl-file]",
+	Short: "Rewrite a legacy ADL file into the current schema shape",
+	Long: `Rewrite an Agent Definition Language (ADL) file that uses superseded schema
+shapes into the current shape, in place. Comments and key order are preserved.
+
+The following migrations are applied in order:
+  - spec.sandbox / spec.ai move under spec.development (ADL v0.6.0)
+  - spec.development.ai.enabled becomes orchestrators.claudecode.enabled (ADL v0.8.0)
+  - flat spec.development.ai.<agent> toggles nest under orchestrators
+
+Use --dry-run to print the changes without writing the file.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMigrate,
+}
+
+var (
+	migrateDryRun bool
+	migrateList   bool
+)
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+
+	migrateCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "Print the changes without writing the file")
+	migrateCmd.Flags().BoolVar(&migrateList, "list", false, "List the available migrations and exit")
+}
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	w := cmd.OutOrStdout()
+	if migrateList {
+		for _, m := range schema.Migrations() {
+			_, _ = fmt.Fprintf(w, "%-20s %s\n", m.ID, m.Description)
+		}
+		return nil
+	}
+
+	adlFile := "agent.yaml"
+	if len(args) > 0 {
+		adlFile = args[0]
+	}
+
+	info, err := os.Stat(adlFile)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("ADL file '%s' does not exist", adlFile)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to stat ADL file: %w", err)
+	}
+
+	data, err := os.ReadFile(adlFile)
+	if err != nil {
+		return fmt.Errorf("failed to read ADL file: %w", err)
+	}
+
+	migrated, changes, err := schema.Migrate(data)
+	if err != nil {
+		return fmt.Errorf("failed to migrate '%s': %w", adlFile, err)
+	}
+	if len(changes) == 0 {
+		_, _ = fmt.Fprintf(w, "✅ '%s' already uses the current schema shape\n", adlFile)
+		return nil
+	}
+
+	for _, c := range changes {
+		_, _ = fmt.Fprintf(w, "🔧 [%s] %s\n", c.Migration, c.Change)
+	}
+
+	if migrateDryRun {
+		_, _ = fmt.Fprintf(w, "Dry run: %d change(s) would be written to '%s'\n", len(changes), adlFile)
+		return nil
+	}
+
+	if err := os.WriteFile(adlFile, migrated, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write ADL file: %w", err)
+	}
+	_, _ = fmt.Fprintf(w, "✅ Migrated '%s' (%d change(s)); run 'adl validate %s' to check the result\n", adlFile, len(changes), adlFile)
+	return nil
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+)
+
+func TestMigrateCommand(t *testing.T) {
+	legacy := `apiVersion: adl.inference-gateway.com/v1
+kind: Agent
+metadata:
+  name: legacy-agent
+  description: Legacy agent
+  version: 1.0.0
+spec:
+  capabilities:
+    streaming: true
+    pushNotifications: false
+    stateTransitionHistory: false
+  server:
+    port: 8080
+  language:
+    go:
+      module: github.com/test/legacy-agent
+      version: "1.26.4"
+  # Claude Code for local development
+  ai:
+    enabled: true
+`
+	adlPath := filepath.Join(t.TempDir(), "agent.yaml")
+	if err := os.WriteFile(adlPath, []byte(legacy), 0644); err != nil {
+		t.Fatalf("failed to write ADL file: %v", err)
+	}
+
+	originalDryRun := migrateDryRun
+	defer func() {
+		migrateDryRun = originalDryRun
+		migrateCmd.SetOut(nil)
+	}()
+
+	var out bytes.Buffer
+	migrateCmd.SetOut(&out)
+
+	migrateDryRun = true
+	if err := runMigrate(migrateCmd, []string{adlPath}); err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if data, _ := os.ReadFile(adlPath); string(data) != legacy {
+		t.Fatal("--dry-run must not rewrite the file")
+	}
+
+	migrateDryRun = false
+	out.Reset()
+	if err := runMigrate(migrateCmd, []string{adlPath}); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "[v0.8.0-ai-enabled]") {
+		t.Errorf("expected the applied migrations to be listed, got:\n%s", out.String())
+	}
+
+	data, err := os.ReadFile(adlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "# Claude Code for local development") {
+		t.Errorf("comments must be preserved, got:\n%s", data)
+	}
+	if _, err := schema.NewValidator().ValidateFile(adlPath); err != nil {
+		t.Errorf("migrated manifest does not validate: %v\n%s", err, data)
+	}
+
+	out.Reset()
+	if err := runMigrate(migrateCmd, []string{adlPath}); err != nil {
+		t.Fatalf("second migrate failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "already uses the current schema shape") {
+		t.Errorf("expected a no-op on an up-to-date manifest, got:\n%s", out.String())
+	}
+}
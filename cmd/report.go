@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+)
+
+// Diagnostic output formats accepted by --format.
+const (
+	formatText  = "text"
+	formatJSON  = "json"
+	formatSARIF = "sarif"
+)
+
+// checkFormat rejects unknown --format values before any work is done.
+func checkFormat(format string) error {
+	switch format {
+	case formatText, formatJSON, formatSARIF:
+		return nil
+	}
+	return fmt.Errorf("unknown format '%s' (expected %s, %s or %s)", format, formatText, formatJSON, formatSARIF)
+}
+
+// writeDiagnostics renders diags for file in the requested format.
+func writeDiagnostics(w io.Writer, format, file string, diags []schema.Diagnostic) error {
+	switch format {
+	case formatText:
+		writeDiagnosticsText(w, file, diags)
+		return nil
+	case formatJSON:
+		return writeDiagnosticsJSON(w, file, diags)
+	case formatSARIF:
+		return writeDiagnosticsSARIF(w, file, diags)
+	default:
+		return checkFormat(format)
+	}
+}
+
+// writeDiagnosticsText prints one compiler-style line per diagnostic
+// (file:line:col: severity: message [rule]) so editors can jump to it.
+func writeDiagnosticsText(w io.Writer, file string, diags []schema.Diagnostic) {
+	for _, d := range diags {
+		loc := file
+		if d.Line > 0 {
+			loc = fmt.Sprintf("%s:%d:%d", file, d.Line, d.Column)
+		}
+		_, _ = fmt.Fprintf(w, "%s: %s: %s [%s]\n", loc, d.Severity, d.Message, d.RuleID)
+	}
+}
+
+func writeDiagnosticsJSON(w io.Writer, file string, diags []schema.Diagnostic) error {
+	if diags == nil {
+		diags = []schema.Diagnostic{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		File        string              `json:"file"`
+		Valid       bool                `json:"valid"`
+		Diagnostics []schema.Diagnostic `json:"diagnostics"`
+	}{file, !schema.HasErrors(diags), diags})
+}
+
+// sarifLog is the subset of SARIF 2.1.0 that GitHub code scanning reads.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+func writeDiagnosticsSARIF(w io.Writer, file string, diags []schema.Diagnostic) error {
+	seen := make(map[string]bool)
+	rules := []sarifRule{}
+	results := []sarifResult{}
+	for _, d := range diags {
+		if !seen[d.RuleID] {
+			seen[d.RuleID] = true
+			rules = append(rules, sarifRule{ID: d.RuleID})
+		}
+		loc := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(file)}}
+		if d.Line > 0 {
+			loc.Region = &sarifRegion{StartLine: d.Line, StartColumn: d.Column}
+		}
+		results = append(results, sarifResult{
+			RuleID:    d.RuleID,
+			Level:     sarifLevel(d.Severity),
+			Message:   sarifMessage{Text: d.Message},
+			Locations: []sarifLocation{{PhysicalLocation: loc}},
+		})
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "adl",
+				Version:        version,
+				InformationURI: "https://github.com/inference-gateway/adl-cli",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	})
+}
+
+func sarifLevel(s schema.Severity) string {
+	if s == schema.SeverityError {
+		return "error"
+	}
+	return "warning"
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+	"github.com/spf13/cobra"
+)
+
+// schemaCmd represents the schema command
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Work with the ADL JSON Schema embedded in the CLI",
+}
+
+// schemaExportCmd represents the schema export command
+var schemaExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write the embedded ADL JSON Schema to disk",
+	Long: `Write the ADL JSON Schema this CLI validates against to disk, so editors
+can use it for completion and inline validation. With the YAML language
+server (VS Code YAML extension, Neovim yamlls, ...) add a modeline to the
+top of the manifest:
+
+  # yaml-language-server: $schema=./adl.schema.json
+
+Use --output - to write the schema to stdout.`,
+	Args: cobra.NoArgs,
+	RunE: runSchemaExport,
+}
+
+var schemaOutput string
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+	schemaCmd.AddCommand(schemaExportCmd)
+
+	schemaExportCmd.Flags().StringVarP(&schemaOutput, "output", "o", "adl.schema.json", "File to write the schema to, or - for stdout")
+}
+
+func runSchemaExport(cmd *cobra.Command, args []string) error {
+	data := schema.SchemaJSON()
+	if schemaOutput == "-" {
+		_, err := cmd.OutOrStdout().Write(data)
+		return err
+	}
+
+	if dir := filepath.Dir(schemaOutput); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(schemaOutput, data, 0644); err != nil {
+		return fmt.Errorf("failed to write schema: %w", err)
+	}
+
+	w := cmd.OutOrStdout()
+	_, _ = fmt.Fprintf(w, "✅ Wrote ADL schema to '%s'\n", schemaOutput)
+	_, _ = fmt.Fprintf(w, "   Add this modeline to the top of your ADL file for editor support:\n")
+	_, _ = fmt.Fprintf(w, "   # yaml-language-server: $schema=%s\n", schemaModelinePath(schemaOutput))
+	return nil
+}
+
+// schemaModelinePath renders a relative output path the way the YAML
+// language server expects it ("./adl.schema.json").
+func schemaModelinePath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return "./" + filepath.ToSlash(filepath.Clean(path))
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+)
+
+func TestSchemaExportCommand(t *testing.T) {
+	originalOutput := schemaOutput
+	defer func() {
+		schemaOutput = originalOutput
+		schemaExportCmd.SetOut(nil)
+	}()
+
+	var out bytes.Buffer
+	schemaExportCmd.SetOut(&out)
+
+	schemaOutput = filepath.Join(t.TempDir(), "schemas", "adl.schema.json")
+	if err := runSchemaExport(schemaExportCmd, nil); err != nil {
+		t.Fatalf("runSchemaExport() failed: %v", err)
+	}
+	data, err := os.ReadFile(schemaOutput)
+	if err != nil {
+		t.Fatalf("schema was not written: %v", err)
+	}
+	if !bytes.Equal(data, schema.SchemaJSON()) {
+		t.Error("exported schema differs from the embedded schema")
+	}
+	if !strings.Contains(out.String(), "# yaml-language-server: $schema="+schemaOutput) {
+		t.Errorf("expected a modeline hint:\n%s", out.String())
+	}
+
+	out.Reset()
+	schemaOutput = "-"
+	if err := runSchemaExport(schemaExportCmd, nil); err != nil {
+		t.Fatalf("runSchemaExport() failed: %v", err)
+	}
+	if !bytes.Equal(out.Bytes(), schema.SchemaJSON()) {
+		t.Error("--output - must write the schema to stdout unchanged")
+	}
+}
+
+func TestSchemaModelinePath(t *testing.T) {
+	cases := map[string]string{
+		"adl.schema.json":          "./adl.schema.json",
+		"schemas/../adl.json":      "./adl.json",
+		"/etc/adl/adl.schema.json": "/etc/adl/adl.schema.json",
+	}
+	for in, want := range cases {
+		if got := schemaModelinePath(in); got != want {
+			t.Errorf("schemaModelinePath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
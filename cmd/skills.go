@@ -0,0 +1,670 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/inference-gateway/adl-cli/internal/registry"
+	"github.com/inference-gateway/adl-cli/internal/schema"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// skillsCmd represents the skills command
+var skillsCmd = &cobra.Command{
+	Use:   "skills",
+	Short: "Manage the skills declared in an ADL file",
+	Long: `Manage spec.skills in an Agent Definition Language (ADL) file: list the
+declared skills, add or remove them, re-fetch and bump pinned versions,
+inspect a skill's SKILL.md frontmatter and publish bare skills to the registry.
+
+Skills without a source are fetched from the skills registries
+(~/.adl/registries.yaml or ADL_SKILLS_REGISTRY, tried in order). Skills with a source are fetched from GitHub, any git
+remote or a local directory; the source accepts the same forms as
+'adl skills add':
+  <skill>[@<tag>]                            inference-gateway/skills repository
+  <owner>/<repo>/<skill>[@<tag>]             skills/<skill> in any public repository
+  https://github.com/<owner>/<repo>/tree/<ref>/<path>
+  git+<scheme>://<remote>[//<path>][@<ref>]  any git remote (GitLab, Gitea, SSH, ...)
+  ./<path>, ../<path>, /<path>, file://<path>  a directory, relative to the ADL file`,
+}
+
+var skillsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the declared skills with their version, source and cache status",
+	Args:  cobra.NoArgs,
+	RunE:  runSkillsList,
+}
+
+var skillsAddCmd = &cobra.Command{
+	Use:   "add <id|owner/repo/skill[@tag]|url>",
+	Short: "Add a skill to spec.skills",
+	Long: `Add a skill to spec.skills. A plain ID adds a registry skill (pin it with
+--version); anything else is recorded as the skill's source and expanded the
+same way as during generation. The skill is fetched first so typos fail
+early, the cache is warm for 'adl generate' and its content is recorded in
+adl.lock; --offline skips the fetch and leaves locking to 'adl generate'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSkillsAdd,
+}
+
+var skillsUpdateCmd = &cobra.Command{
+	Use:   "update [id...]",
+	Short: "Re-fetch skills and bump pinned versions to the latest release",
+	Long: `Re-fetch every non-bare skill (or only the given ones), overwriting the
+cache and the skill's entry in adl.lock. Registry skills pinned with
+version: and GitHub sources pinned to a semver tag (@v1.2.0) are bumped to
+the newest release first; skills that track a branch or the registry
+default are re-fetched at their current head. This is the only command that
+changes the locked content of a skill already in adl.lock.`,
+	RunE: runSkillsUpdate,
+}
+
+var skillsRemoveCmd = &cobra.Command{
+	Use:     "remove <id>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a skill from spec.skills",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runSkillsRemove,
+}
+
+var skillsShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show the SKILL.md frontmatter of a skill",
+	Long: `Show the parsed SKILL.md frontmatter and files of a skill. Declared skills
+are resolved the way 'adl generate' resolves them; any other ID is looked up
+in the skills registry.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSkillsShow,
+}
+
+var skillsOutdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "List skills with newer releases available",
+	Long: `List the skills with newer releases than the ones in use. CURRENT is the
+version in adl.lock (or the declared pin), WANTED the newest release the
+declared version or range allows and LATEST the newest release published.
+'adl skills update' moves skills with a range to WANTED and skills pinned to
+an exact version or tag to LATEST.`,
+	Args: cobra.NoArgs,
+	RunE: runSkillsOutdated,
+}
+
+var skillsSearchCmd = &cobra.Command{
+	Use:   "search [query]",
+	Short: "Search the skills registries for available skills",
+	Long: `List the skills the configured registries serve, with their versions and
+descriptions. A query filters by ID, name, description and tags. Registries
+are read from ~/.adl/registries.yaml and ADL_SKILLS_REGISTRY, in order; a
+skill several registries serve is shown once, from the first.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSkillsSearch,
+}
+
+var skillsPublishCmd = &cobra.Command{
+	Use:   "publish <dir>",
+	Short: "Publish a skill directory to the skills registry",
+	Long: `Publish a skill directory, typically a bare skill scaffolded under
+.agents/skills/<id>, to the skills registry. Its SKILL.md frontmatter must
+declare a name, description, license and MAJOR.MINOR.PATCH version. The
+directory is packaged as a tarball and uploaded with a PUT to
+<registry>/<id>/<version>.tar.gz, using the credentials configured for the
+registry. The primary registry is used unless --registry names another.
+
+Once published, the skill's entry in the ADL file is rewritten from bare: true
+to a registry reference pinned to the published version.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSkillsPublish,
+}
+
+var (
+	skillsFile     string
+	skillsVersion  string
+	skillsID       string
+	skillsOffline  bool
+	skillsBody     bool
+	skillsRegistry string
+	skillsDryRun   bool
+	// skillsPublishID is publish's --id; add's is skillsID.
+	skillsPublishID string
+)
+
+// newSkillsResolver builds the resolver used by the skills commands;
+// tests replace it to point at a local registry.
+var newSkillsResolver = registry.NewDefaultResolver
+
+func init() {
+	rootCmd.AddCommand(skillsCmd)
+	skillsCmd.AddCommand(skillsListCmd, skillsAddCmd, skillsUpdateCmd, skillsRemoveCmd, skillsShowCmd, skillsSearchCmd, skillsOutdatedCmd, skillsPublishCmd)
+
+	skillsCmd.PersistentFlags().StringVarP(&skillsFile, "file", "f", "agent.yaml", "ADL file to manage")
+	skillsAddCmd.Flags().StringVar(&skillsVersion, "version", "", "Pin a registry skill to this version or range (e.g. ^1.2)")
+	skillsAddCmd.Flags().StringVar(&skillsID, "id", "", "Skill ID to record (defaults to the skill's directory name)")
+	skillsAddCmd.Flags().BoolVar(&skillsOffline, "offline", false, "Do not fetch the skill before adding it")
+	skillsShowCmd.Flags().BoolVar(&skillsOffline, "offline", false, "Only use the local skills cache")
+	skillsShowCmd.Flags().BoolVar(&skillsBody, "body", false, "Also print the SKILL.md body")
+	skillsPublishCmd.Flags().StringVar(&skillsPublishID, "id", "", "Skill ID to publish as (defaults to the directory name)")
+	skillsPublishCmd.Flags().StringVar(&skillsRegistry, "registry", "", "Registry URL to publish to (defaults to the primary registry)")
+	skillsPublishCmd.Flags().BoolVar(&skillsDryRun, "dry-run", false, "Validate and package the skill without uploading it")
+}
+
+// readSkillsManifest reads the ADL file and decodes it.
+func readSkillsManifest() ([]byte, *schema.ADL, os.FileMode, error) {
+	info, err := os.Stat(skillsFile)
+	if os.IsNotExist(err) {
+		return nil, nil, 0, fmt.Errorf("ADL file '%s' does not exist", skillsFile)
+	}
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to stat ADL file: %w", err)
+	}
+	data, err := os.ReadFile(skillsFile)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to read ADL file: %w", err)
+	}
+	var adl schema.ADL
+	if err := yaml.Unmarshal(data, &adl); err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to parse ADL file: %w", err)
+	}
+	return data, &adl, info.Mode().Perm(), nil
+}
+
+// writeSkillsManifest writes the edited manifest and surfaces validator
+// warnings the edit introduced (e.g. skills without the read built-in).
+func writeSkillsManifest(w io.Writer, data []byte, perm os.FileMode) error {
+	if err := os.WriteFile(skillsFile, data, perm); err != nil {
+		return fmt.Errorf("failed to write ADL file: %w", err)
+	}
+	for _, d := range schema.NewValidator().Diagnose(data) {
+		if d.Severity == schema.SeverityWarning {
+			_, _ = fmt.Fprintf(w, "⚠️  %s\n", d.Message)
+		}
+	}
+	return nil
+}
+
+// loadSkillsLock reads the adl.lock next to the ADL file.
+func loadSkillsLock() (*registry.Lock, string, error) {
+	path := registry.LockPath(skillsFile)
+	lock, err := registry.LoadLock(path)
+	return lock, path, err
+}
+
+// writeSkillsLock stores the lock if the command changed it.
+func writeSkillsLock(w io.Writer, lock *registry.Lock, path string) error {
+	if !lock.Changed() {
+		return nil
+	}
+	if err := lock.Write(path); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(w, "🔒 Updated '%s'\n", path)
+	return nil
+}
+
+// skillsResolver builds a resolver that resolves local sources relative
+// to the ADL file.
+func skillsResolver() (*registry.Resolver, error) {
+	resolver, err := newSkillsResolver()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize skills resolver: %w", err)
+	}
+	resolver.BaseDir = filepath.Dir(skillsFile)
+	return resolver, nil
+}
+
+func findDeclaredSkill(adl *schema.ADL, id string) (schema.Skill, bool) {
+	for _, s := range adl.Spec.Skills {
+		if s.ID == id {
+			return s, true
+		}
+	}
+	return schema.Skill{}, false
+}
+
+func skillSource(s schema.Skill) string {
+	switch {
+	case s.Bare:
+		return "bare"
+	case s.Source != "":
+		return s.Source
+	}
+	return "registry"
+}
+
+func runSkillsList(cmd *cobra.Command, args []string) error {
+	_, adl, _, err := readSkillsManifest()
+	if err != nil {
+		return err
+	}
+	w := cmd.OutOrStdout()
+	if len(adl.Spec.Skills) == 0 {
+		_, _ = fmt.Fprintf(w, "No skills declared in '%s'\n", skillsFile)
+		return nil
+	}
+
+	resolver, err := skillsResolver()
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tVERSION\tSOURCE\tCACHE")
+	for _, s := range adl.Spec.Skills {
+		version, cache := s.Version, "-"
+		if !s.Bare {
+			files, ok, err := resolver.Cached(s)
+			switch {
+			case err != nil:
+				cache = "error: " + err.Error()
+			case registry.IsLocalSource(s.Source):
+				cache = "local"
+			case ok:
+				cache = "cached"
+				if doc, err := registry.ParseSkillDocument(files[registry.SkillFile]); err == nil && doc.Frontmatter.Version != "" && version == "" {
+					version = doc.Frontmatter.Version
+				}
+			default:
+				cache = "not cached"
+			}
+			if version == "" {
+				version = sourceRef(s)
+			}
+		}
+		if version == "" {
+			version = "latest"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", s.ID, version, skillSource(s), cache)
+	}
+	return tw.Flush()
+}
+
+// sourceRef returns the ref of a source-pulled skill, or "".
+func sourceRef(s schema.Skill) string {
+	if s.Source == "" {
+		return ""
+	}
+	loc, err := registry.ParseSource(s.Source)
+	if err != nil {
+		return ""
+	}
+	return loc.Ref
+}
+
+// skillFromTarget turns an `adl skills add` argument into a skill entry.
+func skillFromTarget(target, id, version string) (schema.Skill, error) {
+	isSource := strings.ContainsAny(target, "/@") || strings.Contains(target, "://")
+	if !isSource {
+		if id != "" && id != target {
+			return schema.Skill{}, fmt.Errorf("--id cannot rename a registry skill; the ID is what the registry serves")
+		}
+		return schema.Skill{ID: target, Version: version}, nil
+	}
+	if version != "" {
+		return schema.Skill{}, fmt.Errorf("--version only applies to registry skills; pin a source with @<tag> instead")
+	}
+
+	loc, err := registry.ParseSource(target)
+	if err != nil {
+		return schema.Skill{}, err
+	}
+	if id == "" {
+		id = path.Base(filepath.ToSlash(loc.Path))
+		if loc.Path == "" {
+			id = strings.TrimSuffix(path.Base(loc.Repo), ".git")
+		}
+	}
+	if id == "" || id == "." || id == "/" {
+		return schema.Skill{}, fmt.Errorf("cannot derive a skill ID from %s; pass --id", target)
+	}
+	return schema.Skill{ID: id, Source: target}, nil
+}
+
+func runSkillsAdd(cmd *cobra.Command, args []string) error {
+	data, adl, perm, err := readSkillsManifest()
+	if err != nil {
+		return err
+	}
+	skill, err := skillFromTarget(args[0], skillsID, skillsVersion)
+	if err != nil {
+		return err
+	}
+	if _, ok := findDeclaredSkill(adl, skill.ID); ok {
+		return fmt.Errorf("skill %q is already declared in '%s'", skill.ID, skillsFile)
+	}
+
+	lock, lockPath, err := loadSkillsLock()
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if !skillsOffline {
+		resolver, err := skillsResolver()
+		if err != nil {
+			return err
+		}
+		resolver.Lock = lock
+		resolver.UpdateLock = true
+		resolved, err := resolver.Resolve(context.Background(), skill)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(w, "📦 Fetched %s (%s %s)\n", skill.ID, resolved.Name, resolved.Version)
+	}
+
+	out, err := schema.AddSkill(data, skill)
+	if err != nil {
+		return err
+	}
+	if err := writeSkillsManifest(w, out, perm); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(w, "✅ Added skill '%s' to '%s'\n", skill.ID, skillsFile)
+	return writeSkillsLock(w, lock, lockPath)
+}
+
+func runSkillsUpdate(cmd *cobra.Command, args []string) error {
+	data, adl, perm, err := readSkillsManifest()
+	if err != nil {
+		return err
+	}
+	for _, id := range args {
+		if _, ok := findDeclaredSkill(adl, id); !ok {
+			return fmt.Errorf("skill %q is not declared in '%s'", id, skillsFile)
+		}
+	}
+
+	resolver, err := skillsResolver()
+	if err != nil {
+		return err
+	}
+	resolver.Refresh = true
+	lock, lockPath, err := loadSkillsLock()
+	if err != nil {
+		return err
+	}
+	resolver.Lock = lock
+	resolver.UpdateLock = true
+
+	w := cmd.OutOrStdout()
+	ctx := context.Background()
+	changed := false
+	for _, s := range adl.Spec.Skills {
+		if s.Bare || len(args) > 0 && !slices.Contains(args, s.ID) {
+			continue
+		}
+
+		latest, err := resolver.LatestRef(ctx, s)
+		if err != nil {
+			return err
+		}
+		if latest != "" {
+			key, from, to := "version", s.Version, latest
+			if s.Source != "" {
+				key, from = "source", s.Source
+				if to, err = registry.WithRef(s.Source, latest); err != nil {
+					return err
+				}
+				s.Source = to
+			} else {
+				s.Version = to
+			}
+			if data, err = schema.SetSkillField(data, s.ID, key, to); err != nil {
+				return err
+			}
+			changed = true
+			_, _ = fmt.Fprintf(w, "⬆️  Bumped %s: %s → %s\n", s.ID, from, to)
+		}
+
+		resolved, err := resolver.Resolve(ctx, s)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(w, "🔄 Refreshed %s (%s)\n", s.ID, resolved.Version)
+	}
+
+	if changed {
+		if err := writeSkillsManifest(w, data, perm); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(w, "✅ Updated '%s'\n", skillsFile)
+	}
+	return writeSkillsLock(w, lock, lockPath)
+}
+
+func runSkillsRemove(cmd *cobra.Command, args []string) error {
+	data, _, perm, err := readSkillsManifest()
+	if err != nil {
+		return err
+	}
+	out, err := schema.RemoveSkill(data, args[0])
+	if err != nil {
+		return err
+	}
+	lock, lockPath, err := loadSkillsLock()
+	if err != nil {
+		return err
+	}
+	lock.Remove(args[0])
+
+	w := cmd.OutOrStdout()
+	if err := writeSkillsManifest(w, out, perm); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(w, "🗑️  Removed skill '%s' from '%s'\n", args[0], skillsFile)
+	_, _ = fmt.Fprintf(w, "   Run 'adl generate --prune' to delete its generated files\n")
+	return writeSkillsLock(w, lock, lockPath)
+}
+
+func runSkillsShow(cmd *cobra.Command, args []string) error {
+	skill := schema.Skill{ID: args[0]}
+	if _, adl, _, err := readSkillsManifest(); err == nil {
+		if declared, ok := findDeclaredSkill(adl, args[0]); ok {
+			skill = declared
+		}
+	}
+
+	w := cmd.OutOrStdout()
+	row := func(label, value string) {
+		if value != "" {
+			_, _ = fmt.Fprintf(w, "  %-12s %s\n", label+":", value)
+		}
+	}
+	_, _ = fmt.Fprintf(w, "%s\n\n", skill.ID)
+
+	if skill.Bare {
+		row("Name", skill.Name)
+		row("Description", skill.Description)
+		row("Version", skill.Version)
+		row("License", string(skill.License))
+		row("Tags", strings.Join(skill.Tags, ", "))
+		row("Source", "bare (SKILL.md is scaffolded by adl generate)")
+		return nil
+	}
+
+	resolver, err := skillsResolver()
+	if err != nil {
+		return err
+	}
+	resolver.Offline = skillsOffline
+	resolved, err := resolver.Resolve(context.Background(), skill)
+	if err != nil {
+		return err
+	}
+	doc, err := registry.ParseSkillDocument(resolved.Files[registry.SkillFile])
+	if err != nil {
+		return fmt.Errorf("skill %q: %w", skill.ID, err)
+	}
+
+	fm := doc.Frontmatter
+	row("Name", fm.Name)
+	row("Description", fm.Description)
+	row("Version", fm.Version)
+	row("License", fm.License)
+	row("Tags", strings.Join(fm.Tags, ", "))
+	row("Source", skillSource(skill))
+
+	files := make([]string, 0, len(resolved.Files))
+	for name := range resolved.Files {
+		files = append(files, name)
+	}
+	slices.Sort(files)
+	row("Files", strings.Join(files, ", "))
+
+	if skillsBody {
+		_, _ = fmt.Fprintf(w, "\n%s", doc.Body)
+	}
+	return nil
+}
+
+func runSkillsSearch(cmd *cobra.Command, args []string) error {
+	query := ""
+	if len(args) > 0 {
+		query = args[0]
+	}
+	resolver, err := skillsResolver()
+	if err != nil {
+		return err
+	}
+	entries, err := resolver.Client.Search(context.Background(), query)
+	if err != nil && len(entries) == 0 {
+		return fmt.Errorf("failed to search the skills registries: %w", err)
+	}
+
+	w := cmd.OutOrStdout()
+	if err != nil {
+		_, _ = fmt.Fprintf(w, "⚠️  Some registries could not be searched: %v\n", err)
+	}
+	if len(entries) == 0 {
+		if query == "" {
+			_, _ = fmt.Fprintln(w, "No skills found in the registries")
+		} else {
+			_, _ = fmt.Fprintf(w, "No skills match '%s'\n", query)
+		}
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tVERSIONS\tDESCRIPTION")
+	for _, e := range entries {
+		versions := strings.Join(e.Versions, ", ")
+		if versions == "" {
+			versions = "latest"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", e.ID, versions, e.Description)
+	}
+	return tw.Flush()
+}
+
+func runSkillsOutdated(cmd *cobra.Command, args []string) error {
+	_, adl, _, err := readSkillsManifest()
+	if err != nil {
+		return err
+	}
+	resolver, err := skillsResolver()
+	if err != nil {
+		return err
+	}
+	lock, _, err := loadSkillsLock()
+	if err != nil {
+		return err
+	}
+	resolver.Lock = lock
+
+	w := cmd.OutOrStdout()
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	outdated := 0
+	for _, s := range adl.Spec.Skills {
+		up, err := resolver.Upgrades(context.Background(), s)
+		if err != nil {
+			return err
+		}
+		if up == nil || up.Current == up.Wanted && up.Current == up.Latest {
+			continue
+		}
+		if outdated == 0 {
+			_, _ = fmt.Fprintln(tw, "ID\tCURRENT\tWANTED\tLATEST")
+		}
+		outdated++
+		current := up.Current
+		if current == "" {
+			current = "-"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", s.ID, current, up.Wanted, up.Latest)
+	}
+	if outdated == 0 {
+		_, _ = fmt.Fprintln(w, "✅ All skills are up to date")
+		return nil
+	}
+	return tw.Flush()
+}
+
+func runSkillsPublish(cmd *cobra.Command, args []string) error {
+	id := skillsPublishID
+	if id == "" {
+		id = filepath.Base(filepath.Clean(args[0]))
+	}
+	pkg, err := registry.PackageSkill(id, args[0])
+	if err != nil {
+		return fmt.Errorf("skill %q cannot be published: %w", id, err)
+	}
+	version := pkg.Frontmatter.Version
+	w := cmd.OutOrStdout()
+	_, _ = fmt.Fprintf(w, "📦 Packaged %s@%s (%d files, %s)\n", id, version, len(pkg.Files), pkg.Frontmatter.License)
+	if skillsDryRun {
+		_, _ = fmt.Fprintln(w, "Dry run: nothing was uploaded")
+		return nil
+	}
+
+	// Read the manifest first so a broken one fails before the upload.
+	var data []byte
+	var adl *schema.ADL
+	var perm os.FileMode
+	if _, err := os.Stat(skillsFile); err == nil {
+		if data, adl, perm, err = readSkillsManifest(); err != nil {
+			return err
+		}
+	}
+
+	var client *registry.Client
+	if skillsRegistry != "" {
+		if client, err = registry.RegistryClient(skillsRegistry); err != nil {
+			return err
+		}
+	} else {
+		resolver, err := skillsResolver()
+		if err != nil {
+			return err
+		}
+		client = resolver.Client
+	}
+	target, err := client.Publish(context.Background(), pkg)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(w, "✅ Published %s@%s to %s\n", id, version, target)
+
+	if adl == nil {
+		return nil
+	}
+	if _, ok := findDeclaredSkill(adl, id); !ok {
+		_, _ = fmt.Fprintf(w, "Skill '%s' is not declared in '%s'; add it with 'adl skills add %s --version %s'\n", id, skillsFile, id, version)
+		return nil
+	}
+	out, err := schema.PinRegistrySkill(data, id, version)
+	if err != nil {
+		return err
+	}
+	if err := writeSkillsManifest(w, out, perm); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(w, "📝 '%s' now uses %s@%s from the registry\n", skillsFile, id, version)
+	return nil
+}
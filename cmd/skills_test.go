@@ -0,0 +1,353 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/inference-gateway/adl-cli/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+const skillsTestADL = `apiVersion: adl.inference-gateway.com/v1
+kind: Agent
+metadata:
+  name: skills-agent
+  description: Skills agent
+  version: 1.0.0
+spec:
+  capabilities:
+    streaming: true
+    pushNotifications: false
+    stateTransitionHistory: false
+  agent:
+    provider: openai
+    model: gpt-4o
+  server:
+    port: 8080
+  language:
+    go:
+      module: github.com/test/skills-agent
+      version: "1.26.4"
+  # skills the agent can load
+  skills:
+    - id: policy
+      bare: true
+      name: policy
+      description: Company policy
+`
+
+// useTestSkillsRegistry points the skills commands at a local registry
+// that serves data-analysis at version 1.4.0 (and 1.2.0 when pinned).
+func useTestSkillsRegistry(t *testing.T) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		version := "1.4.0"
+		switch r.URL.Path {
+		case "/skills/index.json":
+			_, _ = w.Write([]byte(`{"skills": [{"id": "data-analysis", "description": "Analyse data", "versions": ["1.2.0", "1.4.0"], "tags": ["analytics"]}]}`))
+			return
+		case "/skills/data-analysis.md", "/skills/data-analysis/1.4.0.md":
+		case "/skills/data-analysis/1.2.0.md":
+			version = "1.2.0"
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("---\nname: data-analysis\ndescription: Analyse data\nversion: " + version + "\nlicense: MIT\ntags: [analytics]\n---\nLoad the CSV first.\n"))
+	}))
+	t.Cleanup(srv.Close)
+
+	cacheDir := t.TempDir()
+	original := newSkillsResolver
+	newSkillsResolver = func() (*registry.Resolver, error) {
+		cache, err := registry.NewCache(cacheDir)
+		if err != nil {
+			return nil, err
+		}
+		return &registry.Resolver{
+			Client:    registry.NewClient(srv.URL + "/skills/"),
+			Installer: registry.NewInstaller(),
+			Cache:     cache,
+		}, nil
+	}
+	t.Cleanup(func() { newSkillsResolver = original })
+}
+
+func TestSkillsCommands(t *testing.T) {
+	useTestSkillsRegistry(t)
+
+	originalFile, originalVersion := skillsFile, skillsVersion
+	defer func() { skillsFile, skillsVersion = originalFile, originalVersion }()
+	skillsFile = filepath.Join(t.TempDir(), "agent.yaml")
+	if err := os.WriteFile(skillsFile, []byte(skillsTestADL), 0644); err != nil {
+		t.Fatalf("failed to write ADL file: %v", err)
+	}
+
+	var out bytes.Buffer
+	for _, c := range skillsCmd.Commands() {
+		c.SetOut(&out)
+		defer c.SetOut(nil)
+	}
+	read := func() string {
+		data, err := os.ReadFile(skillsFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	locked := func() (registry.LockedSkill, bool) {
+		lock, err := registry.LoadLock(registry.LockPath(skillsFile))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return lock.Skill("data-analysis")
+	}
+
+	skillsVersion = "1.2.0"
+	if err := runSkillsAdd(skillsAddCmd, []string{"data-analysis"}); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+	skillsVersion = ""
+	if !strings.Contains(read(), "    - id: data-analysis\n      version: 1.2.0\n") {
+		t.Errorf("skill not appended to spec.skills:\n%s", read())
+	}
+	if !strings.Contains(read(), "# skills the agent can load") {
+		t.Error("comments must survive the edit")
+	}
+	if !strings.Contains(out.String(), "⚠️") || !strings.Contains(out.String(), "'- id: read'") {
+		t.Errorf("expected the skills-need-read warning after adding a skill:\n%s", out.String())
+	}
+
+	if entry, ok := locked(); !ok || entry.Declared != "data-analysis@1.2.0" || entry.Version != "1.2.0" || entry.SHA256["SKILL.md"] == "" {
+		t.Errorf("expected adl.lock to record the added skill, got %+v (ok=%v)", entry, ok)
+	}
+
+	if err := runSkillsAdd(skillsAddCmd, []string{"data-analysis"}); err == nil {
+		t.Error("expected adding a duplicate skill to fail")
+	}
+
+	out.Reset()
+	if err := runSkillsList(skillsListCmd, nil); err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "ID") {
+		t.Fatalf("unexpected list output:\n%s", out.String())
+	}
+	if fields := strings.Fields(lines[1]); strings.Join(fields, " ") != "policy latest bare -" {
+		t.Errorf("bare skill row = %q", lines[1])
+	}
+	if fields := strings.Fields(lines[2]); strings.Join(fields, " ") != "data-analysis 1.2.0 registry cached" {
+		t.Errorf("registry skill row = %q", lines[2])
+	}
+
+	out.Reset()
+	if err := runSkillsUpdate(skillsUpdateCmd, nil); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Bumped data-analysis: 1.2.0 → 1.4.0") {
+		t.Errorf("expected the pinned version to be bumped:\n%s", out.String())
+	}
+	if !strings.Contains(read(), "version: 1.4.0") {
+		t.Errorf("bumped version not written:\n%s", read())
+	}
+	if entry, ok := locked(); !ok || entry.Declared != "data-analysis@1.4.0" || entry.Version != "1.4.0" {
+		t.Errorf("expected update to refresh the lock entry, got %+v (ok=%v)", entry, ok)
+	}
+
+	out.Reset()
+	skillsBody = true
+	defer func() { skillsBody = false }()
+	if err := runSkillsShow(skillsShowCmd, []string{"data-analysis"}); err != nil {
+		t.Fatalf("show failed: %v", err)
+	}
+	for _, want := range []string{"Analyse data", "Version:     1.4.0", "License:     MIT", "Tags:        analytics", "Files:       SKILL.md", "Load the CSV first."} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("show output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	if err := runSkillsRemove(skillsRemoveCmd, []string{"data-analysis"}); err != nil {
+		t.Fatalf("remove failed: %v", err)
+	}
+	if strings.Contains(read(), "data-analysis") {
+		t.Errorf("skill not removed:\n%s", read())
+	}
+	if _, ok := locked(); ok {
+		t.Error("expected remove to drop the skill from adl.lock")
+	}
+	if err := runSkillsRemove(skillsRemoveCmd, []string{"data-analysis"}); err == nil {
+		t.Error("expected removing an undeclared skill to fail")
+	}
+}
+
+func TestSkillsSearch(t *testing.T) {
+	useTestSkillsRegistry(t)
+
+	var out bytes.Buffer
+	skillsSearchCmd.SetOut(&out)
+	defer skillsSearchCmd.SetOut(nil)
+
+	if err := runSkillsSearch(skillsSearchCmd, []string{"analytics"}); err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || strings.Join(strings.Fields(lines[1]), " ") != "data-analysis 1.4.0, 1.2.0 Analyse data" {
+		t.Errorf("unexpected search output:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := runSkillsSearch(skillsSearchCmd, []string{"pdf"}); err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "No skills match 'pdf'") {
+		t.Errorf("unexpected output for an unmatched query:\n%s", out.String())
+	}
+}
+
+func TestSkillsOutdated(t *testing.T) {
+	useTestSkillsRegistry(t)
+
+	originalFile, originalVersion := skillsFile, skillsVersion
+	defer func() { skillsFile, skillsVersion = originalFile, originalVersion }()
+	skillsFile = filepath.Join(t.TempDir(), "agent.yaml")
+	if err := os.WriteFile(skillsFile, []byte(skillsTestADL), 0644); err != nil {
+		t.Fatalf("failed to write ADL file: %v", err)
+	}
+
+	var out bytes.Buffer
+	for _, c := range []*cobra.Command{skillsAddCmd, skillsOutdatedCmd} {
+		c.SetOut(&out)
+		defer c.SetOut(nil)
+	}
+
+	if err := runSkillsOutdated(skillsOutdatedCmd, nil); err != nil {
+		t.Fatalf("outdated failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "All skills are up to date") {
+		t.Errorf("expected bare skills to be skipped:\n%s", out.String())
+	}
+
+	skillsVersion = "1.2.0"
+	if err := runSkillsAdd(skillsAddCmd, []string{"data-analysis"}); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+	out.Reset()
+	if err := runSkillsOutdated(skillsOutdatedCmd, nil); err != nil {
+		t.Fatalf("outdated failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || strings.Join(strings.Fields(lines[1]), " ") != "data-analysis 1.2.0 1.2.0 1.4.0" {
+		t.Errorf("unexpected outdated output:\n%s", out.String())
+	}
+}
+
+func TestSkillsPublish(t *testing.T) {
+	var uploads []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			http.NotFound(w, r)
+			return
+		}
+		uploads = append(uploads, r.URL.Path)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+	t.Setenv(registry.EnvRegistriesFile, filepath.Join(t.TempDir(), "registries.yaml"))
+	t.Setenv(registry.EnvBaseURL, "")
+
+	dir := t.TempDir()
+	originalFile := skillsFile
+	defer func() { skillsFile, skillsRegistry, skillsDryRun, skillsID = originalFile, "", false, "" }()
+	skillsFile = filepath.Join(dir, "agent.yaml")
+	if err := os.WriteFile(skillsFile, []byte(skillsTestADL), 0644); err != nil {
+		t.Fatalf("failed to write ADL file: %v", err)
+	}
+	skillDir := filepath.Join(dir, ".agents", "skills", "policy")
+	if err := os.MkdirAll(skillDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	skillMD := filepath.Join(skillDir, registry.SkillFile)
+	if err := os.WriteFile(skillMD, []byte("---\nname: policy\ndescription: Company policy\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	skillsPublishCmd.SetOut(&out)
+	defer skillsPublishCmd.SetOut(nil)
+	skillsRegistry = srv.URL + "/skills/"
+
+	if err := runSkillsPublish(skillsPublishCmd, []string{skillDir}); err == nil || !strings.Contains(err.Error(), "license") {
+		t.Fatalf("expected a skill without license to be rejected, got %v", err)
+	}
+	if err := os.WriteFile(skillMD, []byte("---\nname: policy\ndescription: Company policy\nlicense: Proprietary\nversion: 1.0.0\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	skillsDryRun = true
+	if err := runSkillsPublish(skillsPublishCmd, []string{skillDir}); err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if len(uploads) != 0 {
+		t.Fatalf("dry run uploaded %v", uploads)
+	}
+
+	// The --id of 'skills add' must not leak into publish.
+	skillsID = "added-id"
+	skillsDryRun = false
+	out.Reset()
+	if err := runSkillsPublish(skillsPublishCmd, []string{skillDir}); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+	if len(uploads) != 1 || uploads[0] != "/skills/policy/1.0.0.tar.gz" {
+		t.Errorf("unexpected uploads: %v", uploads)
+	}
+	if !strings.Contains(out.String(), "Published policy@1.0.0") {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+	data, err := os.ReadFile(skillsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(data), "  skills:\n    - id: policy\n      version: 1.0.0\n") {
+		t.Errorf("expected the bare skill to become a pinned registry skill:\n%s", data)
+	}
+}
+
+func TestSkillFromTarget(t *testing.T) {
+	cases := []struct {
+		target, id, version string
+		wantID, wantSource  string
+		wantErr             bool
+	}{
+		{target: "data-analysis", wantID: "data-analysis"},
+		{target: "acme/skills/triage@v1.2.0", wantID: "triage", wantSource: "acme/skills/triage@v1.2.0"},
+		{target: "triage@v1.0.0", wantID: "triage", wantSource: "triage@v1.0.0"},
+		{target: "https://github.com/acme/skills/tree/main/skills/triage", id: "incident-triage", wantID: "incident-triage", wantSource: "https://github.com/acme/skills/tree/main/skills/triage"},
+		{target: "acme/skills/triage", version: "1.0.0", wantErr: true},
+		{target: "acme/triage", wantErr: true},
+		{target: "./skills/triage", wantID: "triage", wantSource: "./skills/triage"},
+		{target: "git+ssh://git@git.acme.dev/ai/skills.git//triage@v1.0.0", wantID: "triage", wantSource: "git+ssh://git@git.acme.dev/ai/skills.git//triage@v1.0.0"},
+		{target: "git+https://gitlab.com/acme/incident-triage.git", wantID: "incident-triage", wantSource: "git+https://gitlab.com/acme/incident-triage.git"},
+	}
+	for _, tc := range cases {
+		skill, err := skillFromTarget(tc.target, tc.id, tc.version)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("skillFromTarget(%q) expected an error", tc.target)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("skillFromTarget(%q): %v", tc.target, err)
+		}
+		if skill.ID != tc.wantID || skill.Source != tc.wantSource {
+			t.Errorf("skillFromTarget(%q) = %+v", tc.target, skill)
+		}
+	}
+}
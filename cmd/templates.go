@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+	"github.com/inference-gateway/adl-cli/internal/templates"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// templatesCmd represents the templates command
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "List the embedded templates and eject them into an overlay directory",
+	Long: `Customise generated files without forking the CLI. Template overlay
+directories mirror the embedded template tree (languages/<lang>/, common/,
+sandbox/); a '<name>.tmpl' file in one replaces the embedded template of the
+same name. Overlays apply in this order, later ones winning:
+
+  ~/.adl/templates           every project on this machine
+  spec.templates.path        the project, relative to the ADL file
+  --templates-dir            a single 'adl generate' run
+
+'adl templates eject' copies an embedded template into an overlay directory
+and records which version it copied. When a later CLI release changes that
+template, 'adl generate' and 'adl templates list' warn that the ejected copy
+has drifted so upstream fixes can be merged by hand.`,
+}
+
+var templatesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the embedded templates and the overlays replacing them",
+	Args:  cobra.NoArgs,
+	RunE:  runTemplatesList,
+}
+
+var templatesEjectCmd = &cobra.Command{
+	Use:   "eject <name>...",
+	Short: "Copy embedded templates into an overlay directory for editing",
+	Long: `Copy embedded templates, named as 'adl templates list' shows them (e.g.
+common/docker/dockerfile.go or languages/go/main.go), into an overlay
+directory: --templates-dir if given, otherwise the spec.templates.path of the
+ADL file, otherwise ~/.adl/templates. Existing copies are kept unless --force
+is passed. --stdout prints the embedded template instead of writing it, for
+comparing it with an ejected copy.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runTemplatesEject,
+}
+
+var (
+	templatesFile     string
+	templatesDir      string
+	templatesLanguage string
+	templatesForce    bool
+	templatesStdout   bool
+)
+
+// userTemplatesDir returns the per-user overlay directory; tests replace
+// it to use a temporary directory.
+var userTemplatesDir = func() string {
+	dir, err := templates.DefaultOverlayDir()
+	if err != nil {
+		return ""
+	}
+	return dir
+}
+
+func init() {
+	rootCmd.AddCommand(templatesCmd)
+	templatesCmd.AddCommand(templatesListCmd, templatesEjectCmd)
+
+	templatesCmd.PersistentFlags().StringVarP(&templatesFile, "file", "f", "agent.yaml", "ADL file whose spec.templates.path is used")
+	templatesCmd.PersistentFlags().StringVar(&templatesDir, "templates-dir", "", "Template overlay directory")
+	templatesListCmd.Flags().StringVar(&templatesLanguage, "language", "", "Only list the common templates and those of this language")
+	templatesEjectCmd.Flags().BoolVar(&templatesForce, "force", false, "Replace templates already ejected")
+	templatesEjectCmd.Flags().BoolVar(&templatesStdout, "stdout", false, "Print the embedded templates instead of writing them")
+}
+
+// readTemplatesManifest reads the ADL file for its spec.templates.path. A
+// missing default agent.yaml is not an error: the templates commands also
+// work outside a project.
+func readTemplatesManifest(cmd *cobra.Command) (*schema.ADL, error) {
+	data, err := os.ReadFile(templatesFile)
+	if err != nil {
+		if os.IsNotExist(err) && !cmd.Flags().Changed("file") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read ADL file: %w", err)
+	}
+	var adl schema.ADL
+	if err := yaml.Unmarshal(data, &adl); err != nil {
+		return nil, fmt.Errorf("failed to parse ADL file: %w", err)
+	}
+	return &adl, nil
+}
+
+func runTemplatesList(cmd *cobra.Command, args []string) error {
+	adl, err := readTemplatesManifest(cmd)
+	if err != nil {
+		return err
+	}
+	dirs, err := templates.OverlayDirs(userTemplatesDir(), adl, templatesFile, templatesDir)
+	if err != nil {
+		return err
+	}
+
+	// Later overlays win, so the last one scanned replaces the entry.
+	overrides := make(map[string]templates.OverlayTemplate)
+	var unknown []templates.OverlayTemplate
+	for _, dir := range dirs {
+		found, err := templates.ScanOverlay(dir)
+		if err != nil {
+			return err
+		}
+		for _, t := range found {
+			if t.Unknown {
+				unknown = append(unknown, t)
+				continue
+			}
+			overrides[t.Name] = t
+		}
+	}
+
+	w := cmd.OutOrStdout()
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "TEMPLATE\tSTATUS\tOVERLAY")
+	drifted := 0
+	for _, name := range templates.EmbeddedTemplateNames() {
+		if !templateForLanguage(name, templatesLanguage) {
+			continue
+		}
+		t, ok := overrides[name]
+		if !ok {
+			_, _ = fmt.Fprintf(tw, "%s\tembedded\t-\n", name)
+			continue
+		}
+		status := "overridden"
+		switch {
+		case t.Drifted:
+			status = fmt.Sprintf("drifted (ejected from %s)", t.EjectedFrom)
+			drifted++
+		case !t.Modified:
+			status = "overridden (unchanged copy)"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", name, status, t.Dir)
+	}
+	for _, t := range unknown {
+		_, _ = fmt.Fprintf(tw, "%s\tunknown (ignored)\t%s\n", t.Name, t.Dir)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if drifted > 0 {
+		_, _ = fmt.Fprintf(w, "\n⚠️  %d ejected template(s) changed upstream since they were ejected; compare them with 'adl templates eject <name> --stdout'\n", drifted)
+	}
+	return nil
+}
+
+// templateForLanguage reports whether the named template is shared or
+// belongs to language; every template matches an empty language.
+func templateForLanguage(name, language string) bool {
+	if language == "" {
+		return true
+	}
+	rest, ok := strings.CutPrefix(name, "languages/")
+	return !ok || strings.HasPrefix(rest, language+"/")
+}
+
+func runTemplatesEject(cmd *cobra.Command, args []string) error {
+	w := cmd.OutOrStdout()
+	if templatesStdout {
+		for _, name := range args {
+			content, err := templates.EmbeddedTemplate(strings.TrimSuffix(name, ".tmpl"))
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprint(w, content)
+		}
+		return nil
+	}
+
+	dir, err := ejectDir(cmd)
+	if err != nil {
+		return err
+	}
+	for _, name := range args {
+		path, err := templates.Eject(dir, strings.TrimSuffix(name, ".tmpl"), version, templatesForce)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(w, "✅ Ejected %s to '%s'\n", name, path)
+	}
+	return nil
+}
+
+// ejectDir returns the overlay directory 'adl templates eject' writes to.
+func ejectDir(cmd *cobra.Command) (string, error) {
+	if templatesDir != "" {
+		return templatesDir, nil
+	}
+	adl, err := readTemplatesManifest(cmd)
+	if err != nil {
+		return "", err
+	}
+	if adl != nil && adl.Spec.Templates != nil && adl.Spec.Templates.Path != "" {
+		dir := adl.Spec.Templates.Path
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(filepath.Dir(templatesFile), dir)
+		}
+		return dir, nil
+	}
+	dir := userTemplatesDir()
+	if dir == "" {
+		return "", fmt.Errorf("failed to resolve ~/.adl/templates; pass --templates-dir")
+	}
+	return dir, nil
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTemplatesCommands(t *testing.T) {
+	dir := t.TempDir()
+	original := userTemplatesDir
+	userTemplatesDir = func() string { return dir }
+	defer func() { userTemplatesDir = original }()
+	templatesFile = filepath.Join(t.TempDir(), "agent.yaml")
+	defer func() { templatesFile, templatesLanguage, templatesStdout = "agent.yaml", "", false }()
+
+	var out bytes.Buffer
+	for _, c := range templatesCmd.Commands() {
+		c.SetOut(&out)
+		defer c.SetOut(nil)
+	}
+
+	if err := runTemplatesEject(templatesEjectCmd, []string{"common/docker/dockerfile.go.tmpl"}); err != nil {
+		t.Fatalf("eject failed: %v", err)
+	}
+	ejected := filepath.Join(dir, "common", "docker", "dockerfile.go.tmpl")
+	if _, err := os.Stat(ejected); err != nil || !strings.Contains(out.String(), "Ejected common/docker/dockerfile.go.tmpl") {
+		t.Fatalf("expected %s to be ejected (%v):\n%s", ejected, err, out.String())
+	}
+	if err := runTemplatesEject(templatesEjectCmd, []string{"common/docker/dockerfile.go"}); err == nil {
+		t.Error("ejecting twice without --force should fail")
+	}
+	if err := os.WriteFile(ejected, []byte("FROM scratch\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out.Reset()
+	templatesLanguage = "go"
+	if err := runTemplatesList(templatesListCmd, nil); err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	list := out.String()
+	for _, want := range []string{"TEMPLATE", "languages/go/main.go"} {
+		if !strings.Contains(list, want) {
+			t.Errorf("list output missing %q:\n%s", want, list)
+		}
+	}
+	for _, line := range strings.Split(list, "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 && fields[0] == "common/docker/dockerfile.go" {
+			if len(fields) != 3 || fields[1] != "overridden" || fields[2] != dir {
+				t.Errorf("unexpected status for the ejected template: %q", line)
+			}
+		}
+	}
+	if strings.Contains(list, "languages/rust/") {
+		t.Errorf("--language go must hide other languages' templates:\n%s", list)
+	}
+
+	out.Reset()
+	templatesStdout = true
+	if err := runTemplatesEject(templatesEjectCmd, []string{"common/docker/dockerfile.go"}); err != nil {
+		t.Fatalf("eject --stdout failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "FROM") || strings.Contains(out.String(), "FROM scratch") {
+		t.Errorf("--stdout should print the embedded template:\n%s", out.String())
+	}
+}
@@ -15,16 +15,28 @@ var validateCmd = &cobra.Command{
 	Long: `Validate an Agent Definition Language (ADL) file against the official schema.
 
 This command checks if your ADL file follows the correct structure and
-contains all required fields for generating a valid A2A agent.`,
+contains all required fields for generating a valid A2A agent.
+
+Every finding is reported with its rule ID, JSON path and line/column in the
+file. Use --format json for editors and scripts, or --format sarif to upload
+the results to GitHub code scanning.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runValidate,
 }
 
+var validateFormat string
+
 func init() {
 	rootCmd.AddCommand(validateCmd)
+
+	validateCmd.Flags().StringVar(&validateFormat, "format", formatText, "Output format: text, json or sarif")
 }
 
 func runValidate(cmd *cobra.Command, args []string) error {
+	if err := checkFormat(validateFormat); err != nil {
+		return err
+	}
+
 	adlFile := "agent.yaml"
 	if len(args) > 0 {
 		adlFile = args[0]
@@ -34,19 +46,32 @@ func runValidate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("ADL file '%s' does not exist", adlFile)
 	}
 
-	fmt.Printf("Validating '%s'...\n", adlFile)
-
-	validator := schema.NewValidator()
-	warnings, err := validator.ValidateFile(adlFile)
+	data, err := os.ReadFile(adlFile)
 	if err != nil {
-		fmt.Printf("❌ Validation failed: %v\n", err)
+		return fmt.Errorf("failed to read ADL file: %w", err)
+	}
+
+	w := cmd.OutOrStdout()
+	if validateFormat == formatText {
+		_, _ = fmt.Fprintf(w, "Validating '%s'...\n", adlFile)
+	}
+
+	diags := schema.NewValidator().Diagnose(data)
+	schema.SortDiagnostics(diags)
+	if err := writeDiagnostics(w, validateFormat, adlFile, diags); err != nil {
 		return err
 	}
 
-	for _, w := range warnings {
-		fmt.Fprintf(os.Stderr, "⚠️  %s\n", w)
+	if schema.HasErrors(diags) {
+		cmd.SilenceUsage = true
+		if validateFormat == formatText {
+			_, _ = fmt.Fprintf(w, "❌ Validation failed\n")
+		}
+		return fmt.Errorf("'%s' is not valid", adlFile)
 	}
 
-	fmt.Printf("✅ '%s' is valid!\n", adlFile)
+	if validateFormat == formatText {
+		_, _ = fmt.Fprintf(w, "✅ '%s' is valid!\n", adlFile)
+	}
 	return nil
 }
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateCommand_Formats(t *testing.T) {
+	adlContent := `apiVersion: adl.inference-gateway.com/v1
+kind: Agent
+metadata:
+  name: format-agent
+  description: Format agent
+  version: 1.0.0
+spec:
+  capabilities:
+    streaming: true
+    pushNotifications: false
+    stateTransitionHistory: false
+  server:
+    port: "8080"
+  language:
+    go:
+      module: github.com/test/format-agent
+      version: "1.26.4"
+`
+	adlPath := filepath.Join(t.TempDir(), "agent.yaml")
+	if err := os.WriteFile(adlPath, []byte(adlContent), 0644); err != nil {
+		t.Fatalf("failed to write ADL file: %v", err)
+	}
+
+	originalFormat := validateFormat
+	defer func() {
+		validateFormat = originalFormat
+		validateCmd.SetOut(nil)
+	}()
+
+	var out bytes.Buffer
+	validateCmd.SetOut(&out)
+
+	t.Run("json", func(t *testing.T) {
+		out.Reset()
+		validateFormat = formatJSON
+		if err := runValidate(validateCmd, []string{adlPath}); err == nil {
+			t.Fatal("expected validation to fail")
+		}
+		var report struct {
+			Valid       bool `json:"valid"`
+			Diagnostics []struct {
+				RuleID string `json:"ruleId"`
+				Path   string `json:"path"`
+				Line   int    `json:"line"`
+			} `json:"diagnostics"`
+		}
+		if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+			t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+		}
+		if report.Valid || len(report.Diagnostics) != 1 {
+			t.Fatalf("unexpected report: %+v", report)
+		}
+		if d := report.Diagnostics[0]; d.RuleID != "schema" || d.Path != "spec.server.port" || d.Line != 13 {
+			t.Errorf("unexpected diagnostic: %+v", d)
+		}
+	})
+
+	t.Run("sarif", func(t *testing.T) {
+		out.Reset()
+		validateFormat = formatSARIF
+		if err := runValidate(validateCmd, []string{adlPath}); err == nil {
+			t.Fatal("expected validation to fail")
+		}
+		var log sarifLog
+		if err := json.Unmarshal(out.Bytes(), &log); err != nil {
+			t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+		}
+		if log.Version != "2.1.0" || len(log.Runs) != 1 || len(log.Runs[0].Results) != 1 {
+			t.Fatalf("unexpected SARIF log: %+v", log)
+		}
+		result := log.Runs[0].Results[0]
+		region := result.Locations[0].PhysicalLocation.Region
+		if result.Level != "error" || region == nil || region.StartLine != 13 {
+			t.Errorf("unexpected SARIF result: %+v", result)
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		validateFormat = "xml"
+		if err := runValidate(validateCmd, []string{adlPath}); err == nil {
+			t.Fatal("expected an error for an unknown format")
+		}
+	})
+}
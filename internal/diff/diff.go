@@ -0,0 +1,266 @@
+// Package diff renders line-oriented unified diffs. It backs the
+// generator's dry-run mode so reviewers can see what a manifest change
+// does to the generated tree before anything is written to disk.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultContext is the number of unchanged lines shown around each
+// change, matching `diff -u`.
+const DefaultContext = 3
+
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+// edit is one step of the edit script. a and b are the line indexes in
+// the old and new input at which the step applies.
+type edit struct {
+	kind opKind
+	a, b int
+}
+
+// Unified returns a unified diff turning a into b, labelled oldName and
+// newName in the ---/+++ headers. It returns "" when a and b are equal.
+func Unified(oldName, newName string, a, b []byte) string {
+	if string(a) == string(b) {
+		return ""
+	}
+	oldLines := splitLines(string(a))
+	newLines := splitLines(string(b))
+	edits := computeEdits(oldLines, newLines)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", oldName, newName)
+	for _, h := range groupHunks(edits, DefaultContext) {
+		writeHunk(&sb, h, oldLines, newLines)
+	}
+	return sb.String()
+}
+
+// splitLines splits s into lines, keeping each line's trailing "\n" so a
+// missing newline at end of file is detectable.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// computeEdits returns the shortest edit script between a and b. The
+// common prefix and suffix are stripped before running Myers' algorithm
+// so the typical "one section changed" case stays cheap.
+func computeEdits(a, b []string) []edit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	edits := make([]edit, 0, len(a)+len(b))
+	for i := 0; i < prefix; i++ {
+		edits = append(edits, edit{kind: opEqual, a: i, b: i})
+	}
+	for _, e := range myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]) {
+		e.a += prefix
+		e.b += prefix
+		edits = append(edits, e)
+	}
+	for i := 0; i < suffix; i++ {
+		edits = append(edits, edit{kind: opEqual, a: len(a) - suffix + i, b: len(b) - suffix + i})
+	}
+	return edits
+}
+
+// myers implements the O((N+M)D) greedy algorithm from Myers' "An O(ND)
+// Difference Algorithm and Its Variations", keeping one snapshot of the
+// frontier per edit distance so the path can be recovered.
+func myers(a, b []string) []edit {
+	n, m := len(a), len(b)
+	switch {
+	case n == 0 && m == 0:
+		return nil
+	case n == 0:
+		edits := make([]edit, m)
+		for j := range edits {
+			edits[j] = edit{kind: opInsert, a: 0, b: j}
+		}
+		return edits
+	case m == 0:
+		edits := make([]edit, n)
+		for i := range edits {
+			edits[i] = edit{kind: opDelete, a: i, b: 0}
+		}
+		return edits
+	}
+
+	limit := n + m
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	var trace [][]int
+
+	for d := 0; d <= limit; d++ {
+		snapshot := make([]int, len(v))
+		copy(snapshot, v)
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, offset, n, m)
+			}
+		}
+	}
+	return nil
+}
+
+// backtrack walks the saved frontiers from (n, m) back to the origin and
+// returns the edit script in forward order.
+func backtrack(trace [][]int, offset, n, m int) []edit {
+	var edits []edit
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, edit{kind: opEqual, a: x, b: y})
+		}
+		if d > 0 {
+			if x == prevX {
+				edits = append(edits, edit{kind: opInsert, a: x, b: y - 1})
+			} else {
+				edits = append(edits, edit{kind: opDelete, a: x - 1, b: y})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// groupHunks splits the edit script into hunks, each holding its changes
+// plus up to context lines of surrounding equal lines. Changes separated
+// by no more than 2*context equal lines share a hunk.
+func groupHunks(edits []edit, context int) [][]edit {
+	var hunks [][]edit
+	i, prevEnd := 0, 0
+	for i < len(edits) {
+		for i < len(edits) && edits[i].kind == opEqual {
+			i++
+		}
+		if i == len(edits) {
+			break
+		}
+
+		start := max(i-context, prevEnd)
+
+		end := i
+		for {
+			for end < len(edits) && edits[end].kind != opEqual {
+				end++
+			}
+			next := end
+			for next < len(edits) && edits[next].kind == opEqual {
+				next++
+			}
+			if next < len(edits) && next-end <= 2*context {
+				end = next
+				continue
+			}
+			end = min(end+context, next)
+			break
+		}
+
+		hunks = append(hunks, edits[start:end])
+		i, prevEnd = end, end
+	}
+	return hunks
+}
+
+// writeHunk renders a single @@ hunk.
+func writeHunk(sb *strings.Builder, hunk []edit, a, b []string) {
+	var oldLen, newLen int
+	for _, e := range hunk {
+		switch e.kind {
+		case opEqual:
+			oldLen++
+			newLen++
+		case opDelete:
+			oldLen++
+		case opInsert:
+			newLen++
+		}
+	}
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(hunk[0].a, oldLen), hunkRange(hunk[0].b, newLen))
+
+	for _, e := range hunk {
+		switch e.kind {
+		case opEqual:
+			writeLine(sb, ' ', a[e.a])
+		case opDelete:
+			writeLine(sb, '-', a[e.a])
+		case opInsert:
+			writeLine(sb, '+', b[e.b])
+		}
+	}
+}
+
+// hunkRange formats the "start,len" half of a hunk header. An empty range
+// points at the line before the hunk, as GNU diff does.
+func hunkRange(start, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if length == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
+
+func writeLine(sb *strings.Builder, prefix byte, line string) {
+	sb.WriteByte(prefix)
+	sb.WriteString(line)
+	if !strings.HasSuffix(line, "\n") {
+		sb.WriteString("\n\\ No newline at end of file\n")
+	}
+}
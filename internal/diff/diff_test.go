@@ -0,0 +1,86 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{
+			name: "equal inputs produce no diff",
+			a:    "one\ntwo\n",
+			b:    "one\ntwo\n",
+			want: "",
+		},
+		{
+			name: "created file",
+			a:    "",
+			b:    "one\ntwo\n",
+			want: "--- a\n+++ b\n@@ -0,0 +1,2 @@\n+one\n+two\n",
+		},
+		{
+			name: "deleted file",
+			a:    "one\n",
+			b:    "",
+			want: "--- a\n+++ b\n@@ -1 +0,0 @@\n-one\n",
+		},
+		{
+			name: "single line changed in the middle",
+			a:    "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			b:    "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			want: "--- a\n+++ b\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name: "distant changes get separate hunks",
+			a:    "a\n1\n2\n3\n4\n5\n6\n7\n8\nb\n",
+			b:    "A\n1\n2\n3\n4\n5\n6\n7\n8\nB\n",
+			want: "--- a\n+++ b\n@@ -1,4 +1,4 @@\n-a\n+A\n 1\n 2\n 3\n@@ -7,4 +7,4 @@\n 6\n 7\n 8\n-b\n+B\n",
+		},
+		{
+			name: "nearby changes share a hunk",
+			a:    "a\n1\n2\nb\n",
+			b:    "A\n1\n2\nB\n",
+			want: "--- a\n+++ b\n@@ -1,4 +1,4 @@\n-a\n+A\n 1\n 2\n-b\n+B\n",
+		},
+		{
+			name: "missing trailing newline is marked",
+			a:    "one\ntwo",
+			b:    "one\ntwo\n",
+			want: "--- a\n+++ b\n@@ -1,2 +1,2 @@\n one\n-two\n\\ No newline at end of file\n+two\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Unified("a", "b", []byte(tt.a), []byte(tt.b))
+			if got != tt.want {
+				t.Errorf("Unified() mismatch\n--- got ---\n%s\n--- want ---\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnified_InterleavedEdits(t *testing.T) {
+	a := "alpha\nbeta\ngamma\ndelta\nepsilon\n"
+	b := "alpha\ngamma\nDELTA\nepsilon\nzeta\n"
+
+	got := Unified("old", "new", []byte(a), []byte(b))
+
+	var rebuilt []string
+	for _, line := range strings.Split(got, "\n") {
+		if strings.HasPrefix(line, " ") || (strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++")) {
+			rebuilt = append(rebuilt, line[1:])
+		}
+	}
+	if strings.Join(rebuilt, "\n")+"\n" != b {
+		t.Errorf("applying the diff did not reproduce the new input:\n%s", got)
+	}
+	if !strings.Contains(got, "-beta\n") || !strings.Contains(got, "-delta\n") || !strings.Contains(got, "+zeta\n") {
+		t.Errorf("expected deletions of beta/delta and insertion of zeta, got:\n%s", got)
+	}
+}
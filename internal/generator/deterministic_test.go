@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// readTree returns every regular file under root keyed by its slash path.
+func readTree(t *testing.T, root string) map[string][]byte {
+	t.Helper()
+	tree := make(map[string][]byte)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		tree[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", root, err)
+	}
+	return tree
+}
+
+func TestGenerator_DeterministicRenderOrder(t *testing.T) {
+	render := func() []RenderedFile {
+		gen := New(Config{Template: "minimal", Version: "test", DryRun: true, Overwrite: true, GenerateCI: true})
+		if err := gen.Generate("../../examples/go-agent.yaml", filepath.Join(t.TempDir(), "out")); err != nil {
+			t.Fatalf("Generate() failed: %v", err)
+		}
+		return gen.Files()
+	}
+
+	first := render()
+	for run := 0; run < 5; run++ {
+		next := render()
+		if len(next) != len(first) {
+			t.Fatalf("run %d rendered %d files, want %d", run, len(next), len(first))
+		}
+		for i := range first {
+			if next[i].Path != first[i].Path {
+				t.Fatalf("run %d: file %d is %s, want %s", run, i, next[i].Path, first[i].Path)
+			}
+			if !bytes.Equal(next[i].Content, first[i].Content) {
+				t.Errorf("run %d: %s differs between runs", run, first[i].Path)
+			}
+		}
+	}
+}
+
+func TestGenerator_ReproducibleTree(t *testing.T) {
+	generatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	generate := func() map[string][]byte {
+		out := filepath.Join(t.TempDir(), "out")
+		mustGenerate(t, "../../examples/typescript-agent-tools.yaml", out, Config{
+			Template:    "minimal",
+			Version:     "test",
+			Overwrite:   true,
+			GenerateCI:  true,
+			GenerateCD:  true,
+			GeneratedAt: generatedAt,
+		})
+		return readTree(t, out)
+	}
+
+	first, second := generate(), generate()
+	if len(first) != len(second) {
+		t.Fatalf("generated %d and %d files", len(first), len(second))
+	}
+	for path, data := range first {
+		other, ok := second[path]
+		if !ok {
+			t.Errorf("%s is missing from the second run", path)
+			continue
+		}
+		if !bytes.Equal(data, other) {
+			t.Errorf("%s differs between runs", path)
+		}
+	}
+}
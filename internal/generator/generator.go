@@ -3,10 +3,13 @@ package generator
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -21,6 +24,26 @@ import (
 // Generator generates A2A agent projects from ADL files
 type Generator struct {
 	config Config
+	// files records every file the last Generate call rendered, in the
+	// order it was produced. See Files.
+	files []RenderedFile
+	// generatedAt is the single timestamp stamped into every file of a
+	// Generate call. See Config.GeneratedAt.
+	generatedAt time.Time
+	// overlays are the template overlay directories of the current
+	// Generate call. See templates.OverlayDirs.
+	overlays []string
+	// subdir is the language subdirectory being generated when the ADL
+	// defines several languages. Rendered file paths are recorded
+	// relative to the output root, so it prefixes them.
+	subdir string
+	// skills are the spec.skills of the current Generate call, resolved
+	// once and shared by every language.
+	skills []*registry.ResolvedSkill
+	// scope lists the language subdirectories the last Generate call
+	// rendered when Config.Language picked one language of a
+	// multi-language ADL. Empty means the whole output tree. See Scope.
+	scope []string
 }
 
 // Config holds generator configuration
@@ -34,8 +57,35 @@ type Config struct {
 	EnableFlox         bool
 	EnableDevContainer bool
 	Offline            bool
-	ADLFile            string
-	OutputDir          string
+	// Refresh re-fetches skills on moving refs instead of using cached
+	// copies; their content is still verified against adl.lock.
+	Refresh bool
+	// Language restricts generation to one spec.language entry. When
+	// empty, every language is generated. Whenever the ADL defines
+	// several languages each one lives in a subdirectory named after it,
+	// so a single language is rendered into its subdirectory and the
+	// shared root files and the other languages are left alone.
+	Language string
+	// TemplatesDir is a template overlay directory whose templates take
+	// precedence over spec.templates.path, UserTemplatesDir and the
+	// embedded ones.
+	TemplatesDir string
+	// UserTemplatesDir is the per-user overlay directory, normally
+	// ~/.adl/templates. It is skipped when it does not exist.
+	UserTemplatesDir string
+	// DryRun renders every file into memory without touching disk: no
+	// writes, no .claude/skills symlink and no post-generation commands.
+	// Callers inspect the result through Files and Compare.
+	DryRun bool
+	// Prune deletes files recorded in .adl/generated.json that the ADL no
+	// longer produces, as long as they carry no local edits.
+	Prune bool
+	// GeneratedAt pins the timestamp recorded in the generation metadata.
+	// When zero, SOURCE_DATE_EPOCH is used if set, otherwise the time
+	// Generate is called.
+	GeneratedAt time.Time
+	ADLFile     string
+	OutputDir   string
 	// EnableAI is the derived "any AI assistant is on" state. Computed
 	// in Generate() from AIToggles.Any(); not set by callers.
 	EnableAI bool
@@ -52,8 +102,29 @@ func New(config Config) *Generator {
 	}
 }
 
+// generationTime returns the timestamp recorded in the generation
+// metadata. SOURCE_DATE_EPOCH (https://reproducible-builds.org/specs/source-date-epoch/)
+// takes precedence over the wall clock so regenerated output is
+// reproducible.
+func generationTime() time.Time {
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		if secs, err := strconv.ParseInt(epoch, 10, 64); err == nil {
+			return time.Unix(secs, 0).UTC()
+		}
+		fmt.Fprintf(os.Stderr, "⚠️  Ignoring invalid SOURCE_DATE_EPOCH %q\n", epoch)
+	}
+	return time.Now()
+}
+
 // Generate generates an A2A agent project from an ADL file
 func (g *Generator) Generate(adlFile, outputDir string) error {
+	g.files = nil
+	g.scope = nil
+	g.generatedAt = g.config.GeneratedAt
+	if g.generatedAt.IsZero() {
+		g.generatedAt = generationTime()
+	}
+
 	adl, err := g.parseADL(adlFile)
 	if err != nil {
 		return fmt.Errorf("failed to parse ADL file: %w", err)
@@ -63,6 +134,23 @@ func (g *Generator) Generate(adlFile, outputDir string) error {
 		return fmt.Errorf("ADL validation failed: %w", err)
 	}
 
+	backends, err := g.targetBackends(adl)
+	if err != nil {
+		return fmt.Errorf("ADL validation failed: %w", err)
+	}
+
+	g.overlays, err = templates.OverlayDirs(g.config.UserTemplatesDir, adl, adlFile, g.config.TemplatesDir)
+	if err != nil {
+		return err
+	}
+	overlayWarnings, err := templates.OverlayWarnings(g.overlays)
+	if err != nil {
+		return err
+	}
+	for _, w := range overlayWarnings {
+		fmt.Fprintf(os.Stderr, "⚠️  %s\n", w)
+	}
+
 	// Reconcile CLI flags with manifest fields. The CLI flag is OR'd on top
 	// of the manifest value, so passing --ci/--cd at the command line
 	// always wins; omitting the flag falls back to the manifest. After this
@@ -143,17 +231,77 @@ func (g *Generator) Generate(adlFile, outputDir string) error {
 		template = g.detectTemplate(adl)
 	}
 
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
+	g.skills, err = g.resolveSkills(adl)
+	if err != nil {
+		return err
+	}
+
+	selected := templates.SelectedBackends(adl)
+	if len(selected) == 1 {
+		if err := g.generateLanguage(templates.WithLanguage(adl, backends[0]), template, outputDir); err != nil {
+			return err
+		}
+	} else {
+		for _, backend := range backends {
+			g.subdir = backend.Name()
+			err := g.generateLanguage(templates.WithLanguage(adl, backend), template, filepath.Join(outputDir, g.subdir))
+			g.subdir = ""
+			if err != nil {
+				return fmt.Errorf("%s project: %w", backend.DisplayName(), err)
+			}
+		}
+		if len(backends) < len(selected) {
+			// The shared root files describe every language, so they are
+			// only rendered when all of them are.
+			for _, backend := range backends {
+				g.scope = append(g.scope, backend.Name())
+			}
+		} else if err := g.generateSharedFiles(adl, backends, template, outputDir); err != nil {
+			return fmt.Errorf("failed to generate shared files: %w", err)
+		}
+	}
+
+	if g.config.DryRun {
+		return nil
+	}
+
+	if err := g.reconcileManifest(outputDir); err != nil {
+		return fmt.Errorf("failed to update %s: %w", ManifestPath, err)
+	}
+
+	return nil
+}
+
+// targetBackends returns the languages Generate renders: the one named
+// by Config.Language, or every language adl defines.
+func (g *Generator) targetBackends(adl *schema.ADL) ([]templates.LanguageBackend, error) {
+	selected := templates.SelectedBackends(adl)
+	if g.config.Language == "" {
+		return selected, nil
+	}
+	for _, backend := range selected {
+		if backend.Name() == g.config.Language {
+			return []templates.LanguageBackend{backend}, nil
+		}
+	}
+	if _, ok := templates.LookupBackend(g.config.Language); !ok {
+		return nil, fmt.Errorf("unsupported language: %s", g.config.Language)
+	}
+	return nil, fmt.Errorf("language %s is not defined in spec.language", g.config.Language)
+}
+
+// generateLanguage generates a single-language project into outputDir and
+// runs its post-generation steps.
+func (g *Generator) generateLanguage(adl *schema.ADL, template, outputDir string) error {
+	if !g.config.DryRun {
+		if err := os.MkdirAll(outputDir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
 	}
 
 	language := templates.DetectLanguageFromADL(adl)
 
-	registry, err := templates.NewRegistryWithOptions(templates.RegistryOptions{
-		Language:  language,
-		EnableAI:  g.config.EnableAI,
-		AIToggles: g.config.AIToggles,
-	})
+	registry, err := g.newRegistry(language)
 	if err != nil {
 		return fmt.Errorf("failed to create template registry: %w", err)
 	}
@@ -164,6 +312,10 @@ func (g *Generator) Generate(adlFile, outputDir string) error {
 		return fmt.Errorf("failed to generate project: %w", err)
 	}
 
+	if g.config.DryRun {
+		return nil
+	}
+
 	if err := g.runPostGenerationSteps(adl, outputDir, language); err != nil {
 		return fmt.Errorf("post-generation steps failed: %w", err)
 	}
@@ -171,6 +323,149 @@ func (g *Generator) Generate(adlFile, outputDir string) error {
 	return nil
 }
 
+// newRegistry creates the template registry of language, with the
+// overlays of the current Generate call applied.
+func (g *Generator) newRegistry(language string) (*templates.Registry, error) {
+	return templates.NewRegistryWithOptions(templates.RegistryOptions{
+		Language:  language,
+		EnableAI:  g.config.EnableAI,
+		AIToggles: g.config.AIToggles,
+		Overlays:  g.overlays,
+	})
+}
+
+// generateSharedFiles renders the files a multi-language project keeps at
+// its root: the agent card every implementation serves, a docker-compose
+// file that can run any of them, and the skills and GitHub configuration,
+// which only work at the root of the repository.
+func (g *Generator) generateSharedFiles(adl *schema.ADL, backends []templates.LanguageBackend, template, outputDir string) error {
+	languages := make([]string, 0, len(backends))
+	for _, backend := range backends {
+		languages = append(languages, backend.Name())
+	}
+
+	registry, err := g.newRegistry(languages[0])
+	if err != nil {
+		return fmt.Errorf("failed to create template registry: %w", err)
+	}
+	templateEngine := templates.NewWithRegistry(template, registry)
+
+	ctx := templates.Context{
+		ADL: adl,
+		Metadata: schema.GeneratedMetadata{
+			GeneratedAt: g.generatedAt,
+			CLIVersion:  g.getVersion(),
+			Template:    g.config.Template,
+		},
+		GenerateCI:      g.config.GenerateCI,
+		GenerateCD:      g.config.GenerateCD,
+		EnableAI:        g.config.EnableAI,
+		AIToggles:       g.config.AIToggles,
+		GenerateCommand: g.buildGenerateCommand(),
+		Skills:          skillViews(g.skills),
+		Languages:       languages,
+	}
+
+	ignoreChecker, err := NewIgnoreChecker(outputDir)
+	if err != nil {
+		return fmt.Errorf("failed to initialize ignore checker: %w", err)
+	}
+
+	files := map[string]string{
+		".well-known/agent-card.json": "config/agent.json",
+		"docker-compose.yaml":         "docker/docker-compose.multi.yaml",
+	}
+	for fileName, templateKey := range templateEngine.GetFiles(adl) {
+		if rootOnly(fileName) {
+			files[fileName] = templateKey
+		}
+	}
+	for _, fileName := range slices.Sorted(maps.Keys(files)) {
+		templateKey := files[fileName]
+		if g.skipIgnored(ignoreChecker, fileName, templateKey) {
+			continue
+		}
+
+		var content string
+		if templateKey == "skills/skill.md" {
+			content, err = g.renderBareSkill(templateEngine, ctx, fileName)
+			if err != nil {
+				return err
+			}
+		} else {
+			content, err = templateEngine.ExecuteTemplate(templateKey, ctx)
+			if err != nil {
+				return fmt.Errorf("failed to execute template %s: %w", templateKey, err)
+			}
+			if fileType := templates.FileType(fileName); fileType != "" {
+				content = templates.GetGeneratedFileHeader(fileType, ctx.Metadata.CLIVersion, ctx.Metadata.GeneratedAt) + content
+			}
+		}
+
+		if err := g.emit(outputDir, fileName, templateKey, content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", fileName, err)
+		}
+	}
+
+	return g.generateRootFiles(adl, ctx, outputDir, ignoreChecker)
+}
+
+// rootOnly reports whether fileName, a path relative to a generated
+// project, belongs at the root of a multi-language project instead of in
+// every language subdirectory: the skills, which all implementations
+// share, and the GitHub configuration, which GitHub only reads at the
+// root of the repository.
+func rootOnly(fileName string) bool {
+	return strings.HasPrefix(fileName, ".agents/") || strings.HasPrefix(fileName, ".github/")
+}
+
+// generateRootFiles writes the files of ctx's project that have to live
+// at the repository root: the resolved skills and the CI/CD and AI
+// assistant workflows. In a multi-language project ctx.Languages lists
+// every language, and each workflow covers all of them.
+func (g *Generator) generateRootFiles(adl *schema.ADL, ctx templates.Context, outputDir string, ignoreChecker *IgnoreChecker) error {
+	if err := g.writeResolvedSkillFiles(g.skills, outputDir, ignoreChecker); err != nil {
+		return err
+	}
+
+	if len(adl.Spec.Skills) > 0 && !g.config.DryRun {
+		if err := g.writeClaudePointer(outputDir); err != nil {
+			return err
+		}
+	}
+
+	if g.config.GenerateCI {
+		if err := g.generateCI(adl, ctx.Languages, outputDir, ignoreChecker); err != nil {
+			return fmt.Errorf("failed to generate CI configuration: %w", err)
+		}
+	}
+
+	if g.config.GenerateCD {
+		if err := g.generateCD(adl, ctx.Languages, outputDir, ignoreChecker); err != nil {
+			return fmt.Errorf("failed to generate CD configuration: %w", err)
+		}
+	}
+
+	if err := g.generateAIWorkflows(adl, ctx, outputDir, ignoreChecker); err != nil {
+		return fmt.Errorf("failed to generate AI assistant workflows: %w", err)
+	}
+
+	return nil
+}
+
+// Files returns every file rendered by the last Generate call, including
+// the ones skipped because they match .adl-ignore.
+func (g *Generator) Files() []RenderedFile {
+	return g.files
+}
+
+// Scope returns the language subdirectories the last Generate call was
+// limited to, or nil when it rendered the whole output tree. Pass it to
+// FindOrphans so files outside them are not mistaken for orphans.
+func (g *Generator) Scope() []string {
+	return g.scope
+}
+
 // parseADL parses an ADL file
 func (g *Generator) parseADL(adlFile string) (*schema.ADL, error) {
 	data, err := os.ReadFile(adlFile)
@@ -212,43 +507,14 @@ func (g *Generator) validateADL(adl *schema.ADL) error {
 		return fmt.Errorf("spec.server.port must be between 1 and 65535")
 	}
 
-	languageCount := 0
-	if adl.Spec.Language.Go != nil {
-		languageCount++
-		if adl.Spec.Language.Go.Module == "" {
-			return fmt.Errorf("spec.language.go.module is required")
-		}
-		if adl.Spec.Language.Go.Version == "" {
-			return fmt.Errorf("spec.language.go.version is required")
-		}
-	}
-	if adl.Spec.Language.TypeScript != nil {
-		languageCount++
-		if adl.Spec.Language.TypeScript.PackageName == "" {
-			return fmt.Errorf("spec.language.typescript.packageName is required")
-		}
-		if adl.Spec.Language.TypeScript.NodeVersion == "" {
-			return fmt.Errorf("spec.language.typescript.nodeVersion is required")
-		}
-	}
-	if adl.Spec.Language.Rust != nil {
-		languageCount++
-		if adl.Spec.Language.Rust.PackageName == "" {
-			return fmt.Errorf("spec.language.rust.packageName is required")
-		}
-		if adl.Spec.Language.Rust.Version == "" {
-			return fmt.Errorf("spec.language.rust.version is required")
-		}
-		if adl.Spec.Language.Rust.Edition == "" {
-			return fmt.Errorf("spec.language.rust.edition is required")
-		}
-	}
-
-	if languageCount == 0 {
+	selected := templates.SelectedBackends(adl)
+	if len(selected) == 0 {
 		return fmt.Errorf("at least one programming language must be defined in spec.language")
 	}
-	if languageCount > 1 {
-		return fmt.Errorf("exactly one programming language must be defined for code generation, found %d", languageCount)
+	for _, backend := range selected {
+		if err := backend.Validate(adl); err != nil {
+			return err
+		}
 	}
 
 	for i, tool := range adl.Spec.Tools {
@@ -297,6 +563,8 @@ func (g *Generator) detectTemplate(adl *schema.ADL) string {
 // resolveSkills walks spec.skills[] and produces resolved entries. Bare
 // skills get their metadata from the ADL; non-bare skills are fetched
 // from the registry (or local cache) and have their frontmatter parsed.
+// Their content is verified against adl.lock next to the ADL file;
+// skills missing from the lock are added to it.
 func (g *Generator) resolveSkills(adl *schema.ADL) ([]*registry.ResolvedSkill, error) {
 	if len(adl.Spec.Skills) == 0 {
 		return nil, nil
@@ -306,20 +574,34 @@ func (g *Generator) resolveSkills(adl *schema.ADL) ([]*registry.ResolvedSkill, e
 		return nil, fmt.Errorf("failed to initialize skills resolver: %w", err)
 	}
 	resolver.Offline = g.config.Offline
-	return resolver.ResolveAll(context.Background(), adl.Spec.Skills)
-}
+	resolver.Refresh = g.config.Refresh
+	resolver.BaseDir = filepath.Dir(g.config.ADLFile)
 
-// generateProject generates the complete project structure
-func (g *Generator) generateProject(templateEngine *templates.Engine, adl *schema.ADL, outputDir string) error {
-	resolvedSkills, err := g.resolveSkills(adl)
+	lockPath := registry.LockPath(g.config.ADLFile)
+	lock, err := registry.LoadLock(lockPath)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	resolver.Lock = lock
+
+	resolved, err := resolver.ResolveAll(context.Background(), adl.Spec.Skills)
+	if err != nil {
+		return nil, err
+	}
+	lock.Retain(adl.Spec.Skills)
+	if lock.Changed() && !g.config.DryRun {
+		if err := lock.Write(lockPath); err != nil {
+			return nil, err
+		}
 	}
-	skillsByID := make(map[string]*registry.ResolvedSkill, len(resolvedSkills))
-	skillViews := make([]templates.SkillView, 0, len(resolvedSkills))
-	for _, rs := range resolvedSkills {
-		skillsByID[rs.ID] = rs
-		skillViews = append(skillViews, templates.SkillView{
+	return resolved, nil
+}
+
+// skillViews returns the template view of every resolved skill.
+func skillViews(skills []*registry.ResolvedSkill) []templates.SkillView {
+	views := make([]templates.SkillView, 0, len(skills))
+	for _, rs := range skills {
+		views = append(views, templates.SkillView{
 			ID:          rs.ID,
 			Name:        rs.Name,
 			Description: rs.Description,
@@ -329,7 +611,38 @@ func (g *Generator) generateProject(templateEngine *templates.Engine, adl *schem
 			Bare:        rs.Bare,
 		})
 	}
+	return views
+}
+
+// renderBareSkill scaffolds the SKILL.md of the bare skill whose file is
+// fileName, .agents/skills/<id>/SKILL.md.
+func (g *Generator) renderBareSkill(templateEngine *templates.Engine, ctx templates.Context, fileName string) (string, error) {
+	skillID := filepath.Base(filepath.Dir(fileName))
+	i := slices.IndexFunc(g.skills, func(rs *registry.ResolvedSkill) bool { return rs.ID == skillID })
+	if i < 0 {
+		return "", fmt.Errorf("skill %s not found in resolved skills", skillID)
+	}
+	resolved := g.skills[i]
+	if !resolved.Bare {
+		return "", fmt.Errorf("non-bare skill %s should not flow through the skills/skill.md template", resolved.ID)
+	}
+	skillContext := map[string]interface{}{
+		"ID":          resolved.ID,
+		"Name":        resolved.Name,
+		"Description": resolved.Description,
+		"Tags":        resolved.Tags,
+		"Version":     resolved.Version,
+		"License":     resolved.License,
+	}
+	content, err := templateEngine.ExecuteToolTemplateWithContext("skills/skill.md", skillContext, ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to scaffold bare skill %s: %w", resolved.ID, err)
+	}
+	return content, nil
+}
 
+// generateProject generates the complete project structure
+func (g *Generator) generateProject(templateEngine *templates.Engine, adl *schema.ADL, outputDir string) error {
 	builtinConfigs, err := schema.ResolveBuiltinConfigs(adl)
 	if err != nil {
 		return fmt.Errorf("failed to resolve built-in tool config: %w", err)
@@ -363,7 +676,7 @@ func (g *Generator) generateProject(templateEngine *templates.Engine, adl *schem
 	ctx := templates.Context{
 		ADL: adl,
 		Metadata: schema.GeneratedMetadata{
-			GeneratedAt: time.Now(),
+			GeneratedAt: g.generatedAt,
 			CLIVersion:  g.getVersion(),
 			Template:    g.config.Template,
 		},
@@ -373,10 +686,11 @@ func (g *Generator) generateProject(templateEngine *templates.Engine, adl *schem
 		EnableAI:        g.config.EnableAI,
 		AIToggles:       g.config.AIToggles,
 		GenerateCommand: g.buildGenerateCommand(),
-		Skills:          skillViews,
+		Skills:          skillViews(g.skills),
 		BuiltinConfigs:  builtinConfigs,
 		Vendor:          vendorView,
 		SandboxDeps:     sandboxView,
+		Subdir:          g.subdir,
 	}
 
 	ignoreChecker, err := NewIgnoreChecker(outputDir)
@@ -384,27 +698,30 @@ func (g *Generator) generateProject(templateEngine *templates.Engine, adl *schem
 		return fmt.Errorf("failed to initialize ignore checker: %w", err)
 	}
 
+	backend := templates.BackendFor(adl)
 	files := templateEngine.GetFiles(adl)
-	for fileName, templateKey := range files {
+	for _, fileName := range slices.Sorted(maps.Keys(files)) {
+		templateKey := files[fileName]
 		fileName = g.replacePlaceholders(fileName, adl)
 
-		if ignoreChecker.ShouldIgnore(fileName) {
-			fmt.Printf("🚫 Ignoring file (matches .adl-ignore): %s\n", fileName)
+		if g.subdir != "" && rootOnly(fileName) {
+			continue
+		}
+		if g.skipIgnored(ignoreChecker, fileName, templateKey) {
 			continue
 		}
 
 		var content string
 		var err error
 
-		if (templateKey == "service.go" && strings.Contains(fileName, "internal/") && !strings.Contains(fileName, "internal/services/")) ||
-			templateKey == "service.ts" {
+		if templateKey == backend.ServiceTemplate() {
 			parts := strings.Split(fileName, "/")
 			if len(parts) >= 3 {
 				serviceFileName := parts[len(parts)-1]
 				serviceName := strings.TrimSuffix(serviceFileName, filepath.Ext(serviceFileName))
 
 				var foundService string
-				for svcName := range adl.Spec.Services {
+				for _, svcName := range slices.Sorted(maps.Keys(adl.Spec.Services)) {
 					snakeCaseServiceID := strings.ReplaceAll(svcName, "-", "_")
 					if snakeCaseServiceID == serviceName {
 						foundService = svcName
@@ -430,9 +747,7 @@ func (g *Generator) generateProject(templateEngine *templates.Engine, adl *schem
 							"Config":      adl.Spec.Config,
 						}
 
-						if adl.Spec.Language.Go != nil {
-							svcContext["GoModule"] = adl.Spec.Language.Go.Module
-						}
+						maps.Copy(svcContext, backend.EntryContext(adl))
 						content, err = templateEngine.ExecuteToolTemplateWithContext(templateKey, svcContext, ctx)
 						if err != nil {
 							return fmt.Errorf("failed to execute template %s for service %s: %w", templateKey, serviceName, err)
@@ -442,7 +757,7 @@ func (g *Generator) generateProject(templateEngine *templates.Engine, adl *schem
 					return fmt.Errorf("service %s not found in ADL spec", serviceName)
 				}
 			}
-		} else if (templateKey == "tool.go" || templateKey == "tool.rs" || templateKey == "tool.ts" ||
+		} else if (templateKey == backend.ToolTemplate() ||
 			(strings.HasPrefix(templateKey, "builtin/") && !isBuiltinTestTemplate(templateKey))) && strings.Contains(fileName, "/") {
 			parts := strings.Split(fileName, "/")
 			if len(parts) >= 2 {
@@ -469,9 +784,7 @@ func (g *Generator) generateProject(templateEngine *templates.Engine, adl *schem
 						"TelemetryEnabled": adl.Spec.Telemetry != nil && adl.Spec.Telemetry.Enabled,
 					}
 
-					if adl.Spec.Language.Go != nil {
-						toolContext["GoModule"] = adl.Spec.Language.Go.Module
-					}
+					maps.Copy(toolContext, backend.EntryContext(adl))
 
 					serviceMap := make(map[string]interface{})
 					for svcName, svc := range adl.Spec.Services {
@@ -507,28 +820,10 @@ func (g *Generator) generateProject(templateEngine *templates.Engine, adl *schem
 				}
 			}
 		} else if templateKey == "skills/skill.md" {
-			skillID := filepath.Base(filepath.Dir(fileName))
-			resolved, ok := skillsByID[skillID]
-			if !ok {
-				return fmt.Errorf("skill %s not found in resolved skills", skillID)
-			}
-			if !resolved.Bare {
-				return fmt.Errorf("non-bare skill %s should not flow through the skills/skill.md template", resolved.ID)
-			}
-			skillContext := map[string]interface{}{
-				"ID":          resolved.ID,
-				"Name":        resolved.Name,
-				"Description": resolved.Description,
-				"Tags":        resolved.Tags,
-				"Version":     resolved.Version,
-				"License":     resolved.License,
-			}
-			content, err = templateEngine.ExecuteToolTemplateWithContext(templateKey, skillContext, ctx)
+			content, err = g.renderBareSkill(templateEngine, ctx, fileName)
 			if err != nil {
-				return fmt.Errorf("failed to scaffold bare skill %s: %w", resolved.ID, err)
+				return err
 			}
-		} else if templateKey == "service.go" {
-			return fmt.Errorf("service template reached fallback case - this should not happen")
 		} else {
 			content, err = templateEngine.ExecuteTemplate(templateKey, ctx)
 			if err != nil {
@@ -536,76 +831,38 @@ func (g *Generator) generateProject(templateEngine *templates.Engine, adl *schem
 			}
 		}
 
-		ext := strings.ToLower(filepath.Ext(fileName))
-		baseName := strings.ToLower(filepath.Base(fileName))
-
-		var fileType string
-		switch {
-		case ext == ".go":
-			fileType = "go"
-		case ext == ".rs":
-			fileType = "rust"
-		case ext == ".yaml" || ext == ".yml":
-			fileType = "yaml"
-		case baseName == "dockerfile":
-			fileType = "dockerfile"
-		case baseName == "taskfile.yml":
-			fileType = "taskfile"
-		}
+		fileType := templates.FileType(fileName)
 
 		isSkillFile := templateKey == "skills/skill.md" ||
 			(strings.HasPrefix(fileName, ".agents/skills/") && filepath.Base(fileName) == "SKILL.md")
 
 		isBuiltinToolFile := strings.HasPrefix(templateKey, "builtin/")
-		isToolFile := !isBuiltinToolFile && templateKey != "telemetry.go" &&
-			((templateKey == "tool.go" || templateKey == "tool.rs" || templateKey == "tool.mod.rs" || templateKey == "tool.ts") ||
-				(strings.HasPrefix(fileName, "tools/") && ext == ".go") ||
-				(strings.HasPrefix(fileName, "src/tools/") && (ext == ".rs" || ext == ".ts")))
-
-		isServiceFile := templateKey == "service.go" ||
-			(strings.Contains(fileName, "/internal/") && strings.HasSuffix(fileName, ".go") && !strings.Contains(fileName, "/logger/"))
+		isToolFile := !isBuiltinToolFile && backend.IsToolFile(templateKey, fileName)
+		isServiceFile := backend.IsServiceFile(templateKey, fileName)
 
 		if fileType != "" && !isSkillFile && !isToolFile && !isServiceFile {
 			header := templates.GetGeneratedFileHeader(fileType, ctx.Metadata.CLIVersion, ctx.Metadata.GeneratedAt)
 			content = header + content
 		}
 
-		filePath := filepath.Join(outputDir, fileName)
-		if err := g.writeFile(filePath, content); err != nil {
+		if err := g.emit(outputDir, fileName, templateKey, content); err != nil {
 			return fmt.Errorf("failed to write %s: %w", fileName, err)
 		}
 	}
 
-	if err := g.writeResolvedSkillFiles(resolvedSkills, outputDir, ignoreChecker); err != nil {
-		return err
-	}
-
-	if len(adl.Spec.Skills) > 0 {
-		if err := g.writeClaudePointer(outputDir); err != nil {
-			return err
-		}
-	}
-
 	if err := g.generateADLIgnoreFile(outputDir, templateEngine.GetTemplate(), adl); err != nil {
 		return fmt.Errorf("failed to generate .adl-ignore file: %w", err)
 	}
 
-	if g.config.GenerateCI {
-		if err := g.generateCI(adl, outputDir, ignoreChecker); err != nil {
-			return fmt.Errorf("failed to generate CI configuration: %w", err)
-		}
-	}
-
-	if g.config.GenerateCD {
-		if err := g.generateCD(adl, outputDir, ignoreChecker); err != nil {
-			return fmt.Errorf("failed to generate CD configuration: %w", err)
+	// A language subdirectory of a multi-language project leaves the
+	// skills and workflows to generateSharedFiles, which writes them once
+	// at the project root.
+	if g.subdir == "" {
+		if err := g.generateRootFiles(adl, ctx, outputDir, ignoreChecker); err != nil {
+			return err
 		}
 	}
 
-	if err := g.generateAIWorkflows(adl, ctx, outputDir, ignoreChecker); err != nil {
-		return fmt.Errorf("failed to generate AI assistant workflows: %w", err)
-	}
-
 	if err := g.seedDocumentationPages(adl, outputDir); err != nil {
 		return fmt.Errorf("failed to seed documentation pages: %w", err)
 	}
@@ -632,17 +889,20 @@ func (g *Generator) seedExamples(adl *schema.ADL, outputDir string) error {
 		relPath := filepath.Join("examples", exampleSlug(ex.Title), "README.md")
 		filePath := filepath.Join(outputDir, relPath)
 		if _, err := os.Stat(filePath); err == nil {
-			fmt.Printf("📄 Example already exists: %s\n", relPath)
+			g.printf("📄 Example already exists: %s\n", relPath)
+			continue
+		}
+		content := fmt.Sprintf("# %s\n\n%s\n\nTODO: Add the example implementation.\n", ex.Title, ex.Description)
+		if g.recordSeed(relPath, content) {
 			continue
 		}
 		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
 			return fmt.Errorf("failed to create directory for %s: %w", relPath, err)
 		}
-		content := fmt.Sprintf("# %s\n\n%s\n\nTODO: Add the example implementation.\n", ex.Title, ex.Description)
 		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
 			return fmt.Errorf("failed to write example stub %s: %w", relPath, err)
 		}
-		fmt.Printf("📄 Seeded example stub: %s\n", relPath)
+		g.printf("📄 Seeded example stub: %s\n", relPath)
 	}
 	return nil
 }
@@ -697,12 +957,8 @@ func (g *Generator) generateAIWorkflows(adl *schema.ADL, ctx templates.Context,
 		},
 	}
 
-	language := g.detectLanguage(adl)
-	registry, err := templates.NewRegistryWithOptions(templates.RegistryOptions{
-		Language:  language,
-		EnableAI:  g.config.EnableAI,
-		AIToggles: g.config.AIToggles,
-	})
+	language := templates.DetectLanguageFromADL(adl)
+	registry, err := g.newRegistry(language)
 	if err != nil {
 		return fmt.Errorf("failed to create template registry for AI workflows: %w", err)
 	}
@@ -712,8 +968,7 @@ func (g *Generator) generateAIWorkflows(adl *schema.ADL, ctx templates.Context,
 		if !wf.enabled {
 			continue
 		}
-		if ignoreChecker.ShouldIgnore(wf.path) {
-			fmt.Printf("🚫 Ignoring file (matches .adl-ignore): %s\n", wf.path)
+		if g.skipIgnored(ignoreChecker, wf.path, wf.key) {
 			continue
 		}
 		content, err := engine.ExecuteTemplate(wf.key, ctx)
@@ -723,11 +978,10 @@ func (g *Generator) generateAIWorkflows(adl *schema.ADL, ctx templates.Context,
 		header := templates.GetGeneratedFileHeader("yaml", ctx.Metadata.CLIVersion, ctx.Metadata.GeneratedAt)
 		content = header + content
 
-		fullPath := filepath.Join(outputDir, wf.path)
-		if err := g.writeFile(fullPath, content); err != nil {
+		if err := g.emit(outputDir, wf.path, wf.key, content); err != nil {
 			return fmt.Errorf("failed to write %s workflow: %w", wf.label, err)
 		}
-		fmt.Printf("📁 %s workflow: %s\n", wf.label, wf.path)
+		g.printf("📁 %s workflow: %s\n", wf.label, wf.path)
 	}
 
 	return nil
@@ -744,18 +998,21 @@ func (g *Generator) seedDocumentationPages(adl *schema.ADL, outputDir string) er
 	for _, page := range adl.Spec.Documentation.Pages {
 		filePath := filepath.Join(outputDir, page.Path)
 		if _, err := os.Stat(filePath); err == nil {
-			fmt.Printf("📄 Documentation page already exists: %s\n", page.Path)
+			g.printf("📄 Documentation page already exists: %s\n", page.Path)
+			continue
+		}
+		content := fmt.Sprintf("# %s\n\nTODO: Write documentation for this page.\n", page.Title)
+		if g.recordSeed(page.Path, content) {
 			continue
 		}
 		dir := filepath.Dir(filePath)
 		if err := os.MkdirAll(dir, 0755); err != nil {
 			return fmt.Errorf("failed to create directory for %s: %w", page.Path, err)
 		}
-		content := fmt.Sprintf("# %s\n\nTODO: Write documentation for this page.\n", page.Title)
 		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
 			return fmt.Errorf("failed to write documentation stub %s: %w", page.Path, err)
 		}
-		fmt.Printf("📄 Seeded documentation stub: %s\n", page.Path)
+		g.printf("📄 Seeded documentation stub: %s\n", page.Path)
 	}
 	return nil
 }
@@ -769,18 +1026,18 @@ func (g *Generator) writeResolvedSkillFiles(skills []*registry.ResolvedSkill, ou
 		if rs.Bare {
 			continue
 		}
-		for rel, data := range rs.Files {
+		for _, rel := range slices.Sorted(maps.Keys(rs.Files)) {
+			data := rs.Files[rel]
 			cleaned := filepath.ToSlash(filepath.Clean(rel))
 			if strings.HasPrefix(cleaned, "../") || strings.HasPrefix(cleaned, "/") {
 				return fmt.Errorf("skill %s: refusing to write file with suspicious path %q", rs.ID, rel)
 			}
 			relPath := path.Join(".agents", "skills", rs.ID, cleaned)
-			if ignoreChecker.ShouldIgnore(relPath) {
-				fmt.Printf("🚫 Ignoring file (matches .adl-ignore): %s\n", relPath)
+			templateKey := skillTemplateKey(rs.ID)
+			if g.skipIgnored(ignoreChecker, relPath, templateKey) {
 				continue
 			}
-			filePath := filepath.Join(outputDir, filepath.FromSlash(relPath))
-			if err := g.writeFile(filePath, string(data)); err != nil {
+			if err := g.emit(outputDir, relPath, templateKey, string(data)); err != nil {
 				return fmt.Errorf("failed to write %s: %w", relPath, err)
 			}
 		}
@@ -804,7 +1061,7 @@ func (g *Generator) writeClaudePointer(outputDir string) error {
 	const target = "../.agents/skills"
 	claudeDir := filepath.Join(outputDir, ".claude")
 	if err := os.MkdirAll(claudeDir, 0755); err != nil {
-		fmt.Printf("⚠️  failed to create .claude directory (%v); point Claude Code at .agents/skills manually\n", err)
+		g.printf("⚠️  failed to create .claude directory (%v); point Claude Code at .agents/skills manually\n", err)
 		return nil
 	}
 	link := filepath.Join(claudeDir, "skills")
@@ -814,14 +1071,14 @@ func (g *Generator) writeClaudePointer(outputDir string) error {
 				return nil
 			}
 		}
-		fmt.Printf("⚠️  %s already exists and is not the expected skills symlink; leaving it untouched\n", link)
+		g.printf("⚠️  %s already exists and is not the expected skills symlink; leaving it untouched\n", link)
 		return nil
 	}
 	if err := os.Symlink(target, link); err != nil {
-		fmt.Printf("⚠️  failed to create .claude/skills -> %s symlink (%v); set A2A_SKILLS_DIR or point Claude Code at .agents/skills manually\n", target, err)
+		g.printf("⚠️  failed to create .claude/skills -> %s symlink (%v); set A2A_SKILLS_DIR or point Claude Code at .agents/skills manually\n", target, err)
 		return nil
 	}
-	fmt.Printf("✅ Generated: .claude/skills -> %s\n", target)
+	g.printf("✅ Generated: .claude/skills -> %s\n", target)
 	return nil
 }
 
@@ -842,7 +1099,7 @@ func (g *Generator) replacePlaceholders(fileName string, adl *schema.ADL) string
 func (g *Generator) writeFile(filePath, content string) error {
 	if !g.config.Overwrite {
 		if _, err := os.Stat(filePath); err == nil {
-			fmt.Printf("⚠️  Skipping existing file: %s\n", filePath)
+			g.printf("⚠️  Skipping existing file: %s\n", filePath)
 			return nil
 		}
 	}
@@ -856,7 +1113,7 @@ func (g *Generator) writeFile(filePath, content string) error {
 		return err
 	}
 
-	fmt.Printf("✅ Generated: %s\n", filePath)
+	g.printf("✅ Generated: %s\n", filePath)
 	return nil
 }
 
@@ -882,7 +1139,13 @@ func (g *Generator) getVersion() string {
 func (g *Generator) buildGenerateCommand() string {
 	var parts []string
 
-	parts = append(parts, "adl", "generate", "--file", "agent.yaml", "--output", ".")
+	if g.subdir != "" {
+		// A language subdirectory of a multi-language project is
+		// regenerated together with its siblings from the project root.
+		parts = append(parts, "adl", "generate", "--file", "../agent.yaml", "--output", "..")
+	} else {
+		parts = append(parts, "adl", "generate", "--file", "agent.yaml", "--output", ".")
+	}
 
 	if g.config.Template != "" && g.config.Template != "minimal" {
 		parts = append(parts, "--template", g.config.Template)
@@ -900,63 +1163,16 @@ func (g *Generator) generateADLIgnoreFile(outputDir, templateName string, adl *s
 	ignoreFilePath := filepath.Join(outputDir, ".adl-ignore")
 
 	if _, err := os.Stat(ignoreFilePath); err == nil {
-		fmt.Printf("📄 .adl-ignore file already exists, skipping creation\n")
+		g.printf("📄 .adl-ignore file already exists, skipping creation\n")
 		return nil
 	}
 
 	var filesToIgnore []string
-	language := g.detectLanguage(adl)
+	backend := templates.BackendFor(adl)
 
 	switch templateName {
 	case "minimal":
-		switch language {
-		case "go":
-			for _, tool := range adl.Spec.Tools {
-				if schema.IsReservedToolID(tool.ID) {
-					continue
-				}
-				snakeCaseName := strings.ReplaceAll(tool.ID, "-", "_")
-				filesToIgnore = append(filesToIgnore, fmt.Sprintf("tools/%s.go", snakeCaseName))
-			}
-
-			for serviceName := range adl.Spec.Services {
-				snakeCaseName := strings.ReplaceAll(serviceName, "-", "_")
-				filesToIgnore = append(filesToIgnore, fmt.Sprintf("internal/%s/%s.go", snakeCaseName, snakeCaseName))
-			}
-
-			if adl.Spec.Server.Authz != nil && adl.Spec.Server.Authz.Enabled {
-				filesToIgnore = append(filesToIgnore, "internal/authz/authz.go")
-			}
-		case "rust":
-			if adl.Spec.Agent != nil {
-				for _, tool := range adl.Spec.Tools {
-					if schema.IsReservedToolID(tool.ID) {
-						continue
-					}
-					snakeCaseName := strings.ReplaceAll(tool.ID, "-", "_")
-					filesToIgnore = append(filesToIgnore, fmt.Sprintf("src/tools/%s.rs", snakeCaseName))
-				}
-			}
-		case "typescript":
-			for _, tool := range adl.Spec.Tools {
-				if schema.IsReservedToolID(tool.ID) {
-					continue
-				}
-				snakeCaseName := strings.ReplaceAll(tool.ID, "-", "_")
-				filesToIgnore = append(filesToIgnore, fmt.Sprintf("src/tools/%s.ts", snakeCaseName))
-			}
-
-			for serviceName := range adl.Spec.Services {
-				snakeCaseName := strings.ReplaceAll(serviceName, "-", "_")
-				filesToIgnore = append(filesToIgnore, fmt.Sprintf("src/services/%s.ts", snakeCaseName))
-			}
-
-			// The Cloudflare Worker entrypoint ships as a scaffold with a TODO;
-			// preserve the user's completed handler across regenerations.
-			if adl.Spec.Deployment != nil && adl.Spec.Deployment.Type == schema.DeploymentConfigTypeCloudflare {
-				filesToIgnore = append(filesToIgnore, "src/worker.ts")
-			}
-		}
+		filesToIgnore = append(filesToIgnore, backend.UserFiles(adl)...)
 
 		for _, skill := range adl.Spec.Skills {
 			if skill.Bare {
@@ -973,46 +1189,23 @@ func (g *Generator) generateADLIgnoreFile(outputDir, templateName string, adl *s
 		return nil
 	}
 
-	content := generateA2aIgnoreContent(filesToIgnore, language)
+	content := generateA2aIgnoreContent(filesToIgnore, backend.IgnoreDefaults(adl))
+	if g.recordSeed(".adl-ignore", content) {
+		return nil
+	}
 
 	if err := os.WriteFile(ignoreFilePath, []byte(content), 0644); err != nil {
 		return fmt.Errorf("failed to write .adl-ignore file: %w", err)
 	}
 
-	fmt.Printf("✅ Generated: .adl-ignore\n")
-	fmt.Printf("🔒 Files with TODO implementations will be preserved on future generations\n")
+	g.printf("✅ Generated: .adl-ignore\n")
+	g.printf("🔒 Files with TODO implementations will be preserved on future generations\n")
 
 	return nil
 }
 
 // generateA2aIgnoreContent generates the content for .adl-ignore file
-func generateA2aIgnoreContent(filesToIgnore []string, language string) string {
-	var exampleFile, exampleGlob, exampleDir, customFile, depsHeader, depsList string
-
-	switch language {
-	case "rust":
-		exampleFile = "src/tools/agent_tool.rs"
-		exampleGlob = "*.rs"
-		exampleDir = "target/"
-		customFile = "my-custom-file.rs"
-		depsHeader = "# Rust dependency files"
-		depsList = "Cargo.lock\n"
-	case "typescript":
-		exampleFile = "src/tools/agent_tool.ts"
-		exampleGlob = "*.ts"
-		exampleDir = "node_modules/"
-		customFile = "my-custom-file.ts"
-		depsHeader = "# Node dependency files"
-		depsList = "package-lock.json\npnpm-lock.yaml\nyarn.lock\n"
-	default:
-		exampleFile = "tools/agent_tool.go"
-		exampleGlob = "*.go"
-		exampleDir = "build/"
-		customFile = "my-custom-file.go"
-		depsHeader = "# Go dependency files"
-		depsList = "go.sum\n"
-	}
-
+func generateA2aIgnoreContent(filesToIgnore []string, defaults templates.IgnoreDefaults) string {
 	content := fmt.Sprintf(`# .adl-ignore file
 # This file specifies which files should not be overwritten during generation operations.
 # Files listed here typically contain implementations that users have completed.
@@ -1024,7 +1217,7 @@ func generateA2aIgnoreContent(filesToIgnore []string, language string) string {
 # - Directories: %s
 # - Comments: lines starting with #
 
-`, exampleFile, exampleGlob, exampleDir)
+`, defaults.ExampleFile, defaults.ExampleGlob, defaults.ExampleDir)
 
 	for _, file := range filesToIgnore {
 		content += file + "\n"
@@ -1032,46 +1225,34 @@ func generateA2aIgnoreContent(filesToIgnore []string, language string) string {
 
 	content += fmt.Sprintf(`
 %s
-%s`, depsHeader, depsList)
+%s
+`, defaults.DepsHeader, strings.Join(defaults.DepsFiles, "\n"))
 
 	content += fmt.Sprintf(`
 # Add your own files to ignore here:
 # %s
 # config/secrets.yaml
-`, customFile)
+`, defaults.CustomFile)
 
 	return content
 }
 
-// generateCI generates CI workflow configuration based on the programming language and SCM provider
-func (g *Generator) generateCI(adl *schema.ADL, outputDir string, ignoreChecker *IgnoreChecker) error {
+// generateCI generates CI workflow configuration based on the programming language and SCM provider.
+// languages lists every language of a multi-language project and is nil otherwise.
+func (g *Generator) generateCI(adl *schema.ADL, languages []string, outputDir string, ignoreChecker *IgnoreChecker) error {
 	scmProvider := g.detectSCMProvider(adl)
 
 	switch scmProvider {
 	case "github":
-		return g.generateGitHubActionsWorkflow(adl, outputDir, ignoreChecker)
+		return g.generateGitHubActionsWorkflow(adl, languages, outputDir, ignoreChecker)
 	case "gitlab":
 		return g.generateGitLabCIWorkflow(adl, outputDir, ignoreChecker)
 	default:
-		fmt.Printf("⚠️  No SCM provider specified, defaulting to GitHub Actions\n")
-		return g.generateGitHubActionsWorkflow(adl, outputDir, ignoreChecker)
+		g.printf("⚠️  No SCM provider specified, defaulting to GitHub Actions\n")
+		return g.generateGitHubActionsWorkflow(adl, languages, outputDir, ignoreChecker)
 	}
 }
 
-// detectLanguage detects the programming language from ADL
-func (g *Generator) detectLanguage(adl *schema.ADL) string {
-	if adl.Spec.Language.Go != nil {
-		return "go"
-	}
-	if adl.Spec.Language.TypeScript != nil {
-		return "typescript"
-	}
-	if adl.Spec.Language.Rust != nil {
-		return "rust"
-	}
-	return "unknown"
-}
-
 // detectSCMProvider detects the SCM provider from ADL
 func (g *Generator) detectSCMProvider(adl *schema.ADL) string {
 	if adl.Spec.SCM != nil && adl.Spec.SCM.Provider != "" {
@@ -1081,16 +1262,22 @@ func (g *Generator) detectSCMProvider(adl *schema.ADL) string {
 }
 
 // generateGitHubActionsWorkflow generates a GitHub Actions workflow for projects using templates
-func (g *Generator) generateGitHubActionsWorkflow(adl *schema.ADL, outputDir string, ignoreChecker *IgnoreChecker) error {
+func (g *Generator) generateGitHubActionsWorkflow(adl *schema.ADL, languages []string, outputDir string, ignoreChecker *IgnoreChecker) error {
 	workflowPath := ".github/workflows/ci.yml"
 
-	if ignoreChecker.ShouldIgnore(workflowPath) {
-		fmt.Printf("🚫 Ignoring file (matches .adl-ignore): %s\n", workflowPath)
+	language := templates.DetectLanguageFromADL(adl)
+	templateKey := fmt.Sprintf("github/workflows/ci.%s.yaml", language)
+	if len(languages) > 0 {
+		// One job per language, each run in the language's subdirectory.
+		language = languages[0]
+		templateKey = "github/workflows/ci.multi.yaml"
+	}
+
+	if g.skipIgnored(ignoreChecker, workflowPath, templateKey) {
 		return nil
 	}
 
-	language := g.detectLanguage(adl)
-	templateEngine, err := templates.NewRegistry(language)
+	templateEngine, err := g.newRegistry(language)
 	if err != nil {
 		return fmt.Errorf("failed to create template registry: %w", err)
 	}
@@ -1099,7 +1286,7 @@ func (g *Generator) generateGitHubActionsWorkflow(adl *schema.ADL, outputDir str
 		ADL: adl,
 		Metadata: schema.GeneratedMetadata{
 			CLIVersion:  g.config.Version,
-			GeneratedAt: time.Now(),
+			GeneratedAt: g.generatedAt,
 			ADLFile:     g.config.ADLFile,
 			Template:    g.config.Template,
 		},
@@ -1109,9 +1296,9 @@ func (g *Generator) generateGitHubActionsWorkflow(adl *schema.ADL, outputDir str
 		EnableAI:        g.config.EnableAI,
 		AIToggles:       g.config.AIToggles,
 		GenerateCommand: g.buildGenerateCommand(),
+		Languages:       languages,
 	}
 
-	templateKey := fmt.Sprintf("github/workflows/ci.%s.yaml", language)
 	workflowContent, err := templates.NewWithRegistry("", templateEngine).ExecuteTemplate(templateKey, ctx)
 	if err != nil {
 		return fmt.Errorf("failed to execute CI workflow template: %w", err)
@@ -1121,13 +1308,12 @@ func (g *Generator) generateGitHubActionsWorkflow(adl *schema.ADL, outputDir str
 	header := templates.GetGeneratedFileHeader("yaml", ctx.Metadata.CLIVersion, ctx.Metadata.GeneratedAt)
 	workflowContent = header + workflowContent
 
-	fullWorkflowPath := filepath.Join(outputDir, workflowPath)
-	if err := g.writeFile(fullWorkflowPath, workflowContent); err != nil {
+	if err := g.emit(outputDir, workflowPath, templateKey, workflowContent); err != nil {
 		return fmt.Errorf("failed to write GitHub Actions workflow: %w", err)
 	}
 
-	fmt.Println("✅ CI workflow generated successfully!")
-	fmt.Printf("📁 GitHub Actions workflow: %s\n", workflowPath)
+	g.printf("✅ CI workflow generated successfully!\n")
+	g.printf("📁 GitHub Actions workflow: %s\n", workflowPath)
 
 	return nil
 }
@@ -1138,26 +1324,21 @@ func (g *Generator) runPostGenerationSteps(adl *schema.ADL, outputDir, language
 
 	if adl.Spec.Hooks != nil && len(adl.Spec.Hooks.Post) > 0 {
 		commands = adl.Spec.Hooks.Post
-		fmt.Println("🔧 Running custom post-generation hooks...")
+		g.printf("🔧 Running custom post-generation hooks...\n")
 	} else {
-		switch language {
-		case "go":
-			commands = []string{"go mod tidy", "go fmt ./..."}
-			fmt.Println("🔧 Running default Go post-generation commands...")
-		case "rust":
-			commands = []string{"cargo fmt"}
-			fmt.Println("🔧 Running default Rust post-generation commands...")
-		case "typescript":
-			// Default TypeScript commands could be added here
-			// commands = []string{"npm install", "npm run format"}
+		backend, ok := templates.LookupBackend(language)
+		if !ok {
 			return nil
-		default:
+		}
+		commands = backend.PostGenerateCommands()
+		if len(commands) == 0 {
 			return nil
 		}
+		g.printf("🔧 Running default %s post-generation commands...\n", backend.DisplayName())
 	}
 
 	for _, cmdStr := range commands {
-		fmt.Printf("  ▶ Running: %s\n", cmdStr)
+		g.printf("  ▶ Running: %s\n", cmdStr)
 
 		parts := strings.Fields(cmdStr)
 		if len(parts) == 0 {
@@ -1169,25 +1350,25 @@ func (g *Generator) runPostGenerationSteps(adl *schema.ADL, outputDir, language
 		output, err := cmd.CombinedOutput()
 
 		if err != nil {
-			fmt.Printf("    ⚠️  Warning: command failed: %v\n", err)
+			g.printf("    ⚠️  Warning: command failed: %v\n", err)
 			if len(output) > 0 {
 				lines := strings.Split(string(output), "\n")
 				for _, line := range lines {
 					if line != "" {
-						fmt.Printf("       %s\n", line)
+						g.printf("       %s\n", line)
 					}
 				}
 			}
-			fmt.Printf("       You can run '%s' manually later\n", cmdStr)
+			g.printf("       You can run '%s' manually later\n", cmdStr)
 			continue
 		}
 
-		fmt.Printf("    ✅ Successfully completed\n")
+		g.printf("    ✅ Successfully completed\n")
 		if len(output) > 0 && strings.TrimSpace(string(output)) != "" {
 			lines := strings.Split(string(output), "\n")
 			for _, line := range lines {
 				if line != "" {
-					fmt.Printf("       %s\n", line)
+					g.printf("       %s\n", line)
 				}
 			}
 		}
@@ -1201,32 +1382,36 @@ func (g *Generator) generateGitLabCIWorkflow(adl *schema.ADL, outputDir string,
 	// TODO: Implement GitLab CI workflow generation
 	// This should generate .gitlab-ci.yml based on the programming language
 	// and follow similar patterns to the GitHub Actions implementation
-	fmt.Printf("⚠️  GitLab CI generation is not yet implemented\n")
-	fmt.Printf("This is a planned feature - contributions welcome!\n")
+	g.printf("⚠️  GitLab CI generation is not yet implemented\n")
+	g.printf("This is a planned feature - contributions welcome!\n")
 	return nil
 }
 
-// generateCD generates CD configuration files based on the programming language and SCM provider
-func (g *Generator) generateCD(adl *schema.ADL, outputDir string, ignoreChecker *IgnoreChecker) error {
+// generateCD generates CD configuration files based on the programming language and SCM provider.
+// languages lists every language of a multi-language project and is nil otherwise.
+func (g *Generator) generateCD(adl *schema.ADL, languages []string, outputDir string, ignoreChecker *IgnoreChecker) error {
 	scmProvider := g.detectSCMProvider(adl)
 
 	switch scmProvider {
 	case "github":
-		return g.generateGitHubCDWorkflow(adl, outputDir, ignoreChecker)
+		return g.generateGitHubCDWorkflow(adl, languages, outputDir, ignoreChecker)
 	case "gitlab":
 		return g.generateGitLabCDWorkflow(adl, outputDir, ignoreChecker)
 	default:
-		fmt.Printf("⚠️  No SCM provider specified, defaulting to GitHub Actions\n")
-		return g.generateGitHubCDWorkflow(adl, outputDir, ignoreChecker)
+		g.printf("⚠️  No SCM provider specified, defaulting to GitHub Actions\n")
+		return g.generateGitHubCDWorkflow(adl, languages, outputDir, ignoreChecker)
 	}
 }
 
 // generateGitHubCDWorkflow generates GitHub CD workflow and semantic-release configuration
-func (g *Generator) generateGitHubCDWorkflow(adl *schema.ADL, outputDir string, ignoreChecker *IgnoreChecker) error {
-	language := g.detectLanguage(adl)
+func (g *Generator) generateGitHubCDWorkflow(adl *schema.ADL, languages []string, outputDir string, ignoreChecker *IgnoreChecker) error {
+	language := templates.DetectLanguageFromADL(adl)
+	if len(languages) > 0 {
+		language = languages[0]
+	}
 	template := g.detectTemplate(adl)
 
-	registry, err := templates.NewRegistry(language)
+	registry, err := g.newRegistry(language)
 	if err != nil {
 		return fmt.Errorf("failed to create template registry: %w", err)
 	}
@@ -1236,7 +1421,7 @@ func (g *Generator) generateGitHubCDWorkflow(adl *schema.ADL, outputDir string,
 	ctx := templates.Context{
 		ADL: adl,
 		Metadata: schema.GeneratedMetadata{
-			GeneratedAt: time.Now(),
+			GeneratedAt: g.generatedAt,
 			CLIVersion:  g.getVersion(),
 			Template:    g.config.Template,
 		},
@@ -1246,6 +1431,7 @@ func (g *Generator) generateGitHubCDWorkflow(adl *schema.ADL, outputDir string,
 		EnableAI:        g.config.EnableAI,
 		AIToggles:       g.config.AIToggles,
 		GenerateCommand: g.buildGenerateCommand(),
+		Languages:       languages,
 	}
 
 	if err := g.generateReleaseRC(templateEngine, ctx, outputDir, ignoreChecker); err != nil {
@@ -1253,14 +1439,12 @@ func (g *Generator) generateGitHubCDWorkflow(adl *schema.ADL, outputDir string,
 	}
 
 	workflowPath := ".github/workflows/cd.yml"
+	templateKey := "github/workflows/cd.yaml"
 
-	if ignoreChecker.ShouldIgnore(workflowPath) {
-		fmt.Printf("🚫 Ignoring file (matches .adl-ignore): %s\n", workflowPath)
+	if g.skipIgnored(ignoreChecker, workflowPath, templateKey) {
 		return nil
 	}
 
-	templateKey := "github/workflows/cd.yaml"
-
 	workflowContent, err := templateEngine.ExecuteTemplate(templateKey, ctx)
 	if err != nil {
 		return fmt.Errorf("failed to execute CD workflow template: %w", err)
@@ -1270,14 +1454,13 @@ func (g *Generator) generateGitHubCDWorkflow(adl *schema.ADL, outputDir string,
 	header := templates.GetGeneratedFileHeader("yaml", ctx.Metadata.CLIVersion, ctx.Metadata.GeneratedAt)
 	workflowContent = header + workflowContent
 
-	fullWorkflowPath := filepath.Join(outputDir, workflowPath)
-	if err := g.writeFile(fullWorkflowPath, workflowContent); err != nil {
+	if err := g.emit(outputDir, workflowPath, templateKey, workflowContent); err != nil {
 		return fmt.Errorf("failed to write GitHub CD workflow: %w", err)
 	}
 
-	fmt.Println("✅ CD pipeline generated successfully!")
-	fmt.Printf("📁 GitHub CD workflow: %s\n", workflowPath)
-	fmt.Printf("📁 Semantic release config: .releaserc.yaml\n")
+	g.printf("✅ CD pipeline generated successfully!\n")
+	g.printf("📁 GitHub CD workflow: %s\n", workflowPath)
+	g.printf("📁 Semantic release config: .releaserc.yaml\n")
 
 	return nil
 }
@@ -1285,13 +1468,13 @@ func (g *Generator) generateGitHubCDWorkflow(adl *schema.ADL, outputDir string,
 // generateReleaseRC generates the .releaserc.yaml configuration file
 func (g *Generator) generateReleaseRC(templateEngine *templates.Engine, ctx templates.Context, outputDir string, ignoreChecker *IgnoreChecker) error {
 	releasercPath := ".releaserc.yaml"
+	templateKey := "config/releaserc.yaml"
 
-	if ignoreChecker.ShouldIgnore(releasercPath) {
-		fmt.Printf("🚫 Ignoring file (matches .adl-ignore): %s\n", releasercPath)
+	if g.skipIgnored(ignoreChecker, releasercPath, templateKey) {
 		return nil
 	}
 
-	releasercContent, err := templateEngine.ExecuteTemplate("config/releaserc.yaml", ctx)
+	releasercContent, err := templateEngine.ExecuteTemplate(templateKey, ctx)
 	if err != nil {
 		return fmt.Errorf("failed to execute releaserc template: %w", err)
 	}
@@ -1300,8 +1483,7 @@ func (g *Generator) generateReleaseRC(templateEngine *templates.Engine, ctx temp
 	header := templates.GetGeneratedFileHeader("yaml", ctx.Metadata.CLIVersion, ctx.Metadata.GeneratedAt)
 	releasercContent = header + releasercContent
 
-	fullReleasercPath := filepath.Join(outputDir, releasercPath)
-	if err := g.writeFile(fullReleasercPath, releasercContent); err != nil {
+	if err := g.emit(outputDir, releasercPath, templateKey, releasercContent); err != nil {
 		return fmt.Errorf("failed to write .releaserc.yaml: %w", err)
 	}
 
@@ -1310,8 +1492,8 @@ func (g *Generator) generateReleaseRC(templateEngine *templates.Engine, ctx temp
 
 // generateGitLabCDWorkflow generates a GitLab CD workflow
 func (g *Generator) generateGitLabCDWorkflow(adl *schema.ADL, outputDir string, ignoreChecker *IgnoreChecker) error {
-	fmt.Printf("⚠️  GitLab CD generation is not yet implemented\n")
-	fmt.Printf("This is a planned feature - contributions welcome!\n")
+	g.printf("⚠️  GitLab CD generation is not yet implemented\n")
+	g.printf("This is a planned feature - contributions welcome!\n")
 	return nil
 }
 
@@ -221,8 +221,7 @@ func TestGenerator_validateADL(t *testing.T) {
 					},
 				},
 			},
-			wantErr: true,
-			errMsg:  "exactly one programming language must be defined for code generation, found 2",
+			wantErr: false,
 		},
 	}
 
@@ -522,7 +521,7 @@ func TestGenerator_generateCD(t *testing.T) {
 				t.Fatalf("Failed to create ignore checker: %v", err)
 			}
 
-			err = gen.generateCD(tt.adl, tmpDir, ignoreChecker)
+			err = gen.generateCD(tt.adl, nil, tmpDir, ignoreChecker)
 			if err != nil {
 				t.Fatalf("generateCD() error = %v", err)
 			}
@@ -641,7 +640,7 @@ func TestGenerator_generateCD_Vercel(t *testing.T) {
 		t.Fatalf("Failed to create ignore checker: %v", err)
 	}
 
-	if err := gen.generateCD(vercelADL, tmpDir, ignoreChecker); err != nil {
+	if err := gen.generateCD(vercelADL, nil, tmpDir, ignoreChecker); err != nil {
 		t.Fatalf("generateCD() error = %v", err)
 	}
 
@@ -0,0 +1,162 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+)
+
+// TestGenerator_KotlinTools runs the full pipeline (manifest -> Generate())
+// for a Kotlin agent: sources live under src/main/kotlin/<package path>/,
+// non-reserved tools and services render one file each with their injected
+// dependencies, the regenerated tools/Tools.kt wires them into the ToolBox,
+// generated files carry a "// " header while tool and service files do not,
+// and only the latter land in .adl-ignore.
+func TestGenerator_KotlinTools(t *testing.T) {
+	adl := &schema.ADL{
+		APIVersion: "adl.inference-gateway.com/v1",
+		Kind:       "Agent",
+		Metadata: schema.Metadata{
+			Name:        "kt-tools-agent",
+			Description: "A Kotlin agent exercising tools + services",
+			Version:     "1.0.0",
+		},
+		Spec: schema.Spec{
+			Capabilities: schema.Capabilities{Streaming: false},
+			Server:       schema.Server{Port: 8080},
+			Agent: &schema.Agent{
+				Provider:     "openai",
+				Model:        "gpt-5.5",
+				SystemPrompt: "You are a test bot.",
+			},
+			Config: schema.SpecConfig{
+				"notifications": {
+					"retryAttempts": 3,
+				},
+			},
+			Services: schema.SpecServices{
+				"database": {
+					Interface:   "DatabaseService",
+					Factory:     "NewDatabaseService",
+					Type:        schema.ServiceTypeService,
+					Description: "Database access",
+				},
+			},
+			Tools: []schema.Tool{
+				{ID: "read"},
+				{
+					ID:          "query_database",
+					Name:        "query_database",
+					Description: "Run a read-only SQL query",
+					Tags:        []string{"data"},
+					Schema: schema.ToolSchema{
+						"type": "object",
+						"properties": map[string]any{
+							"query": map[string]any{"type": "string"},
+						},
+						"required": []any{"query"},
+					},
+					Inject: []string{"logger", "database", "config.notifications"},
+				},
+			},
+			Hooks: &schema.Hooks{Post: []string{"true"}},
+			Language: schema.Language{
+				Kotlin: &schema.KotlinConfig{
+					PackageName: "com.example.kttools",
+					JvmVersion:  "21",
+				},
+			},
+		},
+	}
+
+	tmpDir := t.TempDir()
+	adlPath := filepath.Join(tmpDir, "agent.yaml")
+	writeYAML(t, adlPath, adl)
+
+	outDir := filepath.Join(tmpDir, "out")
+	gen := New(Config{Template: "minimal", Overwrite: true, Version: "test"})
+	if err := gen.Generate(adlPath, outDir); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	read := func(rel string) string {
+		t.Helper()
+		b, err := os.ReadFile(filepath.Join(outDir, rel))
+		if err != nil {
+			t.Fatalf("read %s: %v", rel, err)
+		}
+		return string(b)
+	}
+
+	pkgDir := "src/main/kotlin/com/example/kttools/"
+
+	queryDatabase := read(pkgDir + "tools/query_database.kt")
+	for _, want := range []string{
+		"package com.example.kttools.tools",
+		"fun createQueryDatabaseTool(",
+		"logger: Logger,",
+		"database: DatabaseService,",
+		"notificationsConfig: NotificationsConfig,",
+		"import com.example.kttools.services.DatabaseService",
+		`"query"`,
+	} {
+		if !strings.Contains(queryDatabase, want) {
+			t.Errorf("tools/query_database.kt missing %q\n---\n%s", want, queryDatabase)
+		}
+	}
+	if strings.HasPrefix(queryDatabase, "// Code generated") {
+		t.Errorf("tool files are user-owned and must not carry the generated header\n---\n%s", queryDatabase)
+	}
+
+	database := read(pkgDir + "services/database.kt")
+	for _, want := range []string{
+		"class DatabaseService(",
+		"fun newDatabaseService(",
+	} {
+		if !strings.Contains(database, want) {
+			t.Errorf("services/database.kt missing %q\n---\n%s", want, database)
+		}
+	}
+
+	aggregator := read(pkgDir + "tools/Tools.kt")
+	for _, want := range []string{
+		"fun buildToolBox(",
+		"newDatabaseService(logger, config)",
+		"createQueryDatabaseTool(",
+		"config.notifications",
+	} {
+		if !strings.Contains(aggregator, want) {
+			t.Errorf("tools/Tools.kt missing %q\n---\n%s", want, aggregator)
+		}
+	}
+	if !strings.HasPrefix(aggregator, "// ") {
+		t.Errorf("tools/Tools.kt should carry the generated header\n---\n%s", aggregator)
+	}
+	if strings.Contains(aggregator, "createReadTool") {
+		t.Errorf("tools/Tools.kt must not reference the reserved read tool\n---\n%s", aggregator)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, pkgDir, "tools", "read.kt")); !os.IsNotExist(err) {
+		t.Errorf("tools/read.kt must NOT exist, stat err = %v", err)
+	}
+
+	if main := read(pkgDir + "Main.kt"); !strings.Contains(main, "buildToolBox(logger, config)") {
+		t.Errorf("Main.kt should build the toolbox from the aggregator\n---\n%s", main)
+	}
+
+	ignore := read(".adl-ignore")
+	for _, want := range []string{
+		pkgDir + "tools/query_database.kt",
+		pkgDir + "services/database.kt",
+		"# - Exact file names: " + pkgDir + "tools/agent_tool.kt\n",
+	} {
+		if !strings.Contains(ignore, want) {
+			t.Errorf(".adl-ignore missing %q\n---\n%s", want, ignore)
+		}
+	}
+	if strings.Contains(ignore, "tools/Tools.kt") {
+		t.Errorf(".adl-ignore must NOT list the regenerated aggregator\n---\n%s", ignore)
+	}
+}
@@ -0,0 +1,267 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ManifestPath is where Generate records the files it produced, relative
+// to the output directory. The manifest is meant to be committed so every
+// checkout can detect and prune orphaned files.
+const ManifestPath = ".adl/generated.json"
+
+// Manifest lists every file a Generate run produced from the ADL.
+type Manifest struct {
+	// CLIVersion is the version of the CLI that wrote the manifest.
+	CLIVersion string          `json:"cliVersion"`
+	Files      []ManifestEntry `json:"files"`
+}
+
+// ManifestEntry describes one generated file. SHA256 is the digest of the
+// file as the generator left it (after post-generation commands), so a
+// mismatch with the file on disk means it was edited by hand.
+// RenderedSHA256 is the digest of the template output before those
+// commands ran; together they let Check recognise files that differ from
+// the rendered content only because of go fmt, go mod tidy and friends.
+type ManifestEntry struct {
+	Path           string `json:"path"`
+	TemplateKey    string `json:"templateKey,omitempty"`
+	SHA256         string `json:"sha256"`
+	RenderedSHA256 string `json:"renderedSha256,omitempty"`
+	CLIVersion     string `json:"cliVersion"`
+}
+
+// Orphan is a file recorded in the previous manifest that the current ADL
+// no longer produces.
+type Orphan struct {
+	ManifestEntry
+	// Modified is set when the file on disk no longer matches the recorded
+	// digest. Modified orphans are never pruned.
+	Modified bool
+}
+
+// LoadManifest reads the manifest from outputDir. A missing manifest is
+// not an error; it yields an empty manifest. The manifest is committed and
+// its paths end up in os.Remove, so an entry pointing outside outputDir is
+// rejected.
+func LoadManifest(outputDir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(outputDir, filepath.FromSlash(ManifestPath)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Manifest{}, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", ManifestPath, err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ManifestPath, err)
+	}
+	for _, e := range m.Files {
+		if !filepath.IsLocal(filepath.FromSlash(e.Path)) {
+			return nil, fmt.Errorf("invalid %s: %s is not a path inside the output directory", ManifestPath, e.Path)
+		}
+	}
+	return &m, nil
+}
+
+// Write stores the manifest under outputDir.
+func (m *Manifest) Write(outputDir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", ManifestPath, err)
+	}
+	fullPath := filepath.Join(outputDir, filepath.FromSlash(ManifestPath))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", ManifestPath, err)
+	}
+	return os.WriteFile(fullPath, append(data, '\n'), 0644)
+}
+
+// entry returns the recorded entry for path, if any.
+func (m *Manifest) entry(path string) (ManifestEntry, bool) {
+	for _, e := range m.Files {
+		if e.Path == path {
+			return e, true
+		}
+	}
+	return ManifestEntry{}, false
+}
+
+// FindOrphans returns the entries of prev that are not among files and
+// still exist on disk, flagging the ones whose content changed since they
+// were generated. Ignored files count as produced: the ADL still declares
+// them, the user has only asked the generator to keep its hands off.
+// A non-empty scope (see Generator.Scope) limits the search to entries
+// under those subdirectories; everything else was not rendered and so
+// cannot be judged.
+func FindOrphans(outputDir string, prev *Manifest, files []RenderedFile, scope []string) ([]Orphan, error) {
+	produced := make(map[string]bool, len(files))
+	for _, f := range files {
+		produced[f.Path] = true
+	}
+
+	var orphans []Orphan
+	for _, e := range prev.Files {
+		if produced[e.Path] || !inScope(e.Path, scope) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(outputDir, filepath.FromSlash(e.Path)))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read orphaned file %s: %w", e.Path, err)
+		}
+		orphans = append(orphans, Orphan{ManifestEntry: e, Modified: digest(data) != e.SHA256})
+	}
+	sort.Slice(orphans, func(i, j int) bool { return orphans[i].Path < orphans[j].Path })
+	return orphans, nil
+}
+
+// inScope reports whether path lies under one of the scope
+// subdirectories. An empty scope covers every path.
+func inScope(path string, scope []string) bool {
+	if len(scope) == 0 {
+		return true
+	}
+	for _, dir := range scope {
+		if strings.HasPrefix(path, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// PruneOrphans deletes every unmodified orphan and any directories left
+// empty by the deletion. It returns the orphans that were kept.
+func PruneOrphans(outputDir string, orphans []Orphan) (pruned, kept []Orphan, err error) {
+	for _, o := range orphans {
+		if o.Modified {
+			kept = append(kept, o)
+			continue
+		}
+		fullPath := filepath.Join(outputDir, filepath.FromSlash(o.Path))
+		if err := os.Remove(fullPath); err != nil && !os.IsNotExist(err) {
+			return pruned, kept, fmt.Errorf("failed to prune %s: %w", o.Path, err)
+		}
+		removeEmptyParents(outputDir, filepath.Dir(fullPath))
+		pruned = append(pruned, o)
+	}
+	return pruned, kept, nil
+}
+
+// removeEmptyParents removes dir and its ancestors up to (but excluding)
+// root for as long as they are empty.
+func removeEmptyParents(root, dir string) {
+	root = filepath.Clean(root)
+	for dir = filepath.Clean(dir); dir != root && strings.HasPrefix(dir, root+string(filepath.Separator)); dir = filepath.Dir(dir) {
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+	}
+}
+
+// buildManifest records the files produced by the last Generate run. The
+// digest is taken from disk so post-generation formatting is accounted
+// for. Files the generator did not write this time (ignored, or skipped
+// because they exist and Overwrite is off) keep their previous entry so
+// their provenance is not lost; without one, the rendered content is used.
+// Orphans that were not pruned are carried over so they keep being
+// reported until they are dealt with, as are the entries outside the
+// scope of a single-language run.
+func (g *Generator) buildManifest(outputDir string, prev *Manifest, kept []Orphan) (*Manifest, error) {
+	m := &Manifest{CLIVersion: g.getVersion()}
+	for _, e := range prev.Files {
+		if !inScope(e.Path, g.scope) {
+			m.Files = append(m.Files, e)
+		}
+	}
+	for _, f := range g.files {
+		if f.Seed {
+			continue
+		}
+		if f.Ignored || f.Skipped {
+			if e, ok := prev.entry(f.Path); ok {
+				m.Files = append(m.Files, e)
+			} else if f.Skipped {
+				m.Files = append(m.Files, ManifestEntry{
+					Path:           f.Path,
+					TemplateKey:    f.TemplateKey,
+					SHA256:         digest(f.Content),
+					RenderedSHA256: digest(f.Content),
+					CLIVersion:     m.CLIVersion,
+				})
+			}
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(outputDir, filepath.FromSlash(f.Path)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read generated file %s: %w", f.Path, err)
+		}
+		m.Files = append(m.Files, ManifestEntry{
+			Path:           f.Path,
+			TemplateKey:    f.TemplateKey,
+			SHA256:         digest(data),
+			RenderedSHA256: digest(f.Content),
+			CLIVersion:     m.CLIVersion,
+		})
+	}
+	for _, o := range kept {
+		m.Files = append(m.Files, o.ManifestEntry)
+	}
+	sort.Slice(m.Files, func(i, j int) bool { return m.Files[i].Path < m.Files[j].Path })
+	return m, nil
+}
+
+// reconcileManifest reports files the ADL no longer produces, prunes the
+// unmodified ones when Prune is set, and writes the new manifest.
+func (g *Generator) reconcileManifest(outputDir string) error {
+	prev, err := LoadManifest(outputDir)
+	if err != nil {
+		return err
+	}
+
+	orphans, err := FindOrphans(outputDir, prev, g.files, g.scope)
+	if err != nil {
+		return err
+	}
+
+	kept := orphans
+	if g.config.Prune {
+		var pruned []Orphan
+		pruned, kept, err = PruneOrphans(outputDir, orphans)
+		if err != nil {
+			return err
+		}
+		for _, o := range pruned {
+			g.printf("🗑️  Pruned orphaned file: %s\n", o.Path)
+		}
+	}
+	for _, o := range kept {
+		switch {
+		case o.Modified:
+			g.printf("⚠️  Orphaned file has local edits, not pruning: %s (delete it manually if it is no longer needed)\n", o.Path)
+		default:
+			g.printf("🧹 Orphaned file no longer produced by the ADL: %s (run with --prune to remove it)\n", o.Path)
+		}
+	}
+
+	m, err := g.buildManifest(outputDir, prev, kept)
+	if err != nil {
+		return err
+	}
+	return m.Write(outputDir)
+}
+
+// digest returns the hex-encoded SHA-256 of data.
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
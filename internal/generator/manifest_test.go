@@ -0,0 +1,137 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindOrphansAndPrune(t *testing.T) {
+	outputDir := t.TempDir()
+	write := func(rel, content string) {
+		t.Helper()
+		full := filepath.Join(outputDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("main.go", "package main\n")
+	write("tools/old/old.go", "package old\n")
+	write("tools/edited/edited.go", "package edited // hand edit\n")
+
+	prev := &Manifest{Files: []ManifestEntry{
+		{Path: "main.go", SHA256: digest([]byte("package main\n"))},
+		{Path: "tools/edited/edited.go", SHA256: digest([]byte("package edited\n"))},
+		{Path: "tools/gone/gone.go", SHA256: digest([]byte("package gone\n"))},
+		{Path: "tools/ignored/ignored.go", SHA256: digest([]byte("package ignored\n"))},
+		{Path: "tools/old/old.go", SHA256: digest([]byte("package old\n"))},
+	}}
+	files := []RenderedFile{
+		{Path: "main.go", Content: []byte("package main\n")},
+		{Path: "tools/ignored/ignored.go", Ignored: true},
+	}
+
+	orphans, err := FindOrphans(outputDir, prev, files, nil)
+	if err != nil {
+		t.Fatalf("FindOrphans() failed: %v", err)
+	}
+	if len(orphans) != 2 {
+		t.Fatalf("got %d orphans, want 2: %+v", len(orphans), orphans)
+	}
+	if orphans[0].Path != "tools/edited/edited.go" || !orphans[0].Modified {
+		t.Errorf("expected the edited orphan to be flagged as modified, got %+v", orphans[0])
+	}
+	if orphans[1].Path != "tools/old/old.go" || orphans[1].Modified {
+		t.Errorf("expected the untouched orphan to be unmodified, got %+v", orphans[1])
+	}
+
+	pruned, kept, err := PruneOrphans(outputDir, orphans)
+	if err != nil {
+		t.Fatalf("PruneOrphans() failed: %v", err)
+	}
+	if len(pruned) != 1 || len(kept) != 1 {
+		t.Fatalf("pruned %d and kept %d orphans, want 1 and 1", len(pruned), len(kept))
+	}
+	assertFile(t, outputDir, "tools/old/old.go", false)
+	assertFile(t, outputDir, "tools/old", false)
+	assertFile(t, outputDir, "tools/edited/edited.go", true)
+	assertFile(t, outputDir, "main.go", true)
+}
+
+func TestLoadManifest_RejectsPathsOutsideOutput(t *testing.T) {
+	root := t.TempDir()
+	outputDir := filepath.Join(root, "project", "out")
+	victim := filepath.Join(root, "victim.txt")
+	if err := os.WriteFile(victim, []byte("keep me\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{"../../victim.txt", "/etc/passwd", ""} {
+		m := &Manifest{Files: []ManifestEntry{{Path: path, SHA256: digest([]byte("keep me\n"))}}}
+		if err := m.Write(outputDir); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadManifest(outputDir); err == nil {
+			t.Errorf("LoadManifest() accepted the entry %q", path)
+		}
+	}
+
+	tmp := t.TempDir()
+	manifestPath := writeManifest(t, tmp, "")
+	gen := New(Config{Template: "minimal", Overwrite: true, Prune: true, Version: "test"})
+	if err := gen.Generate(manifestPath, outputDir); err == nil {
+		t.Error("Generate() with --prune must fail on a manifest pointing outside the output directory")
+	}
+	if _, err := os.Stat(victim); err != nil {
+		t.Errorf("file outside the output directory was removed: %v", err)
+	}
+}
+
+func TestGenerator_ManifestTracksOrphans(t *testing.T) {
+	tmp := t.TempDir()
+	out := filepath.Join(tmp, "out")
+	const claudeWorkflow = ".github/workflows/claude.yml"
+
+	withClaude := writeManifest(t, tmp, `  development:
+    ai:
+      orchestrators:
+        claudecode:
+          enabled: true
+`)
+	mustGenerate(t, withClaude, out, Config{Overwrite: true, Version: "test"})
+
+	m, err := LoadManifest(out)
+	if err != nil {
+		t.Fatalf("LoadManifest() failed: %v", err)
+	}
+	e, ok := m.entry(claudeWorkflow)
+	if !ok {
+		t.Fatalf("expected %s in the manifest, got %+v", claudeWorkflow, m.Files)
+	}
+	if e.SHA256 == "" || e.CLIVersion != "test" || e.TemplateKey == "" {
+		t.Errorf("incomplete manifest entry: %+v", e)
+	}
+
+	// Dropping the toggle without --prune keeps the file and its entry.
+	withoutClaude := writeManifest(t, tmp, "")
+	mustGenerate(t, withoutClaude, out, Config{Overwrite: true, Version: "test"})
+	assertFile(t, out, claudeWorkflow, true)
+	if m, err = LoadManifest(out); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m.entry(claudeWorkflow); !ok {
+		t.Errorf("an orphan that was not pruned must stay in the manifest")
+	}
+
+	mustGenerate(t, withoutClaude, out, Config{Overwrite: true, Prune: true, Version: "test"})
+	assertFile(t, out, claudeWorkflow, false)
+	if m, err = LoadManifest(out); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m.entry(claudeWorkflow); ok {
+		t.Errorf("a pruned file must be dropped from the manifest")
+	}
+}
@@ -0,0 +1,249 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+)
+
+func polyglotADL() *schema.ADL {
+	return &schema.ADL{
+		APIVersion: "adl.inference-gateway.com/v1",
+		Kind:       "Agent",
+		Metadata: schema.Metadata{
+			Name:        "polyglot-agent",
+			Description: "The same agent in Go and TypeScript",
+			Version:     "1.0.0",
+		},
+		Spec: schema.Spec{
+			Capabilities: schema.Capabilities{Streaming: true},
+			Server:       schema.Server{Port: 8080},
+			Hooks:        &schema.Hooks{Post: []string{"true"}},
+			Language: schema.Language{
+				Go: &schema.GoConfig{
+					Module:  "github.com/example/polyglot-agent",
+					Version: "1.26.4",
+				},
+				TypeScript: &schema.TypeScriptConfig{
+					PackageName: "polyglot-agent",
+					NodeVersion: "22",
+				},
+			},
+		},
+	}
+}
+
+// TestGenerator_MultiLanguage runs the full pipeline for a manifest that
+// defines two languages: each is generated as a standalone project in its
+// own subdirectory, the root gets the shared agent card and a
+// docker-compose file with one service per implementation, and the
+// manifest records every file relative to the root.
+func TestGenerator_MultiLanguage(t *testing.T) {
+	tmpDir := t.TempDir()
+	adlPath := filepath.Join(tmpDir, "agent.yaml")
+	writeYAML(t, adlPath, polyglotADL())
+
+	outDir := filepath.Join(tmpDir, "out")
+	gen := New(Config{Template: "minimal", Overwrite: true, Version: "test"})
+	if err := gen.Generate(adlPath, outDir); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	read := func(rel string) string {
+		t.Helper()
+		b, err := os.ReadFile(filepath.Join(outDir, rel))
+		if err != nil {
+			t.Fatalf("read %s: %v", rel, err)
+		}
+		return string(b)
+	}
+
+	for _, rel := range []string{
+		"go/main.go",
+		"go/go.mod",
+		"go/Dockerfile",
+		"go/.well-known/agent-card.json",
+		"typescript/src/index.ts",
+		"typescript/package.json",
+		"typescript/Dockerfile",
+		".well-known/agent-card.json",
+		"docker-compose.yaml",
+		ManifestPath,
+	} {
+		if _, err := os.Stat(filepath.Join(outDir, rel)); err != nil {
+			t.Errorf("expected %s: %v", rel, err)
+		}
+	}
+	for _, rel := range []string{"main.go", "package.json", "go/package.json", "typescript/go.mod", "go/" + ManifestPath} {
+		if _, err := os.Stat(filepath.Join(outDir, rel)); !os.IsNotExist(err) {
+			t.Errorf("%s must NOT exist, stat err = %v", rel, err)
+		}
+	}
+
+	if card, goCard := read(".well-known/agent-card.json"), read("go/.well-known/agent-card.json"); card != goCard {
+		t.Errorf("shared agent card differs from the Go project's copy\n--- root\n%s\n--- go\n%s", card, goCard)
+	}
+
+	compose := read("docker-compose.yaml")
+	for _, want := range []string{
+		"  polyglot-agent-go:\n    build: ./go\n    profiles:\n      - go\n",
+		"  polyglot-agent-typescript:\n    build: ./typescript\n    profiles:\n      - typescript\n",
+		"./.well-known/agent-card.json:/app/.well-known/agent-card.json:ro",
+		"http://polyglot-agent-go:8080\n        http://polyglot-agent-typescript:8080\n",
+	} {
+		if !strings.Contains(compose, want) {
+			t.Errorf("docker-compose.yaml missing %q\n---\n%s", want, compose)
+		}
+	}
+
+	if taskfile := read("go/Taskfile.yml"); !strings.Contains(taskfile, "adl generate --file ../agent.yaml --output ..") {
+		t.Errorf("go/Taskfile.yml should regenerate from the project root\n---\n%s", taskfile)
+	}
+
+	m, err := LoadManifest(outDir)
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+	for _, want := range []string{"docker-compose.yaml", "go/main.go", "typescript/src/index.ts"} {
+		if _, ok := m.entry(want); !ok {
+			t.Errorf("manifest missing %s", want)
+		}
+	}
+
+	gen = New(Config{Template: "minimal", Overwrite: true, Version: "test", DryRun: true})
+	if err := gen.Generate(adlPath, outDir); err != nil {
+		t.Fatalf("Generate (dry run): %v", err)
+	}
+	stale, err := Check(outDir, gen.Files(), m)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	for _, c := range stale {
+		t.Errorf("freshly generated file reported stale: %s (%s)", c.Path, c.Status)
+	}
+}
+
+// TestGenerator_LanguageSelection checks that Config.Language regenerates
+// one language of a multi-language manifest in its subdirectory, leaving
+// the shared root files and the other language alone even with Prune, and
+// rejects languages the manifest does not define.
+func TestGenerator_LanguageSelection(t *testing.T) {
+	tmpDir := t.TempDir()
+	adlPath := filepath.Join(tmpDir, "agent.yaml")
+	writeYAML(t, adlPath, polyglotADL())
+
+	outDir := filepath.Join(tmpDir, "out")
+	mustGenerate(t, adlPath, outDir, Config{Template: "minimal", Overwrite: true, Version: "test"})
+	if err := os.Remove(filepath.Join(outDir, "go", "main.go")); err != nil {
+		t.Fatal(err)
+	}
+
+	gen := New(Config{Template: "minimal", Overwrite: true, Version: "test", Language: "go", Prune: true})
+	if err := gen.Generate(adlPath, outDir); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	for _, rel := range []string{"go/main.go", "go/go.mod", "typescript/src/index.ts", "docker-compose.yaml", ".well-known/agent-card.json"} {
+		if _, err := os.Stat(filepath.Join(outDir, rel)); err != nil {
+			t.Errorf("expected %s: %v", rel, err)
+		}
+	}
+	for _, rel := range []string{"go.mod", "main.go"} {
+		if _, err := os.Stat(filepath.Join(outDir, rel)); !os.IsNotExist(err) {
+			t.Errorf("%s must NOT exist, stat err = %v", rel, err)
+		}
+	}
+	for _, f := range gen.Files() {
+		if !strings.HasPrefix(f.Path, "go/") {
+			t.Errorf("--language go rendered %s outside go/", f.Path)
+		}
+	}
+
+	full := New(Config{Template: "minimal", Overwrite: true, Version: "test", DryRun: true})
+	if err := full.Generate(adlPath, outDir); err != nil {
+		t.Fatalf("Generate(dry run): %v", err)
+	}
+	m, err := LoadManifest(outDir)
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+	if _, ok := m.entry("typescript/src/index.ts"); !ok {
+		t.Error("manifest lost the typescript/ entries after --language go")
+	}
+	stale, err := Check(outDir, full.Files(), m)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	for _, c := range stale {
+		t.Errorf("file reported stale after --language go: %s (%s)", c.Path, c.Status)
+	}
+	orphans, err := FindOrphans(outDir, m, full.Files(), full.Scope())
+	if err != nil {
+		t.Fatalf("FindOrphans: %v", err)
+	}
+	for _, o := range orphans {
+		t.Errorf("unexpected orphan after --language go: %s", o.Path)
+	}
+
+	for lang, wantErr := range map[string]string{
+		"rust":  "language rust is not defined in spec.language",
+		"cobol": "unsupported language: cobol",
+	} {
+		gen := New(Config{Template: "minimal", Version: "test", Language: lang, DryRun: true})
+		err := gen.Generate(adlPath, filepath.Join(tmpDir, lang))
+		if err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("Generate(--language %s) error = %v, want %q", lang, err, wantErr)
+		}
+	}
+}
+
+// TestGenerator_MultiLanguageWorkflows checks that a multi-language
+// project gets its CI/CD workflows and skills once, at the root where
+// GitHub and every implementation can find them, instead of in each
+// language subdirectory.
+func TestGenerator_MultiLanguageWorkflows(t *testing.T) {
+	tmpDir := t.TempDir()
+	adl := polyglotADL()
+	adl.Spec.SCM = &schema.SCM{Provider: schema.SCMProviderGithub, CI: true, CD: true}
+	adl.Spec.Skills = []schema.Skill{{ID: "summarize", Name: "Summarize", Description: "Summarize text", Bare: true}}
+	adlPath := filepath.Join(tmpDir, "agent.yaml")
+	writeYAML(t, adlPath, adl)
+
+	outDir := filepath.Join(tmpDir, "out")
+	mustGenerate(t, adlPath, outDir, Config{Template: "minimal", Overwrite: true, Version: "test"})
+
+	for _, rel := range []string{
+		".github/workflows/ci.yml",
+		".github/workflows/cd.yml",
+		".releaserc.yaml",
+		".agents/skills/summarize/SKILL.md",
+	} {
+		if _, err := os.Stat(filepath.Join(outDir, rel)); err != nil {
+			t.Errorf("expected %s: %v", rel, err)
+		}
+	}
+	for _, rel := range []string{"go/.github", "typescript/.github", "go/.releaserc.yaml", "go/.agents", "typescript/.agents"} {
+		if _, err := os.Stat(filepath.Join(outDir, rel)); !os.IsNotExist(err) {
+			t.Errorf("%s must NOT exist, stat err = %v", rel, err)
+		}
+	}
+
+	ci := readGenerated(t, outDir, ".github/workflows/ci.yml")
+	for _, want := range []string{
+		"  go:\n    name: Test (go)\n    runs-on: ubuntu-24.04\n    defaults:\n      run:\n        working-directory: go\n",
+		"  typescript:\n    name: Test (typescript)\n    runs-on: ubuntu-24.04\n    defaults:\n      run:\n        working-directory: typescript\n",
+		"go-version-file: go/go.mod",
+		"  drift:\n",
+		"run: adl generate --file agent.yaml --output . --overwrite",
+	} {
+		if !strings.Contains(ci, want) {
+			t.Errorf("ci.yml missing %q\n---\n%s", want, ci)
+		}
+	}
+	assertContains(t, readGenerated(t, outDir, ".releaserc.yaml"), "--push typescript", ".releaserc.yaml")
+	assertContains(t, readGenerated(t, outDir, "go/Dockerfile"), "COPY --from=skills . ./.agents/skills", "go/Dockerfile")
+	assertContains(t, readGenerated(t, outDir, "docker-compose.yaml"), "additional_contexts:\n        skills: ./.agents/skills\n", "docker-compose.yaml")
+	assertContains(t, readGenerated(t, outDir, "go/Taskfile.yml"), "adl validate ../agent.yaml", "go/Taskfile.yml")
+}
@@ -0,0 +1,55 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTemplate(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name)+".tmpl")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestGenerator_TemplateOverlays checks that overlay templates replace the
+// embedded ones, with --templates-dir winning over spec.templates.path and
+// that over the per-user directory.
+func TestGenerator_TemplateOverlays(t *testing.T) {
+	tmpDir := t.TempDir()
+	manifest := writeManifest(t, tmpDir, "  templates:\n    path: tpl\n")
+
+	user := filepath.Join(tmpDir, "user")
+	writeTemplate(t, user, "common/docker/dockerfile.go", "FROM user\n")
+	writeTemplate(t, user, "common/docs/LICENSE", "user license {{ .ADL.Metadata.Name }}\n")
+	project := filepath.Join(tmpDir, "tpl")
+	writeTemplate(t, project, "common/docker/dockerfile.go", "FROM project\n")
+
+	outDir := filepath.Join(tmpDir, "out")
+	mustGenerate(t, manifest, outDir, Config{Template: "minimal", Overwrite: true, Version: "test", UserTemplatesDir: user})
+	if got := readGenerated(t, outDir, "Dockerfile"); !strings.HasSuffix(got, "\nFROM project\n") {
+		t.Errorf("Dockerfile = %q, want the spec.templates.path overlay", got)
+	}
+	if got := readGenerated(t, outDir, "LICENSE"); !strings.HasSuffix(got, "user license ai-toggle-agent\n") {
+		t.Errorf("LICENSE = %q, want the rendered user overlay", got)
+	}
+	assertContains(t, readGenerated(t, outDir, "main.go"), "package main", "main.go")
+
+	flag := filepath.Join(tmpDir, "flag")
+	writeTemplate(t, flag, "common/docker/dockerfile.go", "FROM flag\n")
+	mustGenerate(t, manifest, outDir, Config{Template: "minimal", Overwrite: true, Version: "test", UserTemplatesDir: user, TemplatesDir: flag})
+	if got := readGenerated(t, outDir, "Dockerfile"); !strings.HasSuffix(got, "\nFROM flag\n") {
+		t.Errorf("Dockerfile = %q, want the --templates-dir overlay", got)
+	}
+
+	gen := New(Config{Template: "minimal", Version: "test", TemplatesDir: filepath.Join(tmpDir, "missing"), DryRun: true})
+	if err := gen.Generate(manifest, outDir); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("Generate with a missing --templates-dir error = %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+)
+
+// TestGenerator_PythonTools runs the full pipeline (manifest -> Generate())
+// for a Python agent: the package lives under src/<module>/, non-reserved
+// tools and services render one module each with their injected
+// dependencies, the regenerated tools/__init__.py wires them into the
+// ToolBox, generated modules carry a "# " header while tool and service
+// modules do not, and only the latter land in .adl-ignore.
+func TestGenerator_PythonTools(t *testing.T) {
+	adl := &schema.ADL{
+		APIVersion: "adl.inference-gateway.com/v1",
+		Kind:       "Agent",
+		Metadata: schema.Metadata{
+			Name:        "py-tools-agent",
+			Description: "A Python agent exercising tools + services",
+			Version:     "1.0.0",
+		},
+		Spec: schema.Spec{
+			Capabilities: schema.Capabilities{Streaming: false},
+			Server:       schema.Server{Port: 8080},
+			Agent: &schema.Agent{
+				Provider:     "openai",
+				Model:        "gpt-5.5",
+				SystemPrompt: "You are a test bot.",
+			},
+			Config: schema.SpecConfig{
+				"notifications": {
+					"retryAttempts": 3,
+				},
+			},
+			Services: schema.SpecServices{
+				"database": {
+					Interface:   "DatabaseService",
+					Factory:     "NewDatabaseService",
+					Type:        schema.ServiceTypeService,
+					Description: "Database access",
+				},
+			},
+			Tools: []schema.Tool{
+				{ID: "read"},
+				{
+					ID:          "query_database",
+					Name:        "query_database",
+					Description: "Run a read-only SQL query",
+					Tags:        []string{"data"},
+					Schema: schema.ToolSchema{
+						"type": "object",
+						"properties": map[string]any{
+							"query": map[string]any{"type": "string"},
+						},
+						"required": []any{"query"},
+					},
+					Inject: []string{"logger", "database", "config.notifications"},
+				},
+			},
+			Hooks: &schema.Hooks{Post: []string{"true"}},
+			Language: schema.Language{
+				Python: &schema.PythonConfig{
+					PackageName:   "py-tools-agent",
+					PythonVersion: "3.13",
+				},
+			},
+		},
+	}
+
+	tmpDir := t.TempDir()
+	adlPath := filepath.Join(tmpDir, "agent.yaml")
+	writeYAML(t, adlPath, adl)
+
+	outDir := filepath.Join(tmpDir, "out")
+	gen := New(Config{Template: "minimal", Overwrite: true, Version: "test"})
+	if err := gen.Generate(adlPath, outDir); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	read := func(rel string) string {
+		t.Helper()
+		b, err := os.ReadFile(filepath.Join(outDir, rel))
+		if err != nil {
+			t.Fatalf("read %s: %v", rel, err)
+		}
+		return string(b)
+	}
+
+	queryDatabase := read("src/py_tools_agent/tools/query_database.py")
+	for _, want := range []string{
+		"def create_query_database_tool(",
+		"logger: logging.Logger,",
+		"database: DatabaseService,",
+		"notifications_config: NotificationsConfig,",
+		"from ..services.database import DatabaseService",
+		`"query"`,
+		"# TODO: implement the `query_database` tool.",
+	} {
+		if !strings.Contains(queryDatabase, want) {
+			t.Errorf("tools/query_database.py missing %q\n---\n%s", want, queryDatabase)
+		}
+	}
+	if strings.HasPrefix(queryDatabase, "# Code generated") {
+		t.Errorf("tool modules are user-owned and must not carry the generated header\n---\n%s", queryDatabase)
+	}
+
+	database := read("src/py_tools_agent/services/database.py")
+	for _, want := range []string{
+		"class DatabaseService:",
+		"def new_database_service(logger: logging.Logger, config: Config) -> DatabaseService:",
+	} {
+		if !strings.Contains(database, want) {
+			t.Errorf("services/database.py missing %q\n---\n%s", want, database)
+		}
+	}
+
+	aggregator := read("src/py_tools_agent/tools/__init__.py")
+	for _, want := range []string{
+		"def build_toolbox(logger: logging.Logger, config: Config) -> ToolBox:",
+		"database = new_database_service(logger, config)",
+		"from .query_database import create_query_database_tool",
+		"config.notifications,",
+	} {
+		if !strings.Contains(aggregator, want) {
+			t.Errorf("tools/__init__.py missing %q\n---\n%s", want, aggregator)
+		}
+	}
+	if !strings.HasPrefix(aggregator, "# ") {
+		t.Errorf("tools/__init__.py should carry the generated header\n---\n%s", aggregator)
+	}
+	if strings.Contains(aggregator, "create_read_tool") {
+		t.Errorf("tools/__init__.py must not reference the reserved read tool\n---\n%s", aggregator)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "src", "py_tools_agent", "tools", "read.py")); !os.IsNotExist(err) {
+		t.Errorf("tools/read.py must NOT exist, stat err = %v", err)
+	}
+
+	if main := read("src/py_tools_agent/__main__.py"); !strings.Contains(main, "toolbox = build_toolbox(logger, config)") {
+		t.Errorf("__main__.py should build the toolbox from the aggregator\n---\n%s", main)
+	}
+
+	ignore := read(".adl-ignore")
+	for _, want := range []string{
+		"src/py_tools_agent/tools/query_database.py",
+		"src/py_tools_agent/services/database.py",
+		"uv.lock",
+		"# - Exact file names: src/py_tools_agent/tools/agent_tool.py\n",
+	} {
+		if !strings.Contains(ignore, want) {
+			t.Errorf(".adl-ignore missing %q\n---\n%s", want, ignore)
+		}
+	}
+	if strings.Contains(ignore, "tools/__init__.py") {
+		t.Errorf(".adl-ignore must NOT list the regenerated aggregator\n---\n%s", ignore)
+	}
+}
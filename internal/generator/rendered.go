@@ -0,0 +1,214 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/inference-gateway/adl-cli/internal/diff"
+)
+
+// RenderedFile is a single file produced by a Generate run. Paths are
+// slash-separated and relative to the output directory.
+type RenderedFile struct {
+	Path        string
+	TemplateKey string
+	Content     []byte
+	// Ignored is set when the path matches .adl-ignore; Content is empty
+	// because the generator never renders ignored files.
+	Ignored bool
+	// Skipped is set when the file already exists and Overwrite is off,
+	// so the generator leaves the copy on disk untouched.
+	Skipped bool
+	// Seed marks files that are created once and never overwritten
+	// (.adl-ignore, documentation and example stubs). They are only
+	// recorded when they do not exist yet.
+	Seed bool
+}
+
+// FileStatus classifies a rendered file against the output directory.
+type FileStatus string
+
+const (
+	FileCreated   FileStatus = "created"
+	FileModified  FileStatus = "modified"
+	FileUnchanged FileStatus = "unchanged"
+	FileIgnored   FileStatus = "ignored"
+)
+
+// FileChange is the result of comparing one RenderedFile with what is on
+// disk. Diff holds a unified diff for created and modified files.
+type FileChange struct {
+	Path   string
+	Status FileStatus
+	Diff   string
+}
+
+// Compare classifies every rendered file against outputDir without
+// writing anything. The result is sorted by path.
+func Compare(outputDir string, files []RenderedFile) ([]FileChange, error) {
+	changes := make([]FileChange, 0, len(files))
+	for _, f := range files {
+		if f.Ignored {
+			changes = append(changes, FileChange{Path: f.Path, Status: FileIgnored})
+			continue
+		}
+
+		existing, err := os.ReadFile(filepath.Join(outputDir, filepath.FromSlash(f.Path)))
+		switch {
+		case os.IsNotExist(err):
+			changes = append(changes, FileChange{
+				Path:   f.Path,
+				Status: FileCreated,
+				Diff:   diff.Unified("/dev/null", "b/"+f.Path, nil, f.Content),
+			})
+		case err != nil:
+			return nil, fmt.Errorf("failed to read %s: %w", f.Path, err)
+		case bytes.Equal(existing, f.Content):
+			changes = append(changes, FileChange{Path: f.Path, Status: FileUnchanged})
+		default:
+			changes = append(changes, FileChange{
+				Path:   f.Path,
+				Status: FileModified,
+				Diff:   diff.Unified("a/"+f.Path, "b/"+f.Path, existing, f.Content),
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// Check reports the rendered files whose committed copy in outputDir is
+// missing or out of date, as FileCreated and FileModified changes with a
+// diff. Ignored files and create-once seeds are not checked. A file that
+// differs from the rendered content still counts as up to date when the
+// difference is explained by post-generation commands, which Check does
+// not run: either it matches the gofmt'd rendering, or prev records that
+// this exact rendering was turned into this exact file last time.
+func Check(outputDir string, files []RenderedFile, prev *Manifest) ([]FileChange, error) {
+	var checked []RenderedFile
+	for _, f := range files {
+		if f.Ignored || f.Seed {
+			continue
+		}
+		checked = append(checked, f)
+	}
+
+	changes, err := Compare(outputDir, checked)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := make(map[string][]byte, len(checked))
+	for _, f := range checked {
+		rendered[f.Path] = f.Content
+	}
+
+	var stale []FileChange
+	for _, c := range changes {
+		switch c.Status {
+		case FileUnchanged:
+			continue
+		case FileModified:
+			existing, err := os.ReadFile(filepath.Join(outputDir, filepath.FromSlash(c.Path)))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", c.Path, err)
+			}
+			if postProcessed(c.Path, rendered[c.Path], existing, prev) {
+				continue
+			}
+		}
+		stale = append(stale, c)
+	}
+	return stale, nil
+}
+
+// postProcessed reports whether existing is what post-generation commands
+// make of content.
+func postProcessed(path string, content, existing []byte, prev *Manifest) bool {
+	if e, ok := prev.entry(path); ok && e.RenderedSHA256 != "" {
+		if e.RenderedSHA256 == digest(content) && e.SHA256 == digest(existing) {
+			return true
+		}
+	}
+	if strings.HasSuffix(path, ".go") {
+		if formatted, err := format.Source(content); err == nil && bytes.Equal(formatted, existing) {
+			return true
+		}
+	}
+	return false
+}
+
+// emit records a rendered file and, unless this is a dry run, writes it
+// to outputDir/relPath.
+func (g *Generator) emit(outputDir, relPath, templateKey, content string) error {
+	filePath := filepath.Join(outputDir, relPath)
+	skipped := false
+	if !g.config.Overwrite {
+		_, err := os.Stat(filePath)
+		skipped = err == nil
+	}
+	g.files = append(g.files, RenderedFile{
+		Path:        g.recordedPath(relPath),
+		TemplateKey: templateKey,
+		Content:     []byte(content),
+		Skipped:     skipped,
+	})
+	if g.config.DryRun {
+		return nil
+	}
+	return g.writeFile(filePath, content)
+}
+
+// skipIgnored reports whether relPath matches .adl-ignore. Matches are
+// recorded so dry runs can list them.
+func (g *Generator) skipIgnored(ignoreChecker *IgnoreChecker, relPath, templateKey string) bool {
+	if !ignoreChecker.ShouldIgnore(relPath) {
+		return false
+	}
+	g.printf("🚫 Ignoring file (matches .adl-ignore): %s\n", relPath)
+	g.files = append(g.files, RenderedFile{
+		Path:        g.recordedPath(relPath),
+		TemplateKey: templateKey,
+		Ignored:     true,
+	})
+	return true
+}
+
+// recordSeed records a create-once file that does not exist yet. It
+// returns true when the caller must not write it because this is a dry
+// run.
+func (g *Generator) recordSeed(relPath, content string) bool {
+	g.files = append(g.files, RenderedFile{
+		Path:    g.recordedPath(relPath),
+		Content: []byte(content),
+		Seed:    true,
+	})
+	return g.config.DryRun
+}
+
+// recordedPath returns the slash-separated path of relPath relative to
+// the output root.
+func (g *Generator) recordedPath(relPath string) string {
+	return filepath.ToSlash(filepath.Join(g.subdir, relPath))
+}
+
+// printf writes a progress line to stdout. Dry runs stay silent so the
+// diff is the only thing callers print.
+func (g *Generator) printf(format string, a ...any) {
+	if g.config.DryRun {
+		return
+	}
+	fmt.Printf(format, a...)
+}
+
+// skillTemplateKey is the pseudo template key recorded for files copied
+// verbatim from a resolved (non-bare) skill.
+func skillTemplateKey(id string) string {
+	return "skill:" + id
+}
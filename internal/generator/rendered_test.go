@@ -0,0 +1,185 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func findRendered(t *testing.T, files []RenderedFile, path string) RenderedFile {
+	t.Helper()
+	for _, f := range files {
+		if f.Path == path {
+			return f
+		}
+	}
+	t.Fatalf("expected %s to be rendered", path)
+	return RenderedFile{}
+}
+
+func TestGenerator_DryRunWritesNothing(t *testing.T) {
+	dir := t.TempDir()
+	manifest := writeManifest(t, dir, "")
+	outputDir := filepath.Join(dir, "out")
+
+	gen := New(Config{Template: "minimal", Version: "test", DryRun: true})
+	if err := gen.Generate(manifest, outputDir); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	if _, err := os.Stat(outputDir); !os.IsNotExist(err) {
+		t.Fatalf("dry run must not create the output directory, stat err = %v", err)
+	}
+
+	mainGo := findRendered(t, gen.Files(), "main.go")
+	if mainGo.TemplateKey != "main.go" {
+		t.Errorf("main.go template key = %q, want main.go", mainGo.TemplateKey)
+	}
+	if !strings.Contains(string(mainGo.Content), "package main") {
+		t.Errorf("main.go content was not rendered:\n%s", mainGo.Content)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	outputDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outputDir, "same.txt"), []byte("same\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, "changed.txt"), []byte("old\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := Compare(outputDir, []RenderedFile{
+		{Path: "new.txt", Content: []byte("new\n")},
+		{Path: "same.txt", Content: []byte("same\n")},
+		{Path: "changed.txt", Content: []byte("new\n")},
+		{Path: "tools/custom.go", Ignored: true},
+	})
+	if err != nil {
+		t.Fatalf("Compare() failed: %v", err)
+	}
+
+	want := map[string]FileStatus{
+		"changed.txt":     FileModified,
+		"new.txt":         FileCreated,
+		"same.txt":        FileUnchanged,
+		"tools/custom.go": FileIgnored,
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("got %d changes, want %d: %+v", len(changes), len(want), changes)
+	}
+	for i, c := range changes {
+		if i > 0 && changes[i-1].Path > c.Path {
+			t.Errorf("changes are not sorted by path: %q before %q", changes[i-1].Path, c.Path)
+		}
+		if want[c.Path] != c.Status {
+			t.Errorf("%s: status = %s, want %s", c.Path, c.Status, want[c.Path])
+		}
+		switch c.Status {
+		case FileCreated, FileModified:
+			if c.Diff == "" {
+				t.Errorf("%s: expected a diff", c.Path)
+			}
+		default:
+			if c.Diff != "" {
+				t.Errorf("%s: expected no diff, got:\n%s", c.Path, c.Diff)
+			}
+		}
+	}
+
+	for _, c := range changes {
+		if c.Path == "changed.txt" && !strings.Contains(c.Diff, "-old\n+new\n") {
+			t.Errorf("unexpected diff for changed.txt:\n%s", c.Diff)
+		}
+		if c.Path == "new.txt" && !strings.HasPrefix(c.Diff, "--- /dev/null\n+++ b/new.txt\n") {
+			t.Errorf("unexpected diff header for new.txt:\n%s", c.Diff)
+		}
+	}
+}
+
+func TestGenerator_DryRunReportsIgnoredFiles(t *testing.T) {
+	dir := t.TempDir()
+	manifest := writeManifest(t, dir, "")
+	outputDir := filepath.Join(dir, "out")
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, ".adl-ignore"), []byte("Dockerfile\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	gen := New(Config{Template: "minimal", Version: "test", DryRun: true})
+	if err := gen.Generate(manifest, outputDir); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	dockerfile := findRendered(t, gen.Files(), "Dockerfile")
+	if !dockerfile.Ignored || len(dockerfile.Content) != 0 {
+		t.Errorf("expected Dockerfile to be recorded as ignored without content, got %+v", dockerfile)
+	}
+	for _, f := range gen.Files() {
+		if f.Path == ".adl-ignore" {
+			t.Error("an existing .adl-ignore must not be re-recorded as a seed")
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	outputDir := t.TempDir()
+	files := map[string]string{
+		"fresh.txt":  "fresh\n",
+		"stale.txt":  "old\n",
+		"main.go":    "package main\n\nfunc main() {\n\tprintln(1)\n}\n",
+		"go.mod":     "module example.com/agent\n\nrequire example.com/dep v1.0.0\n",
+		"custom.txt": "user owned\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(outputDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	renderedGoMod := []byte("module example.com/agent\n")
+	prev := &Manifest{Files: []ManifestEntry{{
+		Path:           "go.mod",
+		SHA256:         digest([]byte(files["go.mod"])),
+		RenderedSHA256: digest(renderedGoMod),
+	}}}
+
+	stale, err := Check(outputDir, []RenderedFile{
+		{Path: "fresh.txt", Content: []byte("fresh\n")},
+		{Path: "stale.txt", Content: []byte("new\n")},
+		{Path: "missing.txt", Content: []byte("missing\n")},
+		// Differs only by gofmt.
+		{Path: "main.go", Content: []byte("package main\n\nfunc main() {\n    println( 1 )\n}\n")},
+		// Differs only by go mod tidy, as recorded in the manifest.
+		{Path: "go.mod", Content: renderedGoMod},
+		{Path: "custom.txt", Ignored: true},
+		{Path: "docs/seed.md", Content: []byte("seed\n"), Seed: true},
+	}, prev)
+	if err != nil {
+		t.Fatalf("Check() failed: %v", err)
+	}
+
+	want := []FileChange{
+		{Path: "missing.txt", Status: FileCreated},
+		{Path: "stale.txt", Status: FileModified},
+	}
+	if len(stale) != len(want) {
+		t.Fatalf("got %d stale files, want %d: %+v", len(stale), len(want), stale)
+	}
+	for i, c := range stale {
+		if c.Path != want[i].Path || c.Status != want[i].Status || c.Diff == "" {
+			t.Errorf("stale[%d] = %+v, want %s %s with a diff", i, c, want[i].Status, want[i].Path)
+		}
+	}
+}
+
+func TestGenerationTime_SourceDateEpoch(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+	if got := generationTime(); !got.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("generationTime() = %v, want the SOURCE_DATE_EPOCH timestamp", got)
+	}
+}
@@ -0,0 +1,165 @@
+package lsp
+
+import (
+	"regexp"
+	"strings"
+)
+
+// The editor's buffer is usually mid-edit and does not parse as YAML, so
+// completion and hover infer where the cursor is from indentation alone.
+
+// keyRe matches a block mapping key at the start of a line's content.
+var keyRe = regexp.MustCompile(`^([A-Za-z0-9_$.-]+)\s*:(\s|$)`)
+
+// lineInfo is the shape of one line of a block-style YAML document.
+type lineInfo struct {
+	// dash is the column of a "- " sequence marker, or -1.
+	dash int
+	// indent is the column where the line's content (after any marker)
+	// starts.
+	indent int
+	// key is the mapping key on the line, or "".
+	key string
+	// value is the text after "key:", or the whole content for lines
+	// without a key (scalar sequence items).
+	value string
+	// valueCol is the column where value starts.
+	valueCol int
+	// blank is set for empty and comment-only lines.
+	blank bool
+}
+
+func parseLine(s string) lineInfo {
+	li := lineInfo{dash: -1}
+	li.indent = len(s) - len(strings.TrimLeft(s, " "))
+	content := s[li.indent:]
+	if content == "" || strings.HasPrefix(content, "#") {
+		li.blank = true
+		return li
+	}
+	if content == "-" || strings.HasPrefix(content, "- ") {
+		li.dash = li.indent
+		rest := strings.TrimPrefix(content, "-")
+		trimmed := strings.TrimLeft(rest, " ")
+		li.indent += 1 + len(rest) - len(trimmed)
+		content = trimmed
+	}
+	li.valueCol = li.indent
+	li.value = content
+	if m := keyRe.FindStringSubmatchIndex(content); m != nil {
+		li.key = content[m[2]:m[3]]
+		trimmed := strings.TrimLeft(content[m[1]:], " ")
+		li.valueCol = li.indent + len(content) - len(trimmed)
+		li.value = trimmed
+	}
+	return li
+}
+
+// parentKeys returns the mapping keys enclosing content that starts at
+// column col on the given line, outermost first, by scanning upwards for
+// less indented keys. Sequences are transparent: the keys of a list item
+// resolve to the list's key, which is the form schema.Explain accepts.
+func parentKeys(lines []string, line, col int) []string {
+	limit := col
+	var keys []string
+	for i := line - 1; i >= 0 && limit > 0; i-- {
+		li := parseLine(lines[i])
+		if li.blank {
+			continue
+		}
+		if li.key != "" && li.indent < limit {
+			keys = append(keys, li.key)
+			limit = li.indent
+		}
+		if li.dash >= 0 && li.dash < limit {
+			limit = li.dash
+		}
+	}
+	for i, j := 0, len(keys)-1; i < j; i, j = i+1, j-1 {
+		keys[i], keys[j] = keys[j], keys[i]
+	}
+	return keys
+}
+
+// containerCol is the column parentKeys should start from for a line: the
+// content column, or just past the marker for sequence items so compact
+// sequences (`tools:` followed by `- id:` at the same column) resolve to
+// their key.
+func containerCol(li lineInfo) int {
+	if li.dash >= 0 {
+		return li.dash + 1
+	}
+	return li.indent
+}
+
+// cursor describes what the cursor is on, derived from the text before it.
+type cursor struct {
+	// path is the enclosing mapping keys, outermost first.
+	path []string
+	// key is set when the cursor is in the value position of key.
+	key string
+	// item is set when the cursor is on a scalar sequence item ("- foo").
+	item bool
+	// partial is the text typed so far at the cursor.
+	partial string
+	// comment is set when the cursor is inside a comment.
+	comment bool
+}
+
+// cursorAt inspects the text before pos.
+func cursorAt(lines []string, pos Position) cursor {
+	if pos.Line >= len(lines) {
+		return cursor{}
+	}
+	line := lines[pos.Line]
+	prefix := line[:min(pos.Character, len(line))]
+	li := parseLine(prefix)
+	if li.blank {
+		if strings.TrimSpace(prefix) != "" {
+			return cursor{comment: true}
+		}
+		return cursor{path: parentKeys(lines, pos.Line, len(prefix))}
+	}
+	if strings.HasPrefix(li.value, "#") || strings.Contains(li.value, " #") {
+		return cursor{comment: true}
+	}
+
+	c := cursor{path: parentKeys(lines, pos.Line, containerCol(li))}
+	switch {
+	case li.key != "":
+		c.key = li.key
+		c.partial = li.value
+	case li.dash >= 0 && isInjectPath(c.path):
+		c.item = true
+		c.partial = li.value
+	default:
+		c.partial = li.value
+	}
+	return c
+}
+
+// wordAt returns the identifier-like token of line around col, and the
+// column it starts at.
+func wordAt(line string, col int) (string, int) {
+	isWord := func(b byte) bool {
+		return b == '_' || b == '-' || b == '.' || b == '$' ||
+			'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9'
+	}
+	col = min(col, len(line))
+	start, end := col, col
+	for start > 0 && isWord(line[start-1]) {
+		start--
+	}
+	for end < len(line) && isWord(line[end]) {
+		end++
+	}
+	return line[start:end], start
+}
+
+func isInjectPath(path []string) bool {
+	return len(path) >= 3 && path[0] == "spec" && path[1] == "tools" && path[len(path)-1] == "inject"
+}
+
+func splitLines(text string) []string {
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
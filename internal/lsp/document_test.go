@@ -0,0 +1,114 @@
+package lsp
+
+import (
+	"strings"
+	"testing"
+)
+
+const testManifest = `apiVersion: adl.inference-gateway.com/v1
+kind: Agent
+metadata:
+  name: lsp-agent
+  description: LSP agent
+  version: 0.1.0
+spec:
+  capabilities:
+    streaming: true
+    pushNotifications: false
+    stateTransitionHistory: false
+  agent:
+    provider: openai
+    model: gpt-4o
+  server:
+    port: 8080
+  language:
+    go:
+      module: github.com/example/lsp-agent
+      version: "1.26.4"
+  services:
+    database:
+      type: repository
+      interface: Database
+      factory: NewDatabase
+      description: Database access
+  tools:
+    - id: query
+      name: query
+      description: Run a query
+      tags: [db]
+      schema:
+        type: object
+      inject:
+        - database
+    - id: read
+`
+
+func TestParentKeys(t *testing.T) {
+	lines := splitLines(testManifest)
+	cases := []struct {
+		line int
+		want string
+	}{
+		{line: 12, want: "spec.agent"},             // provider
+		{line: 22, want: "spec.services.database"}, // type
+		{line: 27, want: "spec.tools"},             // - id: query
+		{line: 28, want: "spec.tools"},             // name
+		{line: 32, want: "spec.tools.schema"},      // type
+		{line: 34, want: "spec.tools.inject"},      // - database
+		{line: 35, want: "spec.tools"},             // - id: read
+	}
+	for _, tc := range cases {
+		li := parseLine(lines[tc.line])
+		got := strings.Join(parentKeys(lines, tc.line, containerCol(li)), ".")
+		if got != tc.want {
+			t.Errorf("line %d %q: parents = %q, want %q", tc.line+1, lines[tc.line], got, tc.want)
+		}
+	}
+}
+
+func TestCursorAt(t *testing.T) {
+	lines := splitLines(testManifest)
+
+	c := cursorAt(lines, Position{Line: 12, Character: len("    provider: op")})
+	if strings.Join(c.path, ".") != "spec.agent" || c.key != "provider" || c.partial != "op" {
+		t.Errorf("value cursor = %+v", c)
+	}
+
+	c = cursorAt(lines, Position{Line: 34, Character: len("        - data")})
+	if !c.item || c.partial != "data" {
+		t.Errorf("inject item cursor = %+v", c)
+	}
+
+	c = cursorAt(lines, Position{Line: 27, Character: len("    - i")})
+	if strings.Join(c.path, ".") != "spec.tools" || c.key != "" || c.item {
+		t.Errorf("list item key cursor = %+v", c)
+	}
+
+	c = cursorAt(append(lines[:15:15], "    "), Position{Line: 15, Character: 4})
+	if strings.Join(c.path, ".") != "spec.server" {
+		t.Errorf("blank line cursor = %+v", c)
+	}
+
+	c = cursorAt([]string{"spec: # comm"}, Position{Line: 0, Character: 12})
+	if !c.comment {
+		t.Errorf("comment cursor = %+v", c)
+	}
+}
+
+func TestParseLine(t *testing.T) {
+	li := parseLine("    - id: query")
+	if li.dash != 4 || li.indent != 6 || li.key != "id" || li.value != "query" || li.valueCol != 10 {
+		t.Errorf("parseLine() = %+v", li)
+	}
+	li = parseLine("  spec:")
+	if li.dash != -1 || li.key != "spec" || li.value != "" {
+		t.Errorf("parseLine() = %+v", li)
+	}
+	li = parseLine("      - database")
+	if li.dash != 6 || li.key != "" || li.value != "database" {
+		t.Errorf("parseLine() = %+v", li)
+	}
+	if !parseLine("   # note").blank || !parseLine("").blank {
+		t.Error("comment and empty lines must be blank")
+	}
+}
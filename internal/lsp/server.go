@@ -0,0 +1,405 @@
+package lsp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+)
+
+// Server is an ADL language server for a single client. Messages are
+// handled one at a time in the order they arrive.
+type Server struct {
+	conn      *conn
+	validator *schema.Validator
+	docs      map[string]string
+}
+
+// NewServer returns a server that reads client messages from r and writes
+// responses and notifications to w.
+func NewServer(r io.Reader, w io.Writer) *Server {
+	return &Server{
+		conn:      newConn(r, w),
+		validator: schema.NewValidator(),
+		docs:      make(map[string]string),
+	}
+}
+
+// Run serves the client until it sends exit or closes the connection.
+func (s *Server) Run() error {
+	for {
+		req, err := s.conn.read()
+		var rerr *responseError
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+		case errors.As(err, &rerr):
+			if err := s.conn.write(response{JSONRPC: "2.0", Error: rerr}); err != nil {
+				return err
+			}
+			continue
+		case err != nil:
+			return fmt.Errorf("failed to read message: %w", err)
+		}
+
+		if req.Method == "exit" {
+			return nil
+		}
+		result, rerr := s.handle(req)
+		if req.ID == nil {
+			continue
+		}
+		if err := s.conn.write(response{JSONRPC: "2.0", ID: req.ID, Result: result, Error: rerr}); err != nil {
+			return fmt.Errorf("failed to write response: %w", err)
+		}
+	}
+}
+
+func (s *Server) handle(req *request) (any, *responseError) {
+	switch req.Method {
+	case "initialize":
+		return map[string]any{
+			"capabilities": map[string]any{
+				"textDocumentSync":   textDocumentSyncFull,
+				"completionProvider": map[string]any{"triggerCharacters": []string{":", " ", "-"}},
+				"hoverProvider":      true,
+				"definitionProvider": true,
+			},
+			"serverInfo": map[string]any{"name": "adl"},
+		}, nil
+	case "shutdown":
+		return nil, nil
+
+	case "textDocument/didOpen":
+		p, err := decode[DidOpenTextDocumentParams](req.Params)
+		if err != nil {
+			return nil, err
+		}
+		s.docs[p.TextDocument.URI] = p.TextDocument.Text
+		return nil, s.publish(p.TextDocument.URI)
+	case "textDocument/didChange":
+		p, err := decode[DidChangeTextDocumentParams](req.Params)
+		if err != nil {
+			return nil, err
+		}
+		if n := len(p.ContentChanges); n > 0 {
+			s.docs[p.TextDocument.URI] = p.ContentChanges[n-1].Text
+		}
+		return nil, s.publish(p.TextDocument.URI)
+	case "textDocument/didClose":
+		p, err := decode[DidCloseTextDocumentParams](req.Params)
+		if err != nil {
+			return nil, err
+		}
+		delete(s.docs, p.TextDocument.URI)
+		return nil, s.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{URI: p.TextDocument.URI, Diagnostics: []Diagnostic{}})
+
+	case "textDocument/completion":
+		p, err := decode[TextDocumentPositionParams](req.Params)
+		if err != nil {
+			return nil, err
+		}
+		return s.completion(p), nil
+	case "textDocument/hover":
+		p, err := decode[TextDocumentPositionParams](req.Params)
+		if err != nil {
+			return nil, err
+		}
+		return s.hover(p), nil
+	case "textDocument/definition":
+		p, err := decode[TextDocumentPositionParams](req.Params)
+		if err != nil {
+			return nil, err
+		}
+		return s.definition(p), nil
+	}
+
+	if req.ID != nil {
+		return nil, &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %q is not supported", req.Method)}
+	}
+	return nil, nil
+}
+
+func decode[T any](raw json.RawMessage) (T, *responseError) {
+	var v T
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return v, &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return v, nil
+}
+
+func (s *Server) notify(method string, params any) *responseError {
+	if err := s.conn.write(notification{JSONRPC: "2.0", Method: method, Params: params}); err != nil {
+		return &responseError{Code: codeParseError, Message: err.Error()}
+	}
+	return nil
+}
+
+// publish validates and lints the document and sends the findings.
+func (s *Server) publish(uri string) *responseError {
+	text := s.docs[uri]
+	lines := splitLines(text)
+	diags := []Diagnostic{}
+	for _, d := range s.validator.Lint([]byte(text)) {
+		severity := severityError
+		if d.Severity == schema.SeverityWarning {
+			severity = severityWarning
+		}
+		diags = append(diags, Diagnostic{
+			Range:    diagnosticRange(lines, d.Line, d.Column),
+			Severity: severity,
+			Code:     d.RuleID,
+			Source:   "adl",
+			Message:  d.Message,
+		})
+	}
+	return s.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{URI: uri, Diagnostics: diags})
+}
+
+// diagnosticRange converts a 1-based schema.Diagnostic position into a
+// range covering the token there, or the rest of the line.
+func diagnosticRange(lines []string, line, column int) Range {
+	if line <= 0 || line > len(lines) {
+		return Range{}
+	}
+	start := Position{Line: line - 1, Character: max(column-1, 0)}
+	text := lines[line-1]
+	word, at := wordAt(text, start.Character)
+	end := Position{Line: start.Line, Character: at + len(word)}
+	if word == "" || at != start.Character {
+		end.Character = len(text)
+	}
+	return Range{Start: start, End: end}
+}
+
+func (s *Server) completion(p TextDocumentPositionParams) CompletionList {
+	list := CompletionList{Items: []CompletionItem{}}
+	lines := splitLines(s.docs[p.TextDocument.URI])
+	c := cursorAt(lines, p.Position)
+	switch {
+	case c.comment:
+	case c.item || c.key == "inject" && isInjectPath(append(c.path, c.key)):
+		list.Items = injectCompletions(lines)
+	case c.key != "":
+		list.Items = valueCompletions(append(c.path, c.key))
+	default:
+		list.Items = keyCompletions(c.path)
+	}
+	return list
+}
+
+// keyCompletions offers the properties of the object at path.
+func keyCompletions(path []string) []CompletionItem {
+	items := []CompletionItem{}
+	field, err := schema.Explain(strings.Join(path, "."))
+	if err != nil || strings.HasPrefix(field.Type, "map of ") {
+		return items
+	}
+	for _, prop := range field.Fields {
+		insert := prop.Name + ":"
+		if isScalarType(prop.Type) {
+			insert += " "
+		}
+		item := CompletionItem{Label: prop.Name, Kind: completionKindProperty, Detail: prop.Type, InsertText: insert}
+		if child, err := schema.Explain(strings.Join(append(path, prop.Name), ".")); err == nil && child.Description != "" {
+			item.Documentation = &MarkupContent{Kind: "markdown", Value: child.Description}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// isScalarType reports whether a schema.SchemaProperty type is written
+// on the same line as its key.
+func isScalarType(t string) bool {
+	switch t {
+	case "string", "integer", "number", "boolean", "any":
+		return true
+	}
+	return false
+}
+
+// valueCompletions offers the allowed values of the field at path: enum
+// members, booleans and reserved built-in tool IDs.
+func valueCompletions(path []string) []CompletionItem {
+	items := []CompletionItem{}
+	joined := strings.Join(path, ".")
+	if joined == "spec.tools.id" {
+		for _, id := range slices.Sorted(maps.Keys(schema.ReservedToolIDs)) {
+			meta := schema.BuiltinToolMetaFor(id)
+			items = append(items, CompletionItem{
+				Label:         id,
+				Kind:          completionKindValue,
+				Detail:        meta.Name + " built-in",
+				Documentation: &MarkupContent{Kind: "markdown", Value: meta.Description},
+			})
+		}
+		return items
+	}
+
+	field, err := schema.Explain(joined)
+	if err != nil {
+		return items
+	}
+	values := field.Enum
+	if field.Const != nil {
+		values = []any{field.Const}
+	}
+	if field.Type == "boolean" {
+		values = []any{true, false}
+	}
+	for _, v := range values {
+		label := fmt.Sprint(v)
+		if label == "" {
+			continue
+		}
+		items = append(items, CompletionItem{Label: label, Kind: completionKindValue, Detail: field.Type})
+	}
+	return items
+}
+
+// injectCompletions offers the spec.services keys and spec.config
+// sections a tool can inject.
+func injectCompletions(lines []string) []CompletionItem {
+	items := []CompletionItem{}
+	for _, name := range childKeys(lines, []string{"spec", "services"}) {
+		items = append(items, CompletionItem{Label: name, Kind: completionKindReference, Detail: "service"})
+	}
+	for _, name := range childKeys(lines, []string{"spec", "config"}) {
+		if name == "tools" {
+			continue
+		}
+		items = append(items, CompletionItem{Label: "config." + name, Kind: completionKindReference, Detail: "config section"})
+	}
+	return items
+}
+
+func (s *Server) hover(p TextDocumentPositionParams) *Hover {
+	lines := splitLines(s.docs[p.TextDocument.URI])
+	if p.Position.Line >= len(lines) {
+		return nil
+	}
+	li := parseLine(lines[p.Position.Line])
+	if li.key == "" {
+		return nil
+	}
+	path := append(parentKeys(lines, p.Position.Line, containerCol(li)), li.key)
+	char := p.Position.Character
+
+	if char >= li.valueCol && strings.Join(path, ".") == "spec.tools.id" {
+		meta := schema.BuiltinToolMetaFor(li.value)
+		if meta.Name == "" {
+			return nil
+		}
+		return &Hover{
+			Contents: MarkupContent{Kind: "markdown", Value: fmt.Sprintf("**%s** built-in tool\n\n%s\n\nParameters: `%s`", meta.Name, meta.Description, strings.Join(meta.Parameters, "`, `"))},
+			Range:    &Range{Start: Position{p.Position.Line, li.valueCol}, End: Position{p.Position.Line, li.valueCol + len(li.value)}},
+		}
+	}
+	if char < li.indent || char > li.indent+len(li.key) {
+		return nil
+	}
+
+	field, err := schema.Explain(strings.Join(path, "."))
+	if err != nil {
+		return nil
+	}
+	return &Hover{
+		Contents: MarkupContent{Kind: "markdown", Value: hoverMarkdown(field)},
+		Range:    &Range{Start: Position{p.Position.Line, li.indent}, End: Position{p.Position.Line, li.indent + len(li.key)}},
+	}
+}
+
+func hoverMarkdown(f *schema.SchemaField) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s** `%s`", f.Path, f.Type)
+	if f.Required {
+		b.WriteString(" (required)")
+	}
+	if f.Description != "" {
+		fmt.Fprintf(&b, "\n\n%s", f.Description)
+	}
+	var facts []string
+	if len(f.Enum) > 0 {
+		var values []string
+		for _, v := range f.Enum {
+			values = append(values, fmt.Sprintf("`%v`", v))
+		}
+		facts = append(facts, "Values: "+strings.Join(values, ", "))
+	}
+	if f.Default != nil {
+		facts = append(facts, fmt.Sprintf("Default: `%v`", f.Default))
+	}
+	if len(f.OneOf) > 0 {
+		facts = append(facts, "One of: `"+strings.Join(f.OneOf, "` | `")+"`")
+	}
+	if len(facts) > 0 {
+		fmt.Fprintf(&b, "\n\n%s", strings.Join(facts, " · "))
+	}
+	return b.String()
+}
+
+// definition jumps from a spec.tools[].inject entry to the spec.services
+// key (or spec.config section) it names.
+func (s *Server) definition(p TextDocumentPositionParams) []Location {
+	lines := splitLines(s.docs[p.TextDocument.URI])
+	if p.Position.Line >= len(lines) {
+		return nil
+	}
+	line := lines[p.Position.Line]
+	li := parseLine(line)
+	path := parentKeys(lines, p.Position.Line, containerCol(li))
+
+	onEntry := li.key == "" && li.dash >= 0 && isInjectPath(path) ||
+		li.key == "inject" && isInjectPath(append(path, li.key)) && p.Position.Character >= li.valueCol
+	if !onEntry {
+		return nil
+	}
+	word, _ := wordAt(line, p.Position.Character)
+	if word == "" {
+		return nil
+	}
+
+	target := []string{"spec", "services", word}
+	if section, ok := strings.CutPrefix(word, "config."); ok {
+		target = []string{"spec", "config", section}
+	}
+	at, ok := findKey(lines, target)
+	if !ok {
+		return nil
+	}
+	key := parseLine(lines[at])
+	return []Location{{
+		URI:   p.TextDocument.URI,
+		Range: Range{Start: Position{at, key.indent}, End: Position{at, key.indent + len(key.key)}},
+	}}
+}
+
+// childKeys lists the keys of the mapping at path, in document order.
+func childKeys(lines []string, path []string) []string {
+	var keys []string
+	for i, line := range lines {
+		li := parseLine(line)
+		if li.key != "" && slices.Equal(parentKeys(lines, i, containerCol(li)), path) {
+			keys = append(keys, li.key)
+		}
+	}
+	return keys
+}
+
+// findKey returns the line holding the last key of path.
+func findKey(lines []string, path []string) (int, bool) {
+	last := path[len(path)-1]
+	for i, line := range lines {
+		li := parseLine(line)
+		if li.key == last && slices.Equal(parentKeys(lines, i, containerCol(li)), path[:len(path)-1]) {
+			return i, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,247 @@
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// testClient drives a Server over in-memory pipes.
+type testClient struct {
+	t      *testing.T
+	conn   *conn
+	nextID int
+	done   chan error
+}
+
+func newTestClient(t *testing.T) *testClient {
+	t.Helper()
+	clientR, serverW := io.Pipe()
+	serverR, clientW := io.Pipe()
+	c := &testClient{t: t, conn: newConn(clientR, clientW), done: make(chan error, 1)}
+	go func() {
+		c.done <- NewServer(serverR, serverW).Run()
+		_ = serverW.Close()
+	}()
+	t.Cleanup(func() { _ = clientW.Close() })
+	return c
+}
+
+type incoming struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *responseError  `json:"error"`
+}
+
+func (c *testClient) receive() incoming {
+	c.t.Helper()
+	body, err := c.conn.readBody()
+	if err != nil {
+		c.t.Fatalf("failed to read from server: %v", err)
+	}
+	var msg incoming
+	if err := json.Unmarshal(body, &msg); err != nil {
+		c.t.Fatalf("invalid message %s: %v", body, err)
+	}
+	return msg
+}
+
+func (c *testClient) notify(method string, params any) {
+	c.t.Helper()
+	if err := c.conn.write(notification{JSONRPC: "2.0", Method: method, Params: params}); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// call sends a request and decodes its result into out.
+func (c *testClient) call(method string, params, out any) {
+	c.t.Helper()
+	c.nextID++
+	id := json.RawMessage(fmt.Sprint(c.nextID))
+	if err := c.conn.write(map[string]any{"jsonrpc": "2.0", "id": &id, "method": method, "params": params}); err != nil {
+		c.t.Fatal(err)
+	}
+	msg := c.receive()
+	if msg.ID == nil || *msg.ID != c.nextID {
+		c.t.Fatalf("expected response %d, got %+v", c.nextID, msg)
+	}
+	if msg.Error != nil {
+		c.t.Fatalf("%s failed: %s", method, msg.Error.Message)
+	}
+	if out != nil {
+		if err := json.Unmarshal(msg.Result, out); err != nil {
+			c.t.Fatalf("failed to decode %s result %s: %v", method, msg.Result, err)
+		}
+	}
+}
+
+func (c *testClient) diagnostics() PublishDiagnosticsParams {
+	c.t.Helper()
+	msg := c.receive()
+	if msg.Method != "textDocument/publishDiagnostics" {
+		c.t.Fatalf("expected diagnostics, got %+v", msg)
+	}
+	var p PublishDiagnosticsParams
+	if err := json.Unmarshal(msg.Params, &p); err != nil {
+		c.t.Fatal(err)
+	}
+	return p
+}
+
+func position(uri string, line, char int) TextDocumentPositionParams {
+	return TextDocumentPositionParams{TextDocument: TextDocumentIdentifier{URI: uri}, Position: Position{Line: line, Character: char}}
+}
+
+func labels(items []CompletionItem) []string {
+	var out []string
+	for _, item := range items {
+		out = append(out, item.Label)
+	}
+	return out
+}
+
+func TestServer(t *testing.T) {
+	const uri = "file:///work/agent.yaml"
+	c := newTestClient(t)
+
+	var init struct {
+		Capabilities map[string]any `json:"capabilities"`
+	}
+	c.call("initialize", map[string]any{}, &init)
+	if init.Capabilities["hoverProvider"] != true || init.Capabilities["definitionProvider"] != true {
+		t.Errorf("unexpected capabilities: %v", init.Capabilities)
+	}
+	c.notify("initialized", map[string]any{})
+
+	c.notify("textDocument/didOpen", DidOpenTextDocumentParams{TextDocument: TextDocumentItem{URI: uri, LanguageID: "yaml", Version: 1, Text: testManifest}})
+	if diags := c.diagnostics(); diags.URI != uri || len(diags.Diagnostics) != 0 {
+		t.Fatalf("expected a clean manifest, got %+v", diags)
+	}
+
+	broken := strings.Replace(testManifest, "port: 8080", `port: "8080"`, 1)
+	c.notify("textDocument/didChange", map[string]any{
+		"textDocument":   map[string]any{"uri": uri, "version": 2},
+		"contentChanges": []map[string]any{{"text": broken}},
+	})
+	diags := c.diagnostics()
+	if len(diags.Diagnostics) != 1 {
+		t.Fatalf("expected one diagnostic, got %+v", diags)
+	}
+	d := diags.Diagnostics[0]
+	if d.Severity != severityError || d.Code != "schema" || d.Range.Start != (Position{Line: 15, Character: 4}) || d.Range.End.Character != 8 {
+		t.Errorf("unexpected diagnostic: %+v", d)
+	}
+
+	c.notify("textDocument/didChange", map[string]any{
+		"textDocument":   map[string]any{"uri": uri, "version": 3},
+		"contentChanges": []map[string]any{{"text": testManifest}},
+	})
+	c.diagnostics()
+
+	t.Run("completion of enum values", func(t *testing.T) {
+		var list CompletionList
+		c.call("textDocument/completion", position(uri, 12, len("    provider: ")), &list)
+		got := strings.Join(labels(list.Items), ",")
+		if !strings.Contains(got, "anthropic") || !strings.Contains(got, "openai") || strings.HasPrefix(got, ",") {
+			t.Errorf("provider completions = %s", got)
+		}
+
+		c.call("textDocument/completion", position(uri, 22, len("      type: ")), &list)
+		if got := strings.Join(labels(list.Items), ","); got != "service,repository,client,middleware" {
+			t.Errorf("service type completions = %s", got)
+		}
+	})
+
+	t.Run("completion of reserved tool IDs", func(t *testing.T) {
+		var list CompletionList
+		c.call("textDocument/completion", position(uri, 35, len("    - id: ")), &list)
+		if got := strings.Join(labels(list.Items), ","); got != "bash,edit,fetch,read,write" {
+			t.Errorf("tool id completions = %s", got)
+		}
+	})
+
+	t.Run("completion of keys", func(t *testing.T) {
+		var list CompletionList
+		c.call("textDocument/completion", position(uri, 13, len("    ")), &list)
+		got := labels(list.Items)
+		if !strings.Contains(strings.Join(got, ","), "systemPrompt") {
+			t.Errorf("spec.agent key completions = %v", got)
+		}
+		for _, item := range list.Items {
+			if item.Label == "maxTokens" && item.InsertText != "maxTokens: " {
+				t.Errorf("scalar keys should insert 'key: ', got %q", item.InsertText)
+			}
+			if item.Label == "mcp" && item.InsertText != "mcp:" {
+				t.Errorf("object keys should insert 'key:', got %q", item.InsertText)
+			}
+		}
+	})
+
+	t.Run("completion of inject entries", func(t *testing.T) {
+		var list CompletionList
+		c.call("textDocument/completion", position(uri, 34, len("        - ")), &list)
+		if got := strings.Join(labels(list.Items), ","); got != "database" {
+			t.Errorf("inject completions = %s", got)
+		}
+	})
+
+	t.Run("hover", func(t *testing.T) {
+		var hover Hover
+		c.call("textDocument/hover", position(uri, 12, 6), &hover)
+		if !strings.Contains(hover.Contents.Value, "**spec.agent.provider** `string`") || !strings.Contains(hover.Contents.Value, "`anthropic`") {
+			t.Errorf("provider hover = %q", hover.Contents.Value)
+		}
+
+		c.call("textDocument/hover", position(uri, 35, len("    - id: r")), &hover)
+		if !strings.Contains(hover.Contents.Value, "**Read** built-in tool") {
+			t.Errorf("reserved tool hover = %q", hover.Contents.Value)
+		}
+	})
+
+	t.Run("definition", func(t *testing.T) {
+		var locs []Location
+		c.call("textDocument/definition", position(uri, 34, len("        - data")), &locs)
+		want := Range{Start: Position{Line: 21, Character: 4}, End: Position{Line: 21, Character: 12}}
+		if len(locs) != 1 || locs[0].URI != uri || locs[0].Range != want {
+			t.Errorf("definition = %+v, want %+v", locs, want)
+		}
+
+		c.call("textDocument/definition", position(uri, 12, 14), &locs)
+		if len(locs) != 0 {
+			t.Errorf("expected no definition outside inject, got %+v", locs)
+		}
+	})
+
+	c.call("shutdown", nil, nil)
+	c.notify("exit", nil)
+	if err := <-c.done; err != nil {
+		t.Errorf("Run() returned %v", err)
+	}
+}
+
+func TestServer_UnknownMethod(t *testing.T) {
+	c := newTestClient(t)
+	c.nextID++
+	id := json.RawMessage("1")
+	if err := c.conn.write(map[string]any{"jsonrpc": "2.0", "id": &id, "method": "workspace/symbol"}); err != nil {
+		t.Fatal(err)
+	}
+	msg := c.receive()
+	if msg.Error == nil || msg.Error.Code != codeMethodNotFound {
+		t.Errorf("expected method not found, got %+v", msg)
+	}
+}
+
+func TestDiagnosticRange(t *testing.T) {
+	lines := []string{"spec:", "  port: \"8080\""}
+	if got := diagnosticRange(lines, 2, 3); got != (Range{Start: Position{1, 2}, End: Position{1, 6}}) {
+		t.Errorf("diagnosticRange() = %+v", got)
+	}
+	if got := diagnosticRange(lines, 0, 0); got != (Range{}) {
+		t.Errorf("unknown positions must map to the start of the document, got %+v", got)
+	}
+}
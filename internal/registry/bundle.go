@@ -0,0 +1,198 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+)
+
+// A bundle is a gzipped tar archive holding an ADL file, its adl.lock and
+// the files of every skill it resolves to, so the skills cache of a
+// machine without network access can be seeded with exactly what the ADL
+// needs. Its layout is:
+//
+//	bundle.json                  the bundleIndex
+//	<manifest>                   the ADL file, under its original name
+//	adl.lock                     the lock the skills were resolved against
+//	skills/<id>@<ref>/<file>...  the cached files of every skill
+const (
+	bundleIndexFile = "bundle.json"
+	bundleSkillsDir = "skills/"
+)
+
+// bundleVersion is the format version written to new bundles.
+const bundleVersion = 1
+
+// bundleSkillIDRe is the schema's pattern for skill ids. The ids and refs
+// of a bundle name skill cache directories, so ReadBundle holds them to
+// it rather than trusting the archive.
+var bundleSkillIDRe = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9_-]*$`)
+
+// Bundle is the content of a bundle archive.
+type Bundle struct {
+	// ManifestName is the base name of the ADL file and Manifest its
+	// content.
+	ManifestName string
+	Manifest     []byte
+	// Lock is the lock the skills were resolved against, if any.
+	Lock   *Lock
+	Skills []BundledSkill
+}
+
+// BundledSkill is one skill cache entry carried by a bundle.
+type BundledSkill struct {
+	ID string `json:"id"`
+	// Ref is the ref the skill is cached under; see ResolvedSkill.Ref.
+	Ref string `json:"ref,omitempty"`
+	// Revision is the upstream revision of the cache entry, if known.
+	Revision string `json:"revision,omitempty"`
+	// SHA256 maps every file to the hex SHA-256 digest of its content.
+	SHA256 map[string]string `json:"sha256"`
+	Files  map[string][]byte `json:"-"`
+}
+
+type bundleIndex struct {
+	Version  int            `json:"bundleVersion"`
+	Manifest string         `json:"manifest"`
+	Skills   []BundledSkill `json:"skills"`
+}
+
+// BundleSkills resolves skills and returns the ones the skills cache
+// holds, that is all but bare skills and local directories, as they
+// would be read from the cache offline.
+func (r *Resolver) BundleSkills(ctx context.Context, skills []schema.Skill) ([]BundledSkill, error) {
+	resolved, err := r.ResolveAll(ctx, skills)
+	if err != nil {
+		return nil, err
+	}
+	var bundled []BundledSkill
+	for i, rs := range resolved {
+		if rs.Bare || IsLocalSource(skills[i].Source) {
+			continue
+		}
+		b := BundledSkill{ID: rs.ID, Ref: rs.Ref, SHA256: digestFiles(rs.Files), Files: rs.Files}
+		entry, ok, err := r.Cache.Entry(rs.ID, rs.Ref)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			b.Revision = entry.Revision
+		}
+		bundled = append(bundled, b)
+	}
+	return bundled, nil
+}
+
+// Write writes the bundle as a gzipped tar archive. Entries are sorted
+// and carry no timestamps, so the same content always yields the same
+// archive.
+func (b *Bundle) Write(w io.Writer) error {
+	skills := slices.SortedFunc(slices.Values(b.Skills), func(a, b BundledSkill) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	index, err := json.MarshalIndent(bundleIndex{Version: bundleVersion, Manifest: b.ManifestName, Skills: skills}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode bundle index: %w", err)
+	}
+
+	a := newArchiveWriter(w)
+	if err := a.add(bundleIndexFile, append(index, '\n')); err != nil {
+		return err
+	}
+	if err := a.add(b.ManifestName, b.Manifest); err != nil {
+		return err
+	}
+	if b.Lock != nil {
+		data, err := b.Lock.Marshal()
+		if err != nil {
+			return err
+		}
+		if err := a.add(LockFile, data); err != nil {
+			return err
+		}
+	}
+	for _, s := range skills {
+		dir := bundleSkillDir(s)
+		for _, name := range slices.Sorted(maps.Keys(s.Files)) {
+			if err := a.add(dir+name, s.Files[name]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return a.close()
+}
+
+// ReadBundle reads a bundle archive written by Bundle.Write, checking
+// every skill file against the digests in its index.
+func ReadBundle(r io.Reader) (*Bundle, error) {
+	entries, err := readArchive(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle: %w", err)
+	}
+
+	data, ok := entries[bundleIndexFile]
+	if !ok {
+		return nil, fmt.Errorf("not an adl bundle: %s is missing", bundleIndexFile)
+	}
+	var index bundleIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", bundleIndexFile, err)
+	}
+	if index.Version > bundleVersion {
+		return nil, fmt.Errorf("bundle has bundleVersion %d; this CLI supports up to %d, upgrade adl", index.Version, bundleVersion)
+	}
+
+	b := &Bundle{ManifestName: index.Manifest, Skills: index.Skills}
+	if b.Manifest, ok = entries[index.Manifest]; !ok || index.Manifest == "" {
+		return nil, fmt.Errorf("bundle does not contain its ADL file %q", index.Manifest)
+	}
+	if data, ok := entries[LockFile]; ok {
+		if b.Lock, err = ParseLock(data); err != nil {
+			return nil, err
+		}
+	}
+	for i := range b.Skills {
+		s := &b.Skills[i]
+		if !bundleSkillIDRe.MatchString(s.ID) {
+			return nil, fmt.Errorf("bundle has an invalid skill id %q", s.ID)
+		}
+		if strings.ContainsAny(s.Ref, `/\`) || strings.Contains(s.Ref, "..") {
+			return nil, fmt.Errorf("bundled skill %q has an invalid ref %q", s.ID, s.Ref)
+		}
+		dir := bundleSkillDir(*s)
+		s.Files = make(map[string][]byte)
+		for name, data := range entries {
+			if rel, ok := strings.CutPrefix(name, dir); ok {
+				s.Files[rel] = data
+			}
+		}
+		if len(s.Files) == 0 || !maps.Equal(digestFiles(s.Files), s.SHA256) {
+			return nil, fmt.Errorf("bundled skill %q is incomplete or corrupted", s.ID)
+		}
+	}
+	return b, nil
+}
+
+// Seed stores every bundled skill in cache, replacing the entries
+// already there.
+func (b *Bundle) Seed(cache *Cache) error {
+	for _, s := range b.Skills {
+		if err := cache.PutRevision(s.ID, s.Ref, s.Revision, s.Files); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func bundleSkillDir(s BundledSkill) string {
+	return bundleSkillsDir + s.ID + "@" + cmp.Or(s.Ref, "latest") + "/"
+}
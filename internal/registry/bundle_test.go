@@ -0,0 +1,143 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+)
+
+func TestBundle_RoundTripSeedsOfflineCache(t *testing.T) {
+	resolver, closer := newTestResolver(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/skills/index.json":
+			_, _ = w.Write([]byte(`{"skills": [{"id": "csv", "versions": ["1.0.0", "1.2.0", "2.0.0"]}]}`))
+		case "/skills/csv/1.2.0.md":
+			_, _ = w.Write([]byte("---\nname: csv\ndescription: CSV\nversion: 1.2.0\n---\n"))
+		default:
+			_, _ = w.Write([]byte("---\nname: pdf\ndescription: PDF\n---\n"))
+		}
+	})
+	defer closer()
+	resolver.Lock = &Lock{Version: lockVersion}
+
+	skills := []schema.Skill{
+		{ID: "policy", Bare: true, Name: "policy", Description: "Policy"},
+		{ID: "csv", Version: "^1.0"},
+		{ID: "pdf"},
+	}
+	bundled, err := resolver.BundleSkills(context.Background(), skills)
+	if err != nil {
+		t.Fatalf("BundleSkills: %v", err)
+	}
+	if len(bundled) != 2 || bundled[0].ID != "csv" || bundled[0].Ref != "1.2.0" || bundled[1].ID != "pdf" || bundled[1].Ref != "" {
+		t.Fatalf("unexpected bundled skills: %+v", bundled)
+	}
+
+	b := &Bundle{ManifestName: "agent.yaml", Manifest: []byte("kind: Agent\n"), Lock: resolver.Lock, Skills: bundled}
+	var first, second bytes.Buffer
+	if err := b.Write(&first); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := b.Write(&second); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if !bytes.Equal(first.Bytes(), second.Bytes()) {
+		t.Error("writing the same bundle twice must produce the same archive")
+	}
+
+	read, err := ReadBundle(bytes.NewReader(first.Bytes()))
+	if err != nil {
+		t.Fatalf("ReadBundle: %v", err)
+	}
+	if read.ManifestName != "agent.yaml" || string(read.Manifest) != "kind: Agent\n" || read.Lock == nil || len(read.Lock.Skills) != 2 {
+		t.Fatalf("unexpected bundle: %+v", read)
+	}
+
+	// Seed the cache of a resolver that cannot reach any registry.
+	offline, closeOffline := newTestResolver(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("offline resolver fetched %s", r.URL.Path)
+	})
+	defer closeOffline()
+	offline.Offline = true
+	offline.Lock = read.Lock
+	if err := read.Seed(offline.Cache); err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+	resolved, err := offline.ResolveAll(context.Background(), skills)
+	if err != nil {
+		t.Fatalf("offline ResolveAll: %v", err)
+	}
+	if resolved[1].Version != "1.2.0" || resolved[2].Name != "pdf" {
+		t.Errorf("unexpected offline resolution: %+v %+v", resolved[1], resolved[2])
+	}
+}
+
+func TestReadBundle_RejectsCorruptedSkills(t *testing.T) {
+	b := &Bundle{
+		ManifestName: "agent.yaml",
+		Manifest:     []byte("kind: Agent\n"),
+		Skills: []BundledSkill{{
+			ID:     "pdf",
+			Ref:    "main",
+			SHA256: map[string]string{SkillFile: digest([]byte("original"))},
+			Files:  map[string][]byte{SkillFile: []byte("tampered")},
+		}},
+	}
+	var buf bytes.Buffer
+	if err := b.Write(&buf); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if _, err := ReadBundle(&buf); err == nil || !strings.Contains(err.Error(), "corrupted") {
+		t.Errorf("expected a digest mismatch to be rejected, got %v", err)
+	}
+
+	if _, err := ReadBundle(strings.NewReader("not a bundle")); err == nil {
+		t.Error("expected a non-gzip file to be rejected")
+	}
+}
+
+func TestReadBundle_RejectsUnsafeSkillIDsAndRefs(t *testing.T) {
+	for _, tt := range []struct{ id, ref string }{
+		{"a/b", "main"},
+		{"..", "main"},
+		{"pdf", "x/y"},
+		{"pdf", ".."},
+	} {
+		files := map[string][]byte{SkillFile: []byte("body")}
+		b := &Bundle{
+			ManifestName: "agent.yaml",
+			Manifest:     []byte("kind: Agent\n"),
+			Skills:       []BundledSkill{{ID: tt.id, Ref: tt.ref, SHA256: digestFiles(files), Files: files}},
+		}
+		var buf bytes.Buffer
+		if err := b.Write(&buf); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if _, err := ReadBundle(&buf); err == nil || !strings.Contains(err.Error(), "invalid") {
+			t.Errorf("ReadBundle(id %q, ref %q): expected an invalid id or ref error, got %v", tt.id, tt.ref, err)
+		}
+	}
+
+	cache := &Cache{dir: t.TempDir()}
+	if err := cache.Put("../evil", "main", map[string][]byte{SkillFile: []byte("body")}); err == nil {
+		t.Error("expected Cache.Put to reject an id outside the cache")
+	}
+}
+
+func TestReadBundle_RejectsParentEntry(t *testing.T) {
+	var buf bytes.Buffer
+	a := newArchiveWriter(&buf)
+	if err := a.add("..", []byte("kind: Agent\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadBundle(&buf); err == nil || !strings.Contains(err.Error(), "invalid path") {
+		t.Errorf("expected an entry named .. to be rejected, got %v", err)
+	}
+}
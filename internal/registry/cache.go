@@ -1,10 +1,17 @@
 package registry
 
 import (
+	"cmp"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 )
 
 // Cache is a disk-backed cache for fetched skill directories. Each
@@ -40,8 +47,39 @@ func (c *Cache) SkillDir(id, ref string) string {
 	return filepath.Join(c.dir, fmt.Sprintf("%s@%s", id, r))
 }
 
-// Get returns the cached file map for (id, ref) if the directory exists.
-// The boolean is false when the entry is absent.
+// checkEntry rejects an (id, ref) whose entry directory would not be a
+// single path element inside the cache. Both usually come from the ADL
+// file or a bundle, neither of which is trusted with paths.
+func checkEntry(id, ref string) error {
+	name := id + "@" + cmp.Or(ref, "latest")
+	if !filepath.IsLocal(name) || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid skill cache entry %q", name)
+	}
+	return nil
+}
+
+// Refs returns the refs id is cached at, "latest" included.
+func (c *Cache) Refs(id string) ([]string, error) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read skill cache %s: %w", c.dir, err)
+	}
+	var refs []string
+	for _, e := range entries {
+		if ref, ok := strings.CutPrefix(e.Name(), id+"@"); ok && e.IsDir() {
+			refs = append(refs, ref)
+		}
+	}
+	return refs, nil
+}
+
+// Get returns the cached file map for (id, ref) if the directory exists
+// and its files match the digests recorded when it was written. The
+// boolean is false when the entry is absent, incomplete or corrupted, so
+// the caller fetches it again.
 func (c *Cache) Get(id, ref string) (map[string][]byte, bool, error) {
 	dir := c.SkillDir(id, ref)
 	info, err := os.Stat(dir)
@@ -55,6 +93,12 @@ func (c *Cache) Get(id, ref string) (map[string][]byte, bool, error) {
 		return nil, false, fmt.Errorf("cache entry %s is not a directory", dir)
 	}
 
+	unlock, err := c.lock(id, ref, false)
+	if err != nil {
+		return nil, false, err
+	}
+	defer unlock()
+
 	files := make(map[string][]byte)
 	if err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
@@ -67,6 +111,9 @@ func (c *Cache) Get(id, ref string) (map[string][]byte, bool, error) {
 		if relErr != nil {
 			return relErr
 		}
+		if rel == cacheMetaFile || strings.HasPrefix(d.Name(), cacheTmpPrefix) {
+			return nil
+		}
 		data, readErr := os.ReadFile(path)
 		if readErr != nil {
 			return readErr
@@ -74,30 +121,61 @@ func (c *Cache) Get(id, ref string) (map[string][]byte, bool, error) {
 		files[filepath.ToSlash(rel)] = data
 		return nil
 	}); err != nil {
+		// Replaced or removed while we waited for the lock.
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, false, nil
+		}
 		return nil, false, fmt.Errorf("failed to read skill cache entry %s: %w", dir, err)
 	}
 	if len(files) == 0 {
 		return nil, false, nil
 	}
+	meta, ok, err := readMeta(dir)
+	if err != nil {
+		return nil, false, err
+	}
+	if !ok || len(meta.Files) == 0 || !maps.Equal(digestFiles(files), meta.Files) {
+		return nil, false, nil
+	}
 	return files, true, nil
 }
 
 // Put writes the file map into the cache directory for (id, ref). The
-// directory is wiped first so stale entries from a previous version
-// can't bleed through.
+// directory is replaced as a whole so stale entries from a previous
+// version can't bleed through.
 func (c *Cache) Put(id, ref string, files map[string][]byte) error {
-	dir := c.SkillDir(id, ref)
-	if err := os.RemoveAll(dir); err != nil {
-		return fmt.Errorf("failed to clear skill cache directory %s: %w", dir, err)
-	}
-	if err := os.MkdirAll(dir, 0o755); err != nil {
-		return fmt.Errorf("failed to create skill cache directory: %w", err)
+	return c.PutRevision(id, ref, "", files)
+}
+
+// PutRevision is Put that also records the upstream revision the files
+// were fetched at (a commit SHA or an ETag), so a moving ref can later
+// be revalidated without downloading it again.
+//
+// The files and their digests are written to a temporary directory that
+// is renamed into place under the entry's lock, so neither a crash nor a
+// concurrent adl process can leave a partially written entry behind.
+func (c *Cache) PutRevision(id, ref, revision string, files map[string][]byte) error {
+	if err := checkEntry(id, ref); err != nil {
+		return err
 	}
-	for rel, data := range files {
+	for rel := range files {
 		if strings.HasPrefix(rel, "/") || strings.Contains(rel, "..") {
 			return fmt.Errorf("refusing to cache file with suspicious relative path: %q", rel)
 		}
-		outPath := filepath.Join(dir, filepath.FromSlash(rel))
+	}
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create skill cache directory: %w", err)
+	}
+	tmp, err := os.MkdirTemp(c.dir, cacheTmpPrefix+"*")
+	if err != nil {
+		return fmt.Errorf("failed to create skill cache directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+	if err := os.Chmod(tmp, 0o755); err != nil {
+		return fmt.Errorf("failed to create skill cache directory: %w", err)
+	}
+	for rel, data := range files {
+		outPath := filepath.Join(tmp, filepath.FromSlash(rel))
 		if err := os.MkdirAll(filepath.Dir(outPath), 0o755); err != nil {
 			return fmt.Errorf("failed to create dir for %s: %w", rel, err)
 		}
@@ -105,5 +183,270 @@ func (c *Cache) Put(id, ref string, files map[string][]byte) error {
 			return fmt.Errorf("failed to write skill cache entry %s: %w", outPath, err)
 		}
 	}
+	meta := cacheMeta{FetchedAt: time.Now(), Revision: revision, Files: digestFiles(files)}
+	if err := c.writeMeta(tmp, meta); err != nil {
+		return err
+	}
+
+	unlock, err := c.lock(id, ref, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	dir := c.SkillDir(id, ref)
+	if err := c.discard(dir); err != nil {
+		return fmt.Errorf("failed to clear skill cache directory %s: %w", dir, err)
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		return fmt.Errorf("failed to write skill cache entry %s: %w", dir, err)
+	}
+	return nil
+}
+
+// cacheMetaFile holds the cacheMeta of an entry, inside its directory.
+// Get leaves it out of the skill's files.
+const cacheMetaFile = ".adl-cache.json"
+
+// cacheTmpPrefix starts the names of directories being written or
+// removed, which are never entries.
+const cacheTmpPrefix = ".tmp-"
+
+// cacheLocksDir holds the per-entry lock files.
+const cacheLocksDir = ".locks"
+
+type cacheMeta struct {
+	FetchedAt time.Time `json:"fetchedAt"`
+	Revision  string    `json:"revision,omitempty"`
+	// Files maps each file of the entry to its SHA-256 digest.
+	Files map[string]string `json:"files,omitempty"`
+}
+
+// CacheEntry describes one cached skill directory.
+type CacheEntry struct {
+	ID  string
+	Ref string
+	Dir string
+	// FetchedAt is when the entry was last fetched or revalidated.
+	FetchedAt time.Time
+	// Revision is the upstream revision the entry was fetched at: the
+	// commit SHA of a source ref or the registry's ETag, if known.
+	Revision string
+	// Files and Size count the cached files and their bytes.
+	Files int
+	Size  int64
+}
+
+// Entry returns the metadata of the cache entry for (id, ref). Entries
+// written before metadata was recorded report their directory's
+// modification time as FetchedAt.
+func (c *Cache) Entry(id, ref string) (*CacheEntry, bool, error) {
+	if ref == "" {
+		ref = "latest"
+	}
+	dir := c.SkillDir(id, ref)
+	info, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to stat skill cache entry %s: %w", dir, err)
+	}
+	entry := &CacheEntry{ID: id, Ref: ref, Dir: dir, FetchedAt: info.ModTime()}
+	meta, ok, err := readMeta(dir)
+	if err != nil {
+		return nil, false, err
+	}
+	if ok && !meta.FetchedAt.IsZero() {
+		entry.FetchedAt, entry.Revision = meta.FetchedAt, meta.Revision
+	}
+	return entry, true, nil
+}
+
+// Touch marks the entry for (id, ref) as revalidated now.
+func (c *Cache) Touch(id, ref string) error {
+	unlock, err := c.lock(id, ref, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	dir := c.SkillDir(id, ref)
+	meta, ok, err := readMeta(dir)
+	if err != nil || !ok {
+		return err
+	}
+	meta.FetchedAt = time.Now()
+	return c.writeMeta(dir, meta)
+}
+
+// List returns every cache entry, sorted by ID and ref.
+func (c *Cache) List() ([]CacheEntry, error) {
+	dirs, err := os.ReadDir(c.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read skill cache %s: %w", c.dir, err)
+	}
+	var entries []CacheEntry
+	for _, d := range dirs {
+		id, ref, ok := strings.Cut(d.Name(), "@")
+		if !ok || !d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+			continue
+		}
+		entry, ok, err := c.Entry(id, ref)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		if err := filepath.WalkDir(entry.Dir, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() || d.Name() == cacheMetaFile || strings.HasPrefix(d.Name(), cacheTmpPrefix) {
+				return err
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			entry.Files++
+			entry.Size += info.Size()
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("failed to read skill cache entry %s: %w", entry.Dir, err)
+		}
+		entries = append(entries, *entry)
+	}
+	slices.SortFunc(entries, func(a, b CacheEntry) int {
+		return cmp.Or(strings.Compare(a.ID, b.ID), strings.Compare(a.Ref, b.Ref))
+	})
+	return entries, nil
+}
+
+// Prune removes the entries last fetched or revalidated before cutoff
+// and returns them. Temporary directories older than cutoff, left by
+// runs that were interrupted, are removed too.
+func (c *Cache) Prune(cutoff time.Time) ([]CacheEntry, error) {
+	entries, err := c.List()
+	if err != nil {
+		return nil, err
+	}
+	dirs, err := os.ReadDir(c.dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read skill cache %s: %w", c.dir, err)
+	}
+	for _, d := range dirs {
+		if !strings.HasPrefix(d.Name(), cacheTmpPrefix) {
+			continue
+		}
+		if info, err := d.Info(); err == nil && info.ModTime().Before(cutoff) {
+			if err := os.RemoveAll(filepath.Join(c.dir, d.Name())); err != nil {
+				return nil, fmt.Errorf("failed to remove skill cache directory: %w", err)
+			}
+		}
+	}
+	var pruned []CacheEntry
+	for _, e := range entries {
+		if !e.FetchedAt.Before(cutoff) {
+			continue
+		}
+		if err := c.Remove(e.ID, e.Ref); err != nil {
+			return pruned, err
+		}
+		pruned = append(pruned, e)
+	}
+	return pruned, nil
+}
+
+// Remove deletes the entry for (id, ref).
+func (c *Cache) Remove(id, ref string) error {
+	unlock, err := c.lock(id, ref, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err := c.discard(c.SkillDir(id, ref)); err != nil {
+		return fmt.Errorf("failed to remove skill cache entry: %w", err)
+	}
+	return nil
+}
+
+// discard renames dir to a temporary name before deleting it, so an
+// interrupted delete leaves a directory for Prune rather than a partial
+// entry. The caller holds the entry's lock.
+func (c *Cache) discard(dir string) error {
+	trash := filepath.Join(c.dir, fmt.Sprintf("%sold-%d-%d", cacheTmpPrefix, os.Getpid(), time.Now().UnixNano()))
+	if err := os.Rename(dir, trash); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return os.RemoveAll(trash)
+}
+
+// lock takes the lock of the entry for (id, ref): shared to read it,
+// exclusive to replace it. Call the returned function to release it.
+func (c *Cache) lock(id, ref string, exclusive bool) (func(), error) {
+	if err := checkEntry(id, ref); err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(c.dir, cacheLocksDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create skill cache lock directory: %w", err)
+	}
+	path := filepath.Join(dir, filepath.Base(c.SkillDir(id, ref))+".lock")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open skill cache lock %s: %w", path, err)
+	}
+	if err := lockFile(f, exclusive); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to lock skill cache entry %s: %w", path, err)
+	}
+	return func() { _ = f.Close() }, nil
+}
+
+// readMeta reads the metadata of the entry in dir. The boolean is false
+// when it is missing or unreadable, as for entries written by older
+// versions or interrupted before completion.
+func readMeta(dir string) (cacheMeta, bool, error) {
+	var meta cacheMeta
+	data, err := os.ReadFile(filepath.Join(dir, cacheMetaFile))
+	if os.IsNotExist(err) {
+		return meta, false, nil
+	}
+	if err != nil {
+		return meta, false, fmt.Errorf("failed to read skill cache entry %s: %w", dir, err)
+	}
+	if json.Unmarshal(data, &meta) != nil {
+		return meta, false, nil
+	}
+	return meta, true, nil
+}
+
+// writeMeta replaces the metadata of the entry in dir atomically.
+func (c *Cache) writeMeta(dir string, meta cacheMeta) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("failed to encode skill cache metadata: %w", err)
+	}
+	f, err := os.CreateTemp(dir, cacheTmpPrefix+"*")
+	if err != nil {
+		return fmt.Errorf("failed to write skill cache metadata: %w", err)
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(f.Name(), 0o644)
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), filepath.Join(dir, cacheMetaFile))
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return fmt.Errorf("failed to write skill cache metadata: %w", err)
+	}
 	return nil
 }
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package registry
+
+import "os"
+
+// lockFile does not lock on platforms without flock; entries are still
+// written atomically and verified against their digests when read.
+func lockFile(*os.File, bool) error { return nil }
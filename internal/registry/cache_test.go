@@ -1,8 +1,15 @@
 package registry
 
 import (
+	"bytes"
+	"fmt"
+	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestCache_GetPut(t *testing.T) {
@@ -82,3 +89,178 @@ func TestCache_PutClearsStaleFiles(t *testing.T) {
 		t.Errorf("expected fresh scripts/v2.sh, got %q", got["scripts/v2.sh"])
 	}
 }
+
+func TestCache_ListAndPrune(t *testing.T) {
+	cache, err := NewCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewCache: %v", err)
+	}
+	if err := cache.PutRevision("pdf", "main", "abc123", map[string][]byte{"SKILL.md": []byte("pdf"), "a/b.txt": []byte("12345")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Put("csv", "1.0.0", map[string][]byte{"SKILL.md": []byte("csv")}); err != nil {
+		t.Fatal(err)
+	}
+
+	files, _, _ := cache.Get("pdf", "main")
+	if _, ok := files[cacheMetaFile]; ok || len(files) != 2 {
+		t.Errorf("the metadata file must not be part of the skill, got %v", keysOf(files))
+	}
+
+	entries, err := cache.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(entries) != 2 || entries[0].ID != "csv" || entries[1].Ref != "main" || entries[1].Revision != "abc123" ||
+		entries[1].Files != 2 || entries[1].Size != 8 {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+
+	// Backdate pdf; Touch marks csv as revalidated now.
+	old := time.Now().Add(-48 * time.Hour)
+	if err := cache.writeMeta(cache.SkillDir("pdf", "main"), cacheMeta{FetchedAt: old, Revision: "abc123"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Touch("csv", "1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	pruned, err := cache.Prune(time.Now().Add(-24 * time.Hour))
+	if err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+	if len(pruned) != 1 || pruned[0].ID != "pdf" {
+		t.Errorf("expected only pdf to be pruned, got %+v", pruned)
+	}
+	if _, ok, _ := cache.Get("pdf", "main"); ok {
+		t.Error("pruned entry still cached")
+	}
+	if _, ok, _ := cache.Get("csv", "1.0.0"); !ok {
+		t.Error("recent entry was pruned")
+	}
+}
+
+func TestCache_IncompleteOrCorruptedEntriesMiss(t *testing.T) {
+	cache, err := NewCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewCache: %v", err)
+	}
+	files := map[string][]byte{"SKILL.md": []byte("skill"), "scripts/run.sh": []byte("run")}
+	if err := cache.Put("a", "1.0.0", files); err != nil {
+		t.Fatal(err)
+	}
+	dir := cache.SkillDir("a", "1.0.0")
+
+	if err := os.WriteFile(filepath.Join(dir, "scripts", "run.sh"), []byte("tampered"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := cache.Get("a", "1.0.0"); err != nil || ok {
+		t.Errorf("expected a modified file to be a miss, got ok=%v err=%v", ok, err)
+	}
+
+	if err := cache.Put("a", "1.0.0", files); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dir, "scripts", "run.sh")); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := cache.Get("a", "1.0.0"); err != nil || ok {
+		t.Errorf("expected a missing file to be a miss, got ok=%v err=%v", ok, err)
+	}
+
+	// A directory without metadata, as left by an interrupted write.
+	legacy := cache.SkillDir("b", "1.0.0")
+	if err := os.MkdirAll(legacy, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(legacy, SkillFile), []byte("partial"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := cache.Get("b", "1.0.0"); err != nil || ok {
+		t.Errorf("expected an entry without manifest to be a miss, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestCache_ConcurrentPutAndGet(t *testing.T) {
+	cache, err := NewCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewCache: %v", err)
+	}
+	version := func(n int) map[string][]byte {
+		files := map[string][]byte{}
+		for i := range 5 {
+			files[fmt.Sprintf("file-%d.txt", i)] = []byte(strings.Repeat(strconv.Itoa(n), 1000))
+		}
+		return files
+	}
+
+	var wg sync.WaitGroup
+	for n := range 4 {
+		wg.Go(func() {
+			for range 20 {
+				if err := cache.Put("a", "main", version(n)); err != nil {
+					t.Errorf("Put: %v", err)
+					return
+				}
+			}
+		})
+		wg.Go(func() {
+			for range 50 {
+				files, ok, err := cache.Get("a", "main")
+				if err != nil {
+					t.Errorf("Get: %v", err)
+					return
+				}
+				if !ok {
+					continue
+				}
+				first := files["file-0.txt"]
+				if len(files) != 5 {
+					t.Errorf("incomplete entry: %v", keysOf(files))
+				}
+				for name, data := range files {
+					if !bytes.Equal(data, first) {
+						t.Errorf("%s is from another Put than file-0.txt", name)
+					}
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	entries, err := cache.List()
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected a single entry, got %+v (err=%v)", entries, err)
+	}
+}
+
+func TestCache_PruneRemovesTemporaryDirectories(t *testing.T) {
+	cache, err := NewCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewCache: %v", err)
+	}
+	stale := filepath.Join(cache.Dir(), cacheTmpPrefix+"123")
+	if err := os.MkdirAll(filepath.Join(stale, "scripts"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(stale, old, old); err != nil {
+		t.Fatal(err)
+	}
+	recent := filepath.Join(cache.Dir(), cacheTmpPrefix+"456")
+	if err := os.MkdirAll(recent, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if entries, err := cache.List(); err != nil || len(entries) != 0 {
+		t.Errorf("temporary directories must not be listed, got %+v (err=%v)", entries, err)
+	}
+	if _, err := cache.Prune(time.Now().Add(-24 * time.Hour)); err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("expected stale temporary directory to be removed, got %v", err)
+	}
+	if _, err := os.Stat(recent); err != nil {
+		t.Errorf("expected recent temporary directory to be kept, got %v", err)
+	}
+}
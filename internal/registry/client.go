@@ -2,11 +2,13 @@ package registry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -14,7 +16,8 @@ import (
 // per-skill `source:` overrides it.
 const DefaultBaseURL = "https://registry.inference-gateway.com/skills/"
 
-// EnvBaseURL is the env var that overrides DefaultBaseURL.
+// EnvBaseURL is the env var that overrides DefaultBaseURL. It may hold a
+// comma-separated list of registries, tried in order.
 const EnvBaseURL = "ADL_SKILLS_REGISTRY"
 
 // Client fetches SKILL.md from the default registry by id.
@@ -25,6 +28,22 @@ const EnvBaseURL = "ADL_SKILLS_REGISTRY"
 type Client struct {
 	BaseURL    string
 	HTTPClient *http.Client
+	// Token is sent as a bearer token. Without it, Username and Password
+	// are sent as basic auth when set.
+	Token    string
+	Username string
+	Password string
+	// Headers are added to every request, e.g. an API key header a
+	// mirror's gateway expects.
+	Headers map[string]string
+	// Fallbacks are tried in order when this registry does not serve a
+	// skill or cannot be reached. Any other failure, a rejected
+	// credential above all, ends the lookup there, so a skill is never
+	// silently taken from a registry further down the list.
+	Fallbacks []*Client
+
+	indexMu sync.Mutex
+	indexed []IndexEntry
 }
 
 // NewClient returns a Client with sensible defaults.
@@ -44,48 +63,160 @@ func NewClient(baseURL string) *Client {
 }
 
 // FetchByID retrieves a skill's SKILL.md by id (and optional version)
-// from the configured BaseURL. Version "" resolves to the registry's
-// default version of that skill.
+// from the configured BaseURL, or the first fallback that serves it.
+// Version "" resolves to the registry's default version of that skill.
 func (c *Client) FetchByID(ctx context.Context, id, version string) ([]byte, error) {
+	fetched, err := c.Fetch(ctx, id, version, "")
+	if err != nil {
+		return nil, err
+	}
+	return fetched.Body, nil
+}
+
+// Fetched is a SKILL.md served by a registry.
+type Fetched struct {
+	Body []byte
+	// ETag is the registry's validator for Body, if it sent one.
+	ETag string
+	// Registry is the registry that served the skill.
+	Registry *Client
+	// NotModified is set, without a Body, when the registry confirmed
+	// that the ETag passed to Fetch is still current.
+	NotModified bool
+}
+
+// Fetch is FetchByID that also reports which registry served the skill
+// and its ETag. A non-empty etag makes the request conditional.
+func (c *Client) Fetch(ctx context.Context, id, version, etag string) (*Fetched, error) {
 	if id == "" {
 		return nil, fmt.Errorf("skill id is required")
 	}
 
-	path := id
-	if version != "" {
-		path = id + "/" + version
+	var errs []error
+	for _, reg := range c.registries() {
+		fetched, err := reg.fetch(ctx, id, version, etag)
+		if err == nil {
+			return fetched, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		var unavailable *unavailableError
+		if !errors.As(err, &unavailable) {
+			if len(c.Fallbacks) == 0 {
+				return nil, err
+			}
+			return nil, fmt.Errorf("registry %s: %w", reg.BaseURL, err)
+		}
+		errs = append(errs, err)
 	}
-	path += ".md"
-
-	target, err := joinURL(c.BaseURL, path)
-	if err != nil {
-		return nil, err
+	if len(errs) == 1 {
+		return nil, errs[0]
 	}
+	return nil, fmt.Errorf("skill %q is not available from any registry:\n%w", id, errors.Join(errs...))
+}
+
+// unavailableError marks the fetch failures Fetch falls back on: the
+// registry does not serve the skill or could not be reached.
+type unavailableError struct{ err error }
+
+func (e *unavailableError) Error() string { return e.err.Error() }
+func (e *unavailableError) Unwrap() error { return e.err }
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+func (c *Client) fetch(ctx context.Context, id, version, etag string) (*Fetched, error) {
+	target, err := c.SkillURL(id, version)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build request for %s: %w", target, err)
+		return nil, err
 	}
-	req.Header.Set("Accept", "text/markdown, text/plain; q=0.9, */*; q=0.1")
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.get(ctx, target, "text/markdown, text/plain; q=0.9, */*; q=0.1", etag)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch %s: %w", target, err)
+		return nil, &unavailableError{err}
 	}
 	defer func() { _ = resp.Body.Close() }()
 
+	if resp.StatusCode == http.StatusNotModified && etag != "" {
+		return &Fetched{ETag: etag, Registry: c, NotModified: true}, nil
+	}
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("skill not found at %s", target)
+		return nil, &unavailableError{fmt.Errorf("skill not found at %s", target)}
 	}
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("registry returned %s for %s", resp.Status, target)
+	if err := checkRegistryStatus(resp, target); err != nil {
+		return nil, err
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read %s: %w", target, err)
 	}
-	return body, nil
+	return &Fetched{Body: body, ETag: resp.Header.Get("ETag"), Registry: c}, nil
+}
+
+// registries returns c followed by its fallbacks, in the order they are
+// tried.
+func (c *Client) registries() []*Client {
+	return append([]*Client{c}, c.Fallbacks...)
+}
+
+// get sends a GET to the registry with its credentials and headers,
+// conditional on etag when it is set.
+func (c *Client) get(ctx context.Context, target, accept, etag string) (*http.Response, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", target, err)
+	}
+	return resp, nil
+}
+
+// newRequest builds a request to the registry carrying its credentials
+// and headers.
+func (c *Client) newRequest(ctx context.Context, method, target string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request for %s: %w", target, err)
+	}
+	for name, value := range c.Headers {
+		req.Header.Set(name, value)
+	}
+	switch {
+	case c.Token != "":
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	case c.Username != "" || c.Password != "":
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	return req, nil
+}
+
+// checkRegistryStatus turns a non-2xx registry response into an error,
+// pointing at the credential settings on 401 and 403.
+func checkRegistryStatus(resp *http.Response, target string) error {
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("registry returned %s for %s; check its token, username/password or headers in %s or %s",
+			resp.Status, target, RegistriesFile, EnvRegistryToken)
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return fmt.Errorf("registry returned %s for %s", resp.Status, target)
+	}
+	return nil
+}
+
+// SkillURL returns the URL FetchByID reads the SKILL.md of (id, version)
+// from.
+func (c *Client) SkillURL(id, version string) (string, error) {
+	path := id
+	if version != "" {
+		path = id + "/" + version
+	}
+	return joinURL(c.BaseURL, path+".md")
 }
 
 func joinURL(base, path string) (string, error) {
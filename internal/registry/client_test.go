@@ -69,3 +69,60 @@ func TestNewClient_DefaultBaseURL(t *testing.T) {
 		t.Errorf("trailing slash should be added, got %q", c.BaseURL)
 	}
 }
+
+func TestClient_AuthAndFallbacks(t *testing.T) {
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer mirror-token" || r.Header.Get("X-Org") != "platform" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/skills/internal.md" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("---\nname: internal\ndescription: mirror\n---\n"))
+	}))
+	defer mirror.Close()
+	public := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "bot" || pass != "hunter2" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/public.md" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("---\nname: public\ndescription: public\n---\n"))
+	}))
+	defer public.Close()
+
+	client := NewRegistryClient([]Registry{
+		{URL: mirror.URL + "/skills/", Token: "mirror-token", Headers: map[string]string{"X-Org": "platform"}},
+		{URL: public.URL, Username: "bot", Password: "hunter2"},
+	})
+
+	fetched, err := client.Fetch(context.Background(), "internal", "", "")
+	if err != nil || !strings.Contains(string(fetched.Body), "mirror") || fetched.Registry != client {
+		t.Fatalf("expected the mirror to serve internal, got %+v: %v", fetched, err)
+	}
+	fetched, err = client.Fetch(context.Background(), "public", "", "")
+	if err != nil || !strings.Contains(string(fetched.Body), "description: public") || fetched.Registry != client.Fallbacks[0] {
+		t.Fatalf("expected the fallback to serve public, got %+v: %v", fetched, err)
+	}
+
+	_, err = client.Fetch(context.Background(), "missing", "", "")
+	if err == nil || !strings.Contains(err.Error(), "not available from any registry") || strings.Count(err.Error(), "not found at") != 2 {
+		t.Errorf("expected one not-found error per registry, got %v", err)
+	}
+
+	client.Token = "wrong"
+	_, err = client.Fetch(context.Background(), "public", "", "")
+	if err == nil || !strings.Contains(err.Error(), "registry "+mirror.URL+"/skills/: registry returned 401") {
+		t.Errorf("expected the mirror's auth failure instead of a fallback, got %v", err)
+	}
+
+	client.Fallbacks = nil
+	if _, err := client.FetchByID(context.Background(), "internal", ""); err == nil || !strings.Contains(err.Error(), EnvRegistryToken) {
+		t.Errorf("expected a credentials hint, got %v", err)
+	}
+}
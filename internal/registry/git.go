@@ -0,0 +1,189 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// gitDefaultRef is the ref of git sources without an @<ref> suffix: the
+// remote's default branch.
+const gitDefaultRef = "HEAD"
+
+var commitSHARe = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// gitSchemes are the transports a git source may use. Anything else
+// (ext::, or a "scheme" that is really a command-line option) would let an
+// ADL file run arbitrary commands through git.
+var gitSchemes = []string{"https", "http", "ssh", "git", "file"}
+
+// GitFetcher fetches skills from any git remote (GitLab, Gitea,
+// self-hosted, SSH) by shelling out to the git binary, so the user's
+// credential helpers and SSH keys apply. Sources take the form
+//
+//	git+<scheme>://<remote>[//<path-to-skill>][@<ref>]
+//
+// e.g. git+https://gitlab.com/acme/skills.git//skills/triage@v1.2.0 or
+// git+ssh://git@git.internal/platform/skills.git//triage. Without a
+// path the repository root is the skill; without a ref the remote's
+// default branch is used.
+type GitFetcher struct {
+	// Git is the git executable; "git" when empty.
+	Git string
+}
+
+func (f *GitFetcher) Parse(source string) (*SourceLocation, bool, error) {
+	rest, ok := strings.CutPrefix(source, "git+")
+	if !ok {
+		return nil, false, nil
+	}
+	scheme, body, _ := strings.Cut(rest, "://")
+	if scheme == "" || body == "" {
+		return nil, false, fmt.Errorf("git source must be of the form git+<scheme>://<remote>[//<path>][@<ref>]: got %s", source)
+	}
+	if !slices.Contains(gitSchemes, scheme) {
+		return nil, false, fmt.Errorf("git source scheme must be one of %s: got %s", strings.Join(gitSchemes, ", "), source)
+	}
+
+	ref := gitDefaultRef
+	if at := strings.LastIndex(body, "@"); at > strings.LastIndex(body, "/") {
+		ref = body[at+1:]
+		body = body[:at]
+		if ref == "" {
+			return nil, false, fmt.Errorf("git source has an empty @<ref>: %s", source)
+		}
+		if strings.HasPrefix(ref, "-") {
+			return nil, false, fmt.Errorf("git source ref must not start with '-': %s", source)
+		}
+	}
+	repo, path, _ := strings.Cut(body, "//")
+	if repo == "" {
+		return nil, false, fmt.Errorf("git source has no remote: %s", source)
+	}
+	if strings.HasPrefix(repo, "-") {
+		return nil, false, fmt.Errorf("git source remote must not start with '-': %s", source)
+	}
+	path = strings.Trim(path, "/")
+	if path != "" && !filepath.IsLocal(filepath.FromSlash(path)) {
+		return nil, false, fmt.Errorf("git source path must stay inside the repository: %s", source)
+	}
+	return &SourceLocation{
+		Repo: scheme + "://" + repo,
+		Ref:  ref,
+		Path: path,
+	}, true, nil
+}
+
+// Fetch shallow-fetches loc.Ref into a temporary repository and reads
+// the skill directory from its checkout.
+func (f *GitFetcher) Fetch(ctx context.Context, loc *SourceLocation) (map[string][]byte, error) {
+	dir, err := os.MkdirTemp("", "adl-skill-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	if _, err := f.run(ctx, "", "init", "--quiet", "--end-of-options", dir); err != nil {
+		return nil, err
+	}
+	if _, err := f.run(ctx, dir, "fetch", "--quiet", "--depth", "1", "--end-of-options", loc.Repo, loc.Ref); err != nil {
+		return nil, err
+	}
+	if _, err := f.run(ctx, dir, "checkout", "--quiet", "FETCH_HEAD"); err != nil {
+		return nil, err
+	}
+
+	// loc may not have come through Parse, so the path is checked again
+	// before it is joined onto the checkout.
+	if loc.Path != "" && !filepath.IsLocal(filepath.FromSlash(loc.Path)) {
+		return nil, fmt.Errorf("git source path must stay inside the repository: %s", f.URL(loc))
+	}
+	files, err := (&LocalFetcher{}).Fetch(ctx, &SourceLocation{Path: filepath.Join(dir, filepath.FromSlash(loc.Path)), Local: true})
+	if err != nil {
+		return nil, fmt.Errorf("no skill at %s: %w", f.URL(loc), err)
+	}
+	return files, nil
+}
+
+// Commit resolves loc.Ref with git ls-remote. Annotated tags resolve to
+// the commit they point at; full commit SHAs are returned as is.
+func (f *GitFetcher) Commit(ctx context.Context, loc *SourceLocation) (string, error) {
+	if commitSHARe.MatchString(loc.Ref) {
+		return loc.Ref, nil
+	}
+	out, err := f.run(ctx, "", "ls-remote", "--end-of-options", loc.Repo, loc.Ref, loc.Ref+"^{}")
+	if err != nil {
+		return "", err
+	}
+	refs := parseLsRemote(out)
+	for _, name := range []string{"refs/tags/" + loc.Ref + "^{}", "refs/tags/" + loc.Ref, "refs/heads/" + loc.Ref, loc.Ref} {
+		if sha, ok := refs[name]; ok {
+			return sha, nil
+		}
+	}
+	return "", fmt.Errorf("ref %s not found in %s", loc.Ref, loc.Repo)
+}
+
+func (f *GitFetcher) Tags(ctx context.Context, loc *SourceLocation) ([]string, error) {
+	out, err := f.run(ctx, "", "ls-remote", "--tags", "--refs", "--end-of-options", loc.Repo)
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	for name := range parseLsRemote(out) {
+		tags = append(tags, strings.TrimPrefix(name, "refs/tags/"))
+	}
+	slices.Sort(tags)
+	return tags, nil
+}
+
+func (f *GitFetcher) URL(loc *SourceLocation) string {
+	u := "git+" + loc.Repo
+	if loc.Path != "" {
+		u += "//" + loc.Path
+	}
+	return u + "@" + loc.Ref
+}
+
+// run executes a git subcommand, in dir when set, with prompts disabled
+// so a missing credential fails instead of hanging generation. Callers
+// pass --end-of-options before the repository and refs so values taken
+// from the ADL file are never parsed as options.
+func (f *GitFetcher) run(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	git := f.Git
+	if git == "" {
+		git = "git"
+	}
+	cmd := exec.CommandContext(ctx, git, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("git %s failed: %s", args[0], msg)
+	}
+	return out, nil
+}
+
+// parseLsRemote maps ref names to SHAs in git ls-remote output.
+func parseLsRemote(out []byte) map[string]string {
+	refs := make(map[string]string)
+	for line := range strings.Lines(string(out)) {
+		sha, name, ok := strings.Cut(strings.TrimSpace(line), "\t")
+		if ok {
+			refs[name] = sha
+		}
+	}
+	return refs
+}
@@ -0,0 +1,150 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// IndexFile is the registry endpoint, relative to its base URL, that
+// lists every skill the registry serves.
+const IndexFile = "index.json"
+
+// IndexEntry describes one skill in a registry index.
+type IndexEntry struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Versions    []string `json:"versions,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	// Registry is the base URL of the registry that listed the skill.
+	Registry string `json:"-"`
+}
+
+type registryIndex struct {
+	Skills []IndexEntry `json:"skills"`
+}
+
+// Search lists the skills of every registry whose ID, name, description
+// or tags contain query (case-insensitively; "" matches all), sorted by
+// ID with versions newest first. A skill listed by several registries is
+// reported once, from the first. Registries whose index cannot be read
+// are reported in the error alongside whatever the others listed.
+func (c *Client) Search(ctx context.Context, query string) ([]IndexEntry, error) {
+	query = strings.ToLower(query)
+	seen := make(map[string]bool)
+	var entries []IndexEntry
+	var errs []error
+	for _, reg := range c.registries() {
+		listed, err := reg.index(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		for _, e := range listed {
+			if e.ID == "" || seen[e.ID] || !e.matches(query) {
+				continue
+			}
+			seen[e.ID] = true
+			e.Registry = reg.BaseURL
+			e.Versions = slices.Clone(e.Versions)
+			slices.SortStableFunc(e.Versions, func(a, b string) int {
+				va, okA := parseVersion(a)
+				vb, okB := parseVersion(b)
+				if okA && okB {
+					return compareVersions(vb, va)
+				}
+				return strings.Compare(b, a)
+			})
+			entries = append(entries, e)
+		}
+	}
+	slices.SortFunc(entries, func(a, b IndexEntry) int { return strings.Compare(a.ID, b.ID) })
+	return entries, errors.Join(errs...)
+}
+
+// Versions returns the versions of skill id listed by the first registry
+// whose index lists it.
+func (c *Client) Versions(ctx context.Context, id string) ([]string, error) {
+	var errs []error
+	for _, reg := range c.registries() {
+		listed, err := reg.index(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		for _, e := range listed {
+			if e.ID == id {
+				return e.Versions, nil
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("no registry index lists skill %q: %w", id, errors.Join(errs...))
+	}
+	return nil, fmt.Errorf("no registry index lists skill %q", id)
+}
+
+// index returns the registry's index, fetching it once per Client.
+func (c *Client) index(ctx context.Context) ([]IndexEntry, error) {
+	c.indexMu.Lock()
+	defer c.indexMu.Unlock()
+	if c.indexed != nil {
+		return c.indexed, nil
+	}
+	listed, err := c.fetchIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.indexed = listed
+	return listed, nil
+}
+
+func (c *Client) fetchIndex(ctx context.Context) ([]IndexEntry, error) {
+	target, err := joinURL(c.BaseURL, IndexFile)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.get(ctx, target, "application/json", "")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("registry %s does not publish an index (%s)", c.BaseURL, target)
+	}
+	if err := checkRegistryStatus(resp, target); err != nil {
+		return nil, err
+	}
+	var idx registryIndex
+	if err := json.NewDecoder(resp.Body).Decode(&idx); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", target, err)
+	}
+	if idx.Skills == nil {
+		idx.Skills = []IndexEntry{}
+	}
+	return idx.Skills, nil
+}
+
+func (e IndexEntry) matches(query string) bool {
+	if query == "" {
+		return true
+	}
+	for _, field := range append([]string{e.ID, e.Name, e.Description}, e.Tags...) {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package registry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_Search(t *testing.T) {
+	serve := func(index string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/"+IndexFile || index == "" {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write([]byte(index))
+		}))
+	}
+	mirror := serve(`{"skills": [
+		{"id": "pdf", "description": "Mirror copy of pdf", "versions": ["1.2.0", "1.10.0", "1.9.1"]},
+		{"id": "triage", "description": "Triage incidents", "tags": ["ops"]}
+	]}`)
+	defer mirror.Close()
+	public := serve(`{"skills": [
+		{"id": "pdf", "description": "Public pdf"},
+		{"id": "data-analysis", "description": "Analyse CSV files", "versions": ["1.0.0"]}
+	]}`)
+	defer public.Close()
+	broken := serve("")
+	defer broken.Close()
+
+	client := NewRegistryClient([]Registry{{URL: mirror.URL}, {URL: public.URL}})
+	entries, err := client.Search(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	var ids []string
+	for _, e := range entries {
+		ids = append(ids, e.ID)
+	}
+	if strings.Join(ids, ",") != "data-analysis,pdf,triage" {
+		t.Errorf("unexpected skills: %v", ids)
+	}
+	if pdf := entries[1]; pdf.Description != "Mirror copy of pdf" || strings.Join(pdf.Versions, ",") != "1.10.0,1.9.1,1.2.0" || pdf.Registry != mirror.URL+"/" {
+		t.Errorf("expected the mirror's pdf with versions newest first, got %+v", pdf)
+	}
+
+	entries, err = client.Search(context.Background(), "OPS")
+	if err != nil || len(entries) != 1 || entries[0].ID != "triage" {
+		t.Errorf("expected the tag to match, got %+v, %v", entries, err)
+	}
+
+	client = NewRegistryClient([]Registry{{URL: broken.URL}, {URL: public.URL}})
+	entries, err = client.Search(context.Background(), "csv")
+	if len(entries) != 1 || err == nil || !strings.Contains(err.Error(), "does not publish an index") {
+		t.Errorf("expected partial results and the failing registry, got %+v, %v", entries, err)
+	}
+}
@@ -7,17 +7,21 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 )
 
 const (
-	githubAPIBase     = "https://api.github.com"
-	githubRawBase     = "https://raw.githubusercontent.com"
-	installerTimeout  = 30 * time.Second
-	installerUA       = "inference-gateway-adl-cli"
-	treePartsExpected = 5
+	githubAPIBase    = "https://api.github.com"
+	githubRawBase    = "https://raw.githubusercontent.com"
+	installerTimeout = 30 * time.Second
+	// installerConcurrency bounds the parallel blob downloads of a Fetch.
+	installerConcurrency = 8
+	installerUA          = "inference-gateway-adl-cli"
+	treePartsExpected    = 5
 
 	defaultSkillsOrg    = "inference-gateway"
 	defaultSkillsRepo   = "skills"
@@ -97,7 +101,7 @@ func ParseGitHubTreeURL(rawURL string) (*GitHubLocation, error) {
 	if u.Scheme != "https" && u.Scheme != "http" {
 		return nil, fmt.Errorf("URL must use http(s): got %q", u.Scheme)
 	}
-	if u.Host != "github.com" {
+	if !slices.Contains(githubHosts(), u.Host) {
 		return nil, fmt.Errorf("only github.com URLs are supported, got %q", u.Host)
 	}
 
@@ -127,23 +131,74 @@ type treeResponse struct {
 	Truncated bool        `json:"truncated"`
 }
 
-// Installer downloads a skill folder from a public GitHub repo and
-// returns its contents as a relative-path → bytes map. Tests substitute
-// APIBase / RawBase to point at httptest.Server.
+// Installer downloads a skill folder from a GitHub repo and returns its
+// contents as a relative-path → bytes map. Tests substitute APIBase /
+// RawBase to point at httptest.Server.
 type Installer struct {
 	Client  *http.Client
 	APIBase string
+	// RawBase serves file contents (raw.githubusercontent.com). When
+	// empty, files are downloaded through the API's contents endpoint,
+	// which is how GitHub Enterprise Server is reached.
 	RawBase string
+	// Token authenticates every request; empty means anonymous, which
+	// GitHub limits to 60 API requests per hour and public repositories.
+	Token string
+	// MaxRetries bounds the retries of a request that failed with 429, a
+	// 5xx or a rate limit. Zero means four.
+	MaxRetries int
+	// MaxWait caps how long a single retry may wait for Retry-After or a
+	// rate-limit reset; longer waits fail with an explanation instead.
+	// Zero means one minute.
+	MaxWait time.Duration
+
+	// sleep replaces the wait between retries in tests.
+	sleep func(ctx context.Context, d time.Duration) error
 }
 
-// NewInstaller returns an Installer pointed at github.com with a 30s HTTP
-// timeout.
+// EnvGitHubToken and EnvGitHubAPI configure GitHub access; see
+// NewInstaller.
+const (
+	EnvGitHubToken = "GITHUB_TOKEN"
+	EnvGitHubAPI   = "ADL_GITHUB_API_URL"
+)
+
+// NewInstaller returns an Installer with a 30s HTTP timeout per request.
+// It authenticates with GITHUB_TOKEN or GH_TOKEN when set, and talks to
+// the GitHub Enterprise API named by ADL_GITHUB_API_URL (or
+// GITHUB_API_URL, which GitHub Actions sets) instead of api.github.com.
 func NewInstaller() *Installer {
-	return &Installer{
+	i := &Installer{
 		Client:  &http.Client{Timeout: installerTimeout},
 		APIBase: githubAPIBase,
 		RawBase: githubRawBase,
+		Token:   firstEnv(EnvGitHubToken, "GH_TOKEN"),
 	}
+	if api := strings.TrimSuffix(firstEnv(EnvGitHubAPI, "GITHUB_API_URL"), "/"); api != "" && api != githubAPIBase {
+		i.APIBase = api
+		i.RawBase = ""
+	}
+	return i
+}
+
+func firstEnv(keys ...string) string {
+	for _, k := range keys {
+		if v := os.Getenv(k); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// githubHosts returns the hosts whose tree URLs are accepted: github.com
+// and, when an Enterprise API is configured, its web host.
+func githubHosts() []string {
+	hosts := []string{"github.com"}
+	api := firstEnv(EnvGitHubAPI, "GITHUB_API_URL")
+	if u, err := url.Parse(api); err == nil && u.Host != "" && u.Host != "api.github.com" {
+		hosts = append(hosts, strings.TrimPrefix(u.Host, "api."))
+	}
+	return hosts
 }
 
 // Fetch downloads every blob under loc.Path at loc.Ref and returns a
@@ -171,42 +226,63 @@ func (i *Installer) Fetch(ctx context.Context, loc *GitHubLocation) (map[string]
 		return nil, fmt.Errorf("no files found under %s/%s/%s @ %s - check the URL", loc.Owner, loc.Repo, loc.Path, loc.Ref)
 	}
 
+	contents, err := i.downloadBlobs(ctx, loc, blobs)
+	if err != nil {
+		return nil, err
+	}
 	files := make(map[string][]byte, len(blobs))
-	for _, b := range blobs {
-		rel := strings.TrimPrefix(b.Path, prefix)
-		data, err := i.downloadBlob(ctx, loc, b.Path)
-		if err != nil {
-			return nil, err
-		}
-		files[rel] = data
+	for n, b := range blobs {
+		files[strings.TrimPrefix(b.Path, prefix)] = contents[n]
 	}
 	return files, nil
 }
 
-func (i *Installer) fetchTree(ctx context.Context, loc *GitHubLocation) (*treeResponse, error) {
-	apiURL := fmt.Sprintf("%s/repos/%s/%s/git/trees/%s?recursive=1", i.APIBase, loc.Owner, loc.Repo, loc.Ref)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create tree request: %w", err)
+// downloadBlobs downloads blobs in parallel, at most
+// installerConcurrency at a time, and returns their contents in the same
+// order. The first failure cancels the remaining downloads.
+func (i *Installer) downloadBlobs(ctx context.Context, loc *GitHubLocation, blobs []treeEntry) ([][]byte, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		once     sync.Once
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	contents := make([][]byte, len(blobs))
+	sem := make(chan struct{}, installerConcurrency)
+	for n, b := range blobs {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			data, err := i.downloadBlob(ctx, loc, b.Path)
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			contents[n] = data
+		})
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
 	}
-	req.Header.Set("User-Agent", installerUA)
-	req.Header.Set("Accept", "application/vnd.github+json")
+	return contents, nil
+}
 
-	resp, err := i.Client.Do(req)
+func (i *Installer) fetchTree(ctx context.Context, loc *GitHubLocation) (*treeResponse, error) {
+	apiURL := fmt.Sprintf("%s/repos/%s/%s/git/trees/%s?recursive=1", i.APIBase, loc.Owner, loc.Repo, loc.Ref)
+	resp, err := i.get(ctx, apiURL, "application/vnd.github+json")
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch tree: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	switch resp.StatusCode {
-	case http.StatusNotFound:
-		return nil, fmt.Errorf("repository or ref not found: %s/%s @ %s", loc.Owner, loc.Repo, loc.Ref)
-	case http.StatusForbidden:
-		return nil, fmt.Errorf("GitHub API rate limit exceeded (60 req/hour for unauthenticated requests); try again later")
-	}
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("GitHub API returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	if err := i.checkStatus(resp, loc.Owner, loc.Repo, loc.Ref); err != nil {
+		return nil, err
 	}
 
 	var tree treeResponse
@@ -219,15 +295,95 @@ func (i *Installer) fetchTree(ctx context.Context, loc *GitHubLocation) (*treeRe
 	return &tree, nil
 }
 
-func (i *Installer) downloadBlob(ctx context.Context, loc *GitHubLocation, repoPath string) ([]byte, error) {
-	rawURL := fmt.Sprintf("%s/%s/%s/%s/%s", i.RawBase, loc.Owner, loc.Repo, loc.Ref, repoPath)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+// checkStatus turns a non-200 API response into an error. GitHub answers
+// 404 rather than 403 for private repositories the caller cannot see,
+// so anonymous callers get a hint about tokens either way.
+func (i *Installer) checkStatus(resp *http.Response, owner, repo, ref string) error {
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	hint := ""
+	if i.Token == "" {
+		hint = " (private repositories need GITHUB_TOKEN or GH_TOKEN)"
+	}
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusUnprocessableEntity:
+		if ref == "" {
+			return fmt.Errorf("repository not found: %s/%s%s", owner, repo, hint)
+		}
+		return fmt.Errorf("repository or ref not found: %s/%s @ %s%s", owner, repo, ref, hint)
+	case http.StatusUnauthorized:
+		return fmt.Errorf("GitHub rejected the token for %s/%s: check GITHUB_TOKEN or GH_TOKEN", owner, repo)
+	case http.StatusForbidden:
+		return fmt.Errorf("access to %s/%s denied%s", owner, repo, hint)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return fmt.Errorf("GitHub API returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// Commit resolves loc.Ref (a branch, tag or SHA) to the SHA of the commit
+// it currently points at.
+func (i *Installer) Commit(ctx context.Context, loc *GitHubLocation) (string, error) {
+	apiURL := fmt.Sprintf("%s/repos/%s/%s/commits/%s", i.APIBase, loc.Owner, loc.Repo, loc.Ref)
+	resp, err := i.get(ctx, apiURL, "application/vnd.github.sha")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve commit: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := i.checkStatus(resp, loc.Owner, loc.Repo, loc.Ref); err != nil {
+		return "", err
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read commit response: %w", err)
+	}
+	sha := strings.TrimSpace(string(body))
+	if sha == "" {
+		return "", fmt.Errorf("GitHub API returned an empty commit SHA for %s/%s @ %s", loc.Owner, loc.Repo, loc.Ref)
+	}
+	return sha, nil
+}
+
+type tagEntry struct {
+	Name string `json:"name"`
+}
+
+// Tags lists the tag names of a GitHub repository, most recent first as
+// returned by the API (first page only, up to 100 tags).
+func (i *Installer) Tags(ctx context.Context, owner, repo string) ([]string, error) {
+	apiURL := fmt.Sprintf("%s/repos/%s/%s/tags?per_page=100", i.APIBase, owner, repo)
+	resp, err := i.get(ctx, apiURL, "application/vnd.github+json")
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request for %s: %w", repoPath, err)
+		return nil, fmt.Errorf("failed to fetch tags: %w", err)
 	}
-	req.Header.Set("User-Agent", installerUA)
+	defer func() { _ = resp.Body.Close() }()
 
-	resp, err := i.Client.Do(req)
+	if err := i.checkStatus(resp, owner, repo, ""); err != nil {
+		return nil, err
+	}
+
+	var entries []tagEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("failed to parse tags response: %w", err)
+	}
+	tags := make([]string, len(entries))
+	for n, e := range entries {
+		tags[n] = e.Name
+	}
+	return tags, nil
+}
+
+// downloadBlob downloads one file from RawBase or, without one, through
+// the API's contents endpoint.
+func (i *Installer) downloadBlob(ctx context.Context, loc *GitHubLocation, repoPath string) ([]byte, error) {
+	target := fmt.Sprintf("%s/%s/%s/%s/%s", i.RawBase, loc.Owner, loc.Repo, loc.Ref, repoPath)
+	accept := ""
+	if i.RawBase == "" {
+		target = fmt.Sprintf("%s/repos/%s/%s/contents/%s?ref=%s", i.APIBase, loc.Owner, loc.Repo, repoPath, url.QueryEscape(loc.Ref))
+		accept = "application/vnd.github.raw"
+	}
+	resp, err := i.get(ctx, target, accept)
 	if err != nil {
 		return nil, fmt.Errorf("failed to download %s: %w", repoPath, err)
 	}
@@ -3,9 +3,11 @@ package registry
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 )
 
@@ -124,6 +126,38 @@ func TestInstaller_Fetch(t *testing.T) {
 	}
 }
 
+func TestInstaller_FetchDownloadsInParallel(t *testing.T) {
+	const blobs = 4
+	tree := treeResponse{}
+	for n := range blobs {
+		tree.Tree = append(tree.Tree, treeEntry{Path: fmt.Sprintf("skills/foo/file%d.txt", n), Type: "blob"})
+	}
+
+	var inFlight, peak atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/skills/git/trees/main", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(tree)
+	})
+	mux.HandleFunc("/acme/skills/main/skills/foo/", func(w http.ResponseWriter, r *http.Request) {
+		waitForPeak(&inFlight, &peak, blobs)
+		_, _ = w.Write([]byte(r.URL.Path))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	inst := &Installer{Client: srv.Client(), APIBase: srv.URL, RawBase: srv.URL}
+	files, err := inst.Fetch(context.Background(), &GitHubLocation{Owner: "acme", Repo: "skills", Ref: "main", Path: "skills/foo"})
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(files) != blobs || string(files["file2.txt"]) != "/acme/skills/main/skills/foo/file2.txt" {
+		t.Errorf("unexpected files: %v", files)
+	}
+	if got := peak.Load(); got != blobs {
+		t.Errorf("expected %d concurrent blob downloads, got %d", blobs, got)
+	}
+}
+
 func TestInstaller_FetchEmptyPath(t *testing.T) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/repos/acme/skills/git/trees/main", func(w http.ResponseWriter, r *http.Request) {
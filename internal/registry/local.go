@@ -0,0 +1,99 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalFetcher reads skills from directories on disk, so skills that
+// live in the same repository as the agent (a monorepo) can be used
+// without publishing them first. It accepts file:// URLs and paths that
+// start with "/", "./" or "../"; relative paths resolve against BaseDir.
+type LocalFetcher struct {
+	BaseDir string
+}
+
+// IsLocalSource reports whether source names a directory on disk.
+func IsLocalSource(source string) bool {
+	return strings.HasPrefix(source, "file://") ||
+		strings.HasPrefix(source, "/") ||
+		strings.HasPrefix(source, "./") ||
+		strings.HasPrefix(source, "../")
+}
+
+func (f *LocalFetcher) Parse(source string) (*SourceLocation, bool, error) {
+	if !IsLocalSource(source) {
+		return nil, false, nil
+	}
+	dir := filepath.FromSlash(strings.TrimPrefix(source, "file://"))
+	if dir == "" {
+		return nil, false, fmt.Errorf("file:// source has no path")
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(f.BaseDir, dir)
+	}
+	return &SourceLocation{Path: filepath.Clean(dir), Local: true}, true, nil
+}
+
+// Fetch reads every regular file under loc.Path. Hidden directories
+// (.git and friends) are skipped.
+func (f *LocalFetcher) Fetch(ctx context.Context, loc *SourceLocation) (map[string][]byte, error) {
+	info, err := os.Stat(loc.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read skill directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory - point source at the skill directory", loc.Path)
+	}
+
+	files := make(map[string][]byte)
+	err = filepath.WalkDir(loc.Path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != loc.Path && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(loc.Path, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read skill directory %s: %w", loc.Path, err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files found under %s", loc.Path)
+	}
+	return files, nil
+}
+
+// Commit is not meaningful for directories on disk.
+func (f *LocalFetcher) Commit(ctx context.Context, loc *SourceLocation) (string, error) {
+	return "", nil
+}
+
+// Tags is not meaningful for directories on disk.
+func (f *LocalFetcher) Tags(ctx context.Context, loc *SourceLocation) ([]string, error) {
+	return nil, nil
+}
+
+func (f *LocalFetcher) URL(loc *SourceLocation) string {
+	return "file://" + filepath.ToSlash(loc.Path)
+}
@@ -0,0 +1,225 @@
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+)
+
+// LockFile is the name of the lockfile kept next to the ADL file. It is
+// meant to be committed so every checkout generates the same skill
+// content, whatever branch heads or registry defaults have moved to.
+const LockFile = "adl.lock"
+
+// lockVersion is the format version written to new lockfiles.
+const lockVersion = 1
+
+// Lock records the resolved content of every non-bare skill.
+type Lock struct {
+	Version int           `json:"lockfileVersion"`
+	Skills  []LockedSkill `json:"skills"`
+
+	// mu guards Skills and changed; ResolveAll records entries from
+	// several goroutines.
+	mu      sync.Mutex
+	changed bool
+}
+
+// LockedSkill pins one spec.skills entry.
+type LockedSkill struct {
+	ID string `json:"id"`
+	// Declared is the manifest entry the lock was taken for: the skill's
+	// source, or "<id>[@<version>]" for registry skills. Editing the
+	// entry in the ADL makes the lock entry stale.
+	Declared string `json:"declared"`
+	// Resolved is where the files were fetched from: the GitHub tree URL
+	// pinned to Commit, or the registry URL.
+	Resolved string `json:"resolved"`
+	// Commit is the commit SHA a GitHub source was fetched at.
+	Commit string `json:"commit,omitempty"`
+	// Version is the version the fetched SKILL.md declares, if any.
+	Version string `json:"version,omitempty"`
+	// SHA256 maps every file, relative to the skill directory, to the
+	// hex SHA-256 digest of its content.
+	SHA256 map[string]string `json:"sha256"`
+}
+
+// LockPath returns the path of the lockfile belonging to adlFile.
+func LockPath(adlFile string) string {
+	return filepath.Join(filepath.Dir(adlFile), LockFile)
+}
+
+// LoadLock reads the lockfile at path. A missing lockfile is not an
+// error; it yields an empty lock.
+func LoadLock(path string) (*Lock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Lock{Version: lockVersion}, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", LockFile, err)
+	}
+	return ParseLock(data)
+}
+
+// ParseLock decodes the content of a lockfile.
+func ParseLock(data []byte) (*Lock, error) {
+	var l Lock
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", LockFile, err)
+	}
+	if l.Version > lockVersion {
+		return nil, fmt.Errorf("%s has lockfileVersion %d; this CLI supports up to %d, upgrade adl", LockFile, l.Version, lockVersion)
+	}
+	return &l, nil
+}
+
+// Write stores the lock at path.
+func (l *Lock) Write(path string) error {
+	data, err := l.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", LockFile, err)
+	}
+	l.mu.Lock()
+	l.changed = false
+	l.mu.Unlock()
+	return nil
+}
+
+// Marshal encodes the lock as it is written to adl.lock.
+func (l *Lock) Marshal() ([]byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.Version = lockVersion
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", LockFile, err)
+	}
+	return append(data, '\n'), nil
+}
+
+// Changed reports whether the lock was modified since it was loaded or
+// last written.
+func (l *Lock) Changed() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.changed
+}
+
+// Skill returns the entry for id, if any.
+func (l *Lock) Skill(id string) (LockedSkill, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, s := range l.Skills {
+		if s.ID == id {
+			return s, true
+		}
+	}
+	return LockedSkill{}, false
+}
+
+// Set adds or replaces the entry for entry.ID, keeping entries sorted by
+// ID.
+func (l *Lock) Set(entry LockedSkill) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	i, found := slices.BinarySearchFunc(l.Skills, entry.ID, func(s LockedSkill, id string) int {
+		return strings.Compare(s.ID, id)
+	})
+	if found {
+		if l.Skills[i].equal(entry) {
+			return
+		}
+		l.Skills[i] = entry
+	} else {
+		l.Skills = slices.Insert(l.Skills, i, entry)
+	}
+	l.changed = true
+}
+
+// Remove deletes the entry for id. It reports whether there was one.
+func (l *Lock) Remove(id string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := len(l.Skills)
+	l.Skills = slices.DeleteFunc(l.Skills, func(s LockedSkill) bool { return s.ID == id })
+	if len(l.Skills) == n {
+		return false
+	}
+	l.changed = true
+	return true
+}
+
+// Retain drops the entries of skills no longer declared in skills, or
+// declared as bare skills or local directories, which are not locked.
+func (l *Lock) Retain(skills []schema.Skill) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := len(l.Skills)
+	l.Skills = slices.DeleteFunc(l.Skills, func(s LockedSkill) bool {
+		return !slices.ContainsFunc(skills, func(d schema.Skill) bool { return d.ID == s.ID && !d.Bare && !IsLocalSource(d.Source) })
+	})
+	if len(l.Skills) != n {
+		l.changed = true
+	}
+}
+
+func (s LockedSkill) equal(o LockedSkill) bool {
+	return s.ID == o.ID && s.Declared == o.Declared && s.Resolved == o.Resolved &&
+		s.Commit == o.Commit && s.Version == o.Version && maps.Equal(s.SHA256, o.SHA256)
+}
+
+// verify checks files against the recorded digests.
+func (s LockedSkill) verify(files map[string][]byte) error {
+	for _, name := range slices.Sorted(maps.Keys(s.SHA256)) {
+		data, ok := files[name]
+		if !ok {
+			return fmt.Errorf("%s is missing", name)
+		}
+		if digest(data) != s.SHA256[name] {
+			return fmt.Errorf("%s has changed", name)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		if _, ok := s.SHA256[name]; !ok {
+			return fmt.Errorf("%s is not in the lock", name)
+		}
+	}
+	return nil
+}
+
+// declaredAs renders the manifest entry a lock entry is taken for.
+func declaredAs(skill schema.Skill) string {
+	switch {
+	case skill.Source != "":
+		return skill.Source
+	case skill.Version != "":
+		return skill.ID + "@" + skill.Version
+	}
+	return skill.ID
+}
+
+func digestFiles(files map[string][]byte) map[string]string {
+	digests := make(map[string]string, len(files))
+	for name, data := range files {
+		digests[name] = digest(data)
+	}
+	return digests
+}
+
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,205 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+)
+
+func TestLock_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), LockFile)
+	lock, err := LoadLock(path)
+	if err != nil {
+		t.Fatalf("LoadLock on a missing file: %v", err)
+	}
+	if len(lock.Skills) != 0 || lock.Changed() {
+		t.Fatalf("expected an empty, unchanged lock, got %+v", lock)
+	}
+
+	lock.Set(LockedSkill{ID: "zeta", Declared: "zeta", SHA256: map[string]string{SkillFile: digest([]byte("z"))}})
+	lock.Set(LockedSkill{ID: "alpha", Declared: "alpha@1.0.0", Version: "1.0.0", SHA256: map[string]string{SkillFile: digest([]byte("a"))}})
+	if !lock.Changed() {
+		t.Fatal("expected Set to mark the lock changed")
+	}
+	if err := lock.Write(path); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	loaded, err := LoadLock(path)
+	if err != nil {
+		t.Fatalf("LoadLock: %v", err)
+	}
+	if loaded.Version != lockVersion || len(loaded.Skills) != 2 || loaded.Skills[0].ID != "alpha" {
+		t.Fatalf("expected two entries sorted by ID, got %+v", loaded)
+	}
+
+	loaded.Set(loaded.Skills[0])
+	if loaded.Changed() {
+		t.Error("setting an identical entry must not mark the lock changed")
+	}
+	loaded.Retain([]schema.Skill{{ID: "alpha"}, {ID: "zeta", Bare: true}})
+	if _, ok := loaded.Skill("zeta"); ok || !loaded.Changed() {
+		t.Errorf("expected Retain to drop entries of bare or undeclared skills, got %+v", loaded.Skills)
+	}
+	if loaded.Remove("zeta") || !loaded.Remove("alpha") || len(loaded.Skills) != 0 {
+		t.Errorf("unexpected Remove result, got %+v", loaded.Skills)
+	}
+}
+
+func TestLockedSkill_Verify(t *testing.T) {
+	entry := LockedSkill{SHA256: digestFiles(map[string][]byte{
+		SkillFile:      []byte("skill"),
+		"scripts/a.sh": []byte("echo a"),
+	})}
+
+	tests := []struct {
+		name  string
+		files map[string][]byte
+		want  string
+	}{
+		{"matching", map[string][]byte{SkillFile: []byte("skill"), "scripts/a.sh": []byte("echo a")}, ""},
+		{"changed", map[string][]byte{SkillFile: []byte("skill v2"), "scripts/a.sh": []byte("echo a")}, "SKILL.md has changed"},
+		{"missing", map[string][]byte{SkillFile: []byte("skill")}, "scripts/a.sh is missing"},
+		{"extra", map[string][]byte{SkillFile: []byte("skill"), "scripts/a.sh": []byte("echo a"), "b.sh": nil}, "b.sh is not in the lock"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := entry.verify(tt.files)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("verify() = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolver_LockRegistrySkill(t *testing.T) {
+	body := "---\nname: data-analysis\ndescription: v1\nversion: 1.0.0\n---\nbody\n"
+	resolver, closer := newTestResolver(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	})
+	defer closer()
+	resolver.Lock = &Lock{}
+
+	skill := schema.Skill{ID: "data-analysis"}
+	if _, err := resolver.Resolve(context.Background(), skill); err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	entry, ok := resolver.Lock.Skill("data-analysis")
+	if !ok {
+		t.Fatal("expected the skill to be added to the lock")
+	}
+	if entry.Declared != "data-analysis" || entry.Version != "1.0.0" || !strings.HasSuffix(entry.Resolved, "/skills/data-analysis/1.0.0.md") {
+		t.Errorf("unexpected lock entry: %+v", entry)
+	}
+	if entry.SHA256[SkillFile] != digest([]byte(body)) {
+		t.Errorf("unexpected SKILL.md digest %q", entry.SHA256[SkillFile])
+	}
+
+	// A teammate with an empty cache fetches different content.
+	resolver.Cache, _ = NewCache(t.TempDir())
+	body = "---\nname: data-analysis\ndescription: tampered\nversion: 1.0.0\n---\nbody\n"
+	_, err := resolver.Resolve(context.Background(), skill)
+	if err == nil || !strings.Contains(err.Error(), "does not match adl.lock") || !strings.Contains(err.Error(), "adl skills update data-analysis") {
+		t.Fatalf("expected a lock mismatch error, got %v", err)
+	}
+
+	resolver.UpdateLock = true
+	if _, err := resolver.Resolve(context.Background(), skill); err != nil {
+		t.Fatalf("Resolve with UpdateLock: %v", err)
+	}
+	if entry, _ := resolver.Lock.Skill("data-analysis"); entry.SHA256[SkillFile] != digest([]byte(body)) {
+		t.Error("expected UpdateLock to record the new content")
+	}
+}
+
+func TestResolver_LockStaleDeclaration(t *testing.T) {
+	resolver, closer := newTestResolver(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("a stale lock entry should fail before fetching, got request: %s", r.URL.Path)
+	})
+	defer closer()
+	resolver.Lock = &Lock{}
+	resolver.Lock.Set(LockedSkill{ID: "data-analysis", Declared: "data-analysis@1.0.0"})
+
+	_, err := resolver.Resolve(context.Background(), schema.Skill{ID: "data-analysis", Version: "2.0.0"})
+	if err == nil || !strings.Contains(err.Error(), "locked as data-analysis@1.0.0 but declared as data-analysis@2.0.0") {
+		t.Fatalf("expected a stale lock error, got %v", err)
+	}
+}
+
+func TestResolver_LockGitHubCommit(t *testing.T) {
+	head := "c0ffee"
+	contents := map[string]string{
+		"c0ffee":   "---\nname: triage\ndescription: first\n---\nbody\n",
+		"deadbeef": "---\nname: triage\ndescription: second\n---\nbody\n",
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/skills/commits/main", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "application/vnd.github.sha" {
+			t.Errorf("unexpected Accept header %q", r.Header.Get("Accept"))
+		}
+		_, _ = w.Write([]byte(head))
+	})
+	mux.HandleFunc("/repos/acme/skills/git/trees/", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(treeResponse{Tree: []treeEntry{{Path: "skills/triage/SKILL.md", Type: "blob"}}})
+	})
+	mux.HandleFunc("/acme/skills/", func(w http.ResponseWriter, r *http.Request) {
+		ref := strings.Split(strings.TrimPrefix(r.URL.Path, "/acme/skills/"), "/")[0]
+		body, ok := contents[ref]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	newResolver := func() *Resolver {
+		cache, err := NewCache(t.TempDir())
+		if err != nil {
+			t.Fatalf("NewCache: %v", err)
+		}
+		return &Resolver{
+			Client:    NewClient(""),
+			Installer: &Installer{Client: srv.Client(), APIBase: srv.URL, RawBase: srv.URL},
+			Cache:     cache,
+		}
+	}
+	skill := schema.Skill{ID: "triage", Source: "acme/skills/triage"}
+
+	lock := &Lock{}
+	first := newResolver()
+	first.Lock = lock
+	if _, err := first.Resolve(context.Background(), skill); err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	entry, _ := lock.Skill("triage")
+	if entry.Commit != "c0ffee" || entry.Resolved != "https://github.com/acme/skills/tree/c0ffee/skills/triage" {
+		t.Errorf("expected the lock to pin the resolved commit, got %+v", entry)
+	}
+
+	// main moves on; a fresh checkout still generates the locked commit.
+	head = "deadbeef"
+	second := newResolver()
+	second.Lock = lock
+	resolved, err := second.Resolve(context.Background(), skill)
+	if err != nil {
+		t.Fatalf("Resolve with lock: %v", err)
+	}
+	if resolved.Description != "first" {
+		t.Errorf("expected the locked content, got description %q", resolved.Description)
+	}
+}
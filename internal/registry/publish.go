@@ -0,0 +1,114 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+)
+
+// skillLicenses are the licenses spec.skills[].license accepts.
+var skillLicenses = []schema.SkillLicense{
+	schema.SkillLicenseApache20, schema.SkillLicenseBSD2Clause, schema.SkillLicenseBSD3Clause,
+	schema.SkillLicenseCC010, schema.SkillLicenseCCBY40, schema.SkillLicenseCCBYSA40,
+	schema.SkillLicenseGPL20, schema.SkillLicenseGPL30, schema.SkillLicenseISC,
+	schema.SkillLicenseLGPL21, schema.SkillLicenseLGPL30, schema.SkillLicenseMIT,
+	schema.SkillLicenseMPL20, schema.SkillLicenseProprietary, schema.SkillLicenseUnlicense,
+}
+
+// SkillPackage is a skill directory ready to be published.
+type SkillPackage struct {
+	ID          string
+	Frontmatter Frontmatter
+	Files       map[string][]byte
+}
+
+// PackageSkill reads the skill directory dir as skill id and checks
+// that its SKILL.md can be published: besides the name and description
+// every skill needs, the frontmatter must declare one of the licenses
+// spec.skills accepts and a MAJOR.MINOR.PATCH version.
+func PackageSkill(id, dir string) (*SkillPackage, error) {
+	files, err := (&LocalFetcher{}).Fetch(context.Background(), &SourceLocation{Path: dir, Local: true})
+	if err != nil {
+		return nil, err
+	}
+	skillMD, ok := files[SkillFile]
+	if !ok {
+		return nil, fmt.Errorf("no %s found in %s", SkillFile, dir)
+	}
+	doc, err := ParseSkillDocument(skillMD)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", SkillFile, err)
+	}
+
+	fm := doc.Frontmatter
+	if fm.License == "" {
+		return nil, fmt.Errorf("%s: frontmatter is missing required field 'license'", SkillFile)
+	}
+	if !slices.Contains(skillLicenses, schema.SkillLicense(fm.License)) {
+		return nil, fmt.Errorf("%s: license %q is not an accepted SPDX identifier or Proprietary", SkillFile, fm.License)
+	}
+	if fm.Version == "" {
+		return nil, fmt.Errorf("%s: frontmatter is missing required field 'version'", SkillFile)
+	}
+	if _, ok := parseVersion(fm.Version); !ok || strings.HasPrefix(fm.Version, "v") {
+		return nil, fmt.Errorf("%s: version %q is not a MAJOR.MINOR.PATCH release", SkillFile, fm.Version)
+	}
+	return &SkillPackage{ID: id, Frontmatter: fm, Files: files}, nil
+}
+
+// Write writes the package as a gzipped tar archive of the skill's
+// files, sorted by path.
+func (p *SkillPackage) Write(w io.Writer) error {
+	a := newArchiveWriter(w)
+	for _, name := range slices.Sorted(maps.Keys(p.Files)) {
+		if err := a.add(name, p.Files[name]); err != nil {
+			return err
+		}
+	}
+	return a.close()
+}
+
+// PackageURL returns the URL Publish uploads the package of (id,
+// version) to, next to the SKILL.md FetchByID reads.
+func (c *Client) PackageURL(id, version string) (string, error) {
+	return joinURL(c.BaseURL, id+"/"+version+".tar.gz")
+}
+
+// Publish uploads p to the registry with a PUT to PackageURL and returns
+// that URL. Fallback registries are never published to.
+func (c *Client) Publish(ctx context.Context, p *SkillPackage) (string, error) {
+	target, err := c.PackageURL(p.ID, p.Frontmatter.Version)
+	if err != nil {
+		return "", err
+	}
+	var body bytes.Buffer
+	if err := p.Write(&body); err != nil {
+		return "", err
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPut, target, &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to publish to %s: %w", target, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusConflict {
+		return "", fmt.Errorf("skill %s@%s is already published to %s; bump the version in its %s", p.ID, p.Frontmatter.Version, c.BaseURL, SkillFile)
+	}
+	if err := checkRegistryStatus(resp, target); err != nil {
+		return "", err
+	}
+	return target, nil
+}
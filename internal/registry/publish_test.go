@@ -0,0 +1,112 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSkillDir(t *testing.T, frontmatter string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "policy")
+	if err := os.MkdirAll(filepath.Join(dir, "scripts"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, SkillFile), []byte("---\n"+frontmatter+"---\n# Policy\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "scripts", "check.sh"), []byte("#!/bin/sh\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestPackageSkill_Validates(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter string
+		wantErr     string
+	}{
+		{"valid", "name: policy\ndescription: Rules\nlicense: MIT\nversion: 1.0.0\n", ""},
+		{"missing description", "name: policy\nlicense: MIT\nversion: 1.0.0\n", "description"},
+		{"missing license", "name: policy\ndescription: Rules\nversion: 1.0.0\n", "'license'"},
+		{"unknown license", "name: policy\ndescription: Rules\nlicense: WTFPL\nversion: 1.0.0\n", "WTFPL"},
+		{"missing version", "name: policy\ndescription: Rules\nlicense: MIT\n", "'version'"},
+		{"partial version", "name: policy\ndescription: Rules\nlicense: MIT\nversion: \"1.0\"\n", "MAJOR.MINOR.PATCH"},
+		{"prefixed version", "name: policy\ndescription: Rules\nlicense: MIT\nversion: v1.0.0\n", "MAJOR.MINOR.PATCH"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg, err := PackageSkill("policy", writeSkillDir(t, tt.frontmatter))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("PackageSkill: %v", err)
+				}
+				if len(pkg.Files) != 2 || pkg.Frontmatter.Version != "1.0.0" {
+					t.Errorf("unexpected package: %+v", pkg)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error mentioning %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestClient_Publish(t *testing.T) {
+	var uploaded map[string][]byte
+	published := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/skills/policy/1.0.0.tar.gz" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("Content-Type") != "application/gzip" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if published {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		var err error
+		if uploaded, err = readArchive(r.Body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		published = true
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	pkg, err := PackageSkill("policy", writeSkillDir(t, "name: policy\ndescription: Rules\nlicense: MIT\nversion: 1.0.0\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := NewClient(srv.URL + "/skills/")
+	if _, err := client.Publish(context.Background(), pkg); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected publishing without credentials to fail, got %v", err)
+	}
+
+	client.Token = "secret"
+	target, err := client.Publish(context.Background(), pkg)
+	if err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if target != srv.URL+"/skills/policy/1.0.0.tar.gz" {
+		t.Errorf("unexpected target %s", target)
+	}
+	if len(uploaded) != 2 || !bytes.Equal(uploaded["scripts/check.sh"], pkg.Files["scripts/check.sh"]) {
+		t.Errorf("unexpected upload: %v", keysOf(uploaded))
+	}
+
+	if _, err := client.Publish(context.Background(), pkg); err == nil || !strings.Contains(err.Error(), "already published") {
+		t.Errorf("expected republishing a version to fail, got %v", err)
+	}
+}
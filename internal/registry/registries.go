@@ -0,0 +1,181 @@
+package registry
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RegistriesFile is the per-user file, under ~/.adl/, that lists the
+// skills registries to fetch from and how to authenticate against them.
+const RegistriesFile = "registries.yaml"
+
+const (
+	// EnvRegistriesFile overrides the location of RegistriesFile.
+	EnvRegistriesFile = "ADL_SKILLS_REGISTRIES_FILE"
+	// EnvRegistryToken is a bearer token for the primary registry.
+	EnvRegistryToken = "ADL_SKILLS_REGISTRY_TOKEN"
+	// EnvRegistryUsername and EnvRegistryPassword are basic auth
+	// credentials for the primary registry.
+	EnvRegistryUsername = "ADL_SKILLS_REGISTRY_USERNAME"
+	EnvRegistryPassword = "ADL_SKILLS_REGISTRY_PASSWORD"
+	// EnvRegistryHeaders holds extra headers for the primary registry as
+	// comma-separated Name=value pairs.
+	EnvRegistryHeaders = "ADL_SKILLS_REGISTRY_HEADERS"
+)
+
+// Registry configures one skills registry. Token, Username, Password and
+// header values may reference environment variables as $VAR or ${VAR}
+// so secrets stay out of the file.
+type Registry struct {
+	URL      string            `yaml:"url"`
+	Token    string            `yaml:"token,omitempty"`
+	Username string            `yaml:"username,omitempty"`
+	Password string            `yaml:"password,omitempty"`
+	Headers  map[string]string `yaml:"headers,omitempty"`
+}
+
+type registriesConfig struct {
+	Registries []Registry `yaml:"registries"`
+}
+
+// RegistriesPath returns the path of the registries file:
+// ADL_SKILLS_REGISTRIES_FILE, or ~/.adl/registries.yaml.
+func RegistriesPath() (string, error) {
+	if path := os.Getenv(EnvRegistriesFile); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve user home directory: %w", err)
+	}
+	return filepath.Join(home, ".adl", RegistriesFile), nil
+}
+
+// LoadRegistries reads the registries listed in path, in order, with
+// environment references expanded. A missing file lists none.
+func LoadRegistries(path string) ([]Registry, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var cfg registriesConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for i := range cfg.Registries {
+		reg := &cfg.Registries[i]
+		if reg.URL == "" {
+			return nil, fmt.Errorf("%s: registries[%d] has no url", path, i)
+		}
+		reg.Token = os.ExpandEnv(reg.Token)
+		reg.Username = os.ExpandEnv(reg.Username)
+		reg.Password = os.ExpandEnv(reg.Password)
+		for name, value := range reg.Headers {
+			reg.Headers[name] = os.ExpandEnv(value)
+		}
+	}
+	return cfg.Registries, nil
+}
+
+// DefaultRegistries returns the registries to fetch from, in order. They
+// come from the registries file; ADL_SKILLS_REGISTRY, when set, replaces
+// that list (keeping the file's settings for URLs it also lists), and
+// the ADL_SKILLS_REGISTRY_* credentials apply to the first registry.
+// Without either, the default registry is used.
+func DefaultRegistries() ([]Registry, error) {
+	path, err := RegistriesPath()
+	if err != nil {
+		return nil, err
+	}
+	regs, err := LoadRegistries(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if env := os.Getenv(EnvBaseURL); env != "" {
+		var fromEnv []Registry
+		for u := range strings.SplitSeq(env, ",") {
+			if u = strings.TrimSpace(u); u == "" {
+				continue
+			}
+			reg := Registry{URL: u}
+			for _, configured := range regs {
+				if sameRegistry(configured.URL, u) {
+					reg = configured
+				}
+			}
+			fromEnv = append(fromEnv, reg)
+		}
+		regs = fromEnv
+	}
+	if len(regs) == 0 {
+		regs = []Registry{{URL: DefaultBaseURL}}
+	}
+
+	primary := &regs[0]
+	if token := os.Getenv(EnvRegistryToken); token != "" {
+		primary.Token = token
+	}
+	if username := os.Getenv(EnvRegistryUsername); username != "" {
+		primary.Username = username
+		primary.Password = os.Getenv(EnvRegistryPassword)
+	}
+	if headers := os.Getenv(EnvRegistryHeaders); headers != "" {
+		primary.Headers = make(map[string]string)
+		for pair := range strings.SplitSeq(headers, ",") {
+			name, value, ok := strings.Cut(pair, "=")
+			if !ok || strings.TrimSpace(name) == "" {
+				return nil, fmt.Errorf("%s must hold comma-separated Name=value pairs: got %q", EnvRegistryHeaders, pair)
+			}
+			primary.Headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+		}
+	}
+	return regs, nil
+}
+
+// NewRegistryClient returns a Client for the first registry that falls
+// back to the others in order.
+func NewRegistryClient(regs []Registry) *Client {
+	if len(regs) == 0 {
+		return NewClient("")
+	}
+	clients := make([]*Client, len(regs))
+	for i, reg := range regs {
+		c := NewClient(reg.URL)
+		c.Token = reg.Token
+		c.Username = reg.Username
+		c.Password = reg.Password
+		c.Headers = reg.Headers
+		clients[i] = c
+	}
+	clients[0].Fallbacks = clients[1:]
+	return clients[0]
+}
+
+// RegistryClient returns a Client for the registry at url alone, with
+// the settings DefaultRegistries has for it, if any.
+func RegistryClient(url string) (*Client, error) {
+	regs, err := DefaultRegistries()
+	if err != nil {
+		return nil, err
+	}
+	reg := Registry{URL: url}
+	for _, configured := range regs {
+		if sameRegistry(configured.URL, url) {
+			reg = configured
+			break
+		}
+	}
+	return NewRegistryClient([]Registry{reg}), nil
+}
+
+func sameRegistry(a, b string) bool {
+	return strings.TrimSuffix(a, "/") == strings.TrimSuffix(b, "/")
+}
@@ -0,0 +1,63 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDefaultRegistries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registries.yaml")
+	config := `registries:
+  - url: https://skills.acme.internal/
+    token: ${ACME_SKILLS_TOKEN}
+    headers:
+      X-Org: platform
+  - url: https://registry.inference-gateway.com/skills/
+`
+	if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(EnvRegistriesFile, path)
+	t.Setenv("ACME_SKILLS_TOKEN", "s3cret")
+	for _, env := range []string{EnvBaseURL, EnvRegistryToken, EnvRegistryUsername, EnvRegistryPassword, EnvRegistryHeaders} {
+		t.Setenv(env, "")
+	}
+
+	regs, err := DefaultRegistries()
+	if err != nil {
+		t.Fatalf("DefaultRegistries: %v", err)
+	}
+	if len(regs) != 2 || regs[0].Token != "s3cret" || regs[0].Headers["X-Org"] != "platform" || regs[1].URL != DefaultBaseURL {
+		t.Errorf("unexpected registries from the file: %+v", regs)
+	}
+
+	// The env list replaces the file's, keeping its settings for URLs
+	// both mention; the env credentials go to the first registry.
+	t.Setenv(EnvBaseURL, "https://mirror.acme.dev/skills, https://skills.acme.internal")
+	t.Setenv(EnvRegistryUsername, "bot")
+	t.Setenv(EnvRegistryPassword, "hunter2")
+	t.Setenv(EnvRegistryHeaders, "X-Api-Key=abc, X-Team=ai")
+	regs, err = DefaultRegistries()
+	if err != nil {
+		t.Fatalf("DefaultRegistries: %v", err)
+	}
+	if len(regs) != 2 || regs[0].URL != "https://mirror.acme.dev/skills" || regs[0].Username != "bot" || regs[0].Password != "hunter2" ||
+		regs[0].Headers["X-Api-Key"] != "abc" || regs[0].Headers["X-Team"] != "ai" || regs[1].Token != "s3cret" {
+		t.Errorf("unexpected registries from the environment: %+v", regs)
+	}
+
+	t.Setenv(EnvRegistryHeaders, "X-Api-Key")
+	if _, err := DefaultRegistries(); err == nil || !strings.Contains(err.Error(), EnvRegistryHeaders) {
+		t.Errorf("expected a malformed-headers error, got %v", err)
+	}
+
+	t.Setenv(EnvRegistriesFile, filepath.Join(t.TempDir(), "missing.yaml"))
+	for _, env := range []string{EnvBaseURL, EnvRegistryUsername, EnvRegistryHeaders} {
+		t.Setenv(env, "")
+	}
+	if regs, err = DefaultRegistries(); err != nil || len(regs) != 1 || regs[0].URL != DefaultBaseURL {
+		t.Errorf("expected the default registry without configuration, got %+v, %v", regs, err)
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/inference-gateway/adl-cli/internal/schema"
 )
@@ -26,7 +29,11 @@ type ResolvedSkill struct {
 	Version     string
 	License     string
 	Bare        bool
-	Files       map[string][]byte
+	// Ref is the ref the files are cached under: the declared version or
+	// source ref, or the version a range resolved to. It is empty for
+	// bare skills, local directories and unpinned registry skills.
+	Ref   string
+	Files map[string][]byte
 }
 
 // Resolver coordinates the GitHub Installer, the default registry
@@ -37,20 +44,91 @@ type Resolver struct {
 	Installer *Installer
 	Cache     *Cache
 	Offline   bool
+	// Refresh skips cache lookups for moving refs (branches and unpinned
+	// registry skills) so they are fetched again and their cache entries
+	// overwritten. Releases and commits never change and stay cached.
+	Refresh bool
+	// CacheTTL is how long a cached moving ref is used before it is
+	// revalidated against upstream. Zero means DefaultCacheTTL; a
+	// negative TTL revalidates on every use.
+	CacheTTL time.Duration
+	// Lock, when set, pins non-bare skills: their files must match the
+	// digests recorded for them, and GitHub sources are fetched at the
+	// recorded commit. Skills without an entry are added to the lock.
+	Lock *Lock
+	// UpdateLock replaces existing lock entries with whatever is resolved
+	// instead of verifying against them.
+	UpdateLock bool
+	// Fetchers handle skill sources beyond the built-in local directories,
+	// git remotes and GitHub; they are consulted first.
+	Fetchers []SourceFetcher
+	// BaseDir is the directory relative local sources resolve against,
+	// normally the ADL file's directory.
+	BaseDir string
+	// Concurrency bounds how many skills ResolveAll resolves at once.
+	// Zero means DefaultConcurrency.
+	Concurrency int
 }
 
-// NewDefaultResolver builds a Resolver using ADL_SKILLS_REGISTRY (or the
-// hardcoded default) and the user's home cache directory.
+// DefaultCacheTTL is how long cached moving refs are trusted unless
+// Resolver.CacheTTL says otherwise.
+const DefaultCacheTTL = time.Hour
+
+// EnvCacheTTL overrides DefaultCacheTTL with a Go duration ("30m");
+// "0" revalidates moving refs on every use.
+const EnvCacheTTL = "ADL_SKILLS_CACHE_TTL"
+
+// DefaultConcurrency is the number of skills ResolveAll resolves in
+// parallel unless Resolver.Concurrency says otherwise.
+const DefaultConcurrency = 8
+
+// ResolveErrors aggregates the failures of a ResolveAll call, one per
+// failing skill, in manifest order.
+type ResolveErrors []error
+
+func (e ResolveErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d skills failed to resolve:", len(e))
+	for _, err := range e {
+		b.WriteString("\n  - ")
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+func (e ResolveErrors) Unwrap() []error {
+	return e
+}
+
+// NewDefaultResolver builds a Resolver using the registries from
+// ~/.adl/registries.yaml and ADL_SKILLS_REGISTRY (or the hardcoded
+// default) and the user's home cache directory.
 func NewDefaultResolver() (*Resolver, error) {
-	base := os.Getenv(EnvBaseURL)
+	regs, err := DefaultRegistries()
+	if err != nil {
+		return nil, err
+	}
 	cache, err := NewCache("")
 	if err != nil {
 		return nil, err
 	}
+	var ttl time.Duration
+	if env := os.Getenv(EnvCacheTTL); env != "" {
+		if ttl, err = time.ParseDuration(env); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", EnvCacheTTL, err)
+		}
+		if ttl == 0 {
+			ttl = -1
+		}
+	}
 	return &Resolver{
-		Client:    NewClient(base),
+		Client:    NewRegistryClient(regs),
 		Installer: NewInstaller(),
 		Cache:     cache,
+		CacheTTL:  ttl,
 	}, nil
 }
 
@@ -103,7 +181,10 @@ func (r *Resolver) Resolve(ctx context.Context, skill schema.Skill) (*ResolvedSk
 		tags = skill.Tags
 	}
 	version := doc.Frontmatter.Version
-	if skill.Version != "" {
+	switch {
+	case IsVersionRange(skill.Version):
+		version = cacheRef
+	case skill.Version != "":
 		version = skill.Version
 	}
 	if version == "" {
@@ -121,69 +202,317 @@ func (r *Resolver) Resolve(ctx context.Context, skill schema.Skill) (*ResolvedSk
 		Tags:        tags,
 		Version:     version,
 		License:     license,
+		Ref:         cacheRef,
 		Files:       files,
 	}, nil
 }
 
 // loadFiles returns the cached or freshly fetched files for a non-bare
 // skill, along with the cache ref used (either skill.Version or, for
-// source-pulled skills, the GitHub ref from the URL).
+// source-pulled skills, the ref of the source). Local directories are
+// read directly, bypassing the cache and the lock.
 func (r *Resolver) loadFiles(ctx context.Context, skill schema.Skill) (string, map[string][]byte, error) {
-	if skill.Source != "" {
-		expanded := ExpandShorthand(skill.Source)
-		loc, err := ParseGitHubTreeURL(expanded)
-		if err != nil {
-			return "", nil, fmt.Errorf("skill %q: %w", skill.ID, err)
+	loc, err := r.Locate(skill)
+	if err != nil {
+		return "", nil, err
+	}
+	ref := skill.Version
+	if loc != nil {
+		if loc.Local {
+			files, err := loc.fetcher.Fetch(ctx, loc)
+			if err != nil {
+				return "", nil, fmt.Errorf("skill %q: %w", skill.ID, err)
+			}
+			return "", files, nil
+		}
+		ref = loc.Ref
+	}
+
+	var entry LockedSkill
+	locked := false
+	if r.Lock != nil && !r.UpdateLock {
+		entry, locked = r.Lock.Skill(skill.ID)
+		if locked && entry.Declared != declaredAs(skill) {
+			return "", nil, fmt.Errorf("skill %q is locked as %s but declared as %s; run 'adl skills update %s' to update %s",
+				skill.ID, entry.Declared, declaredAs(skill), skill.ID, LockFile)
+		}
+	}
+
+	if loc == nil && IsVersionRange(skill.Version) {
+		if ref, err = r.pickVersion(ctx, skill, entry, locked); err != nil {
+			return "", nil, err
 		}
-		ref := loc.Ref
-		if cached, ok, err := r.Cache.Get(skill.ID, ref); err != nil {
+	}
+
+	// A skill entering the lock is fetched afresh so the lock records the
+	// commit its files actually came from. Refresh bypasses cached moving
+	// refs.
+	useCache := (r.Lock == nil || locked || r.Offline) && !(r.Refresh && isMovingRef(ref))
+	var stale error
+	var cached map[string][]byte
+	var cachedEntry *CacheEntry
+	if useCache {
+		var ok bool
+		cached, ok, err = r.Cache.Get(skill.ID, ref)
+		if err != nil {
 			return "", nil, err
-		} else if ok {
+		}
+		if ok {
+			if locked {
+				if stale = entry.verify(cached); stale == nil {
+					return ref, cached, nil
+				}
+			} else {
+				fresh, e, err := r.fresh(skill.ID, ref)
+				if err != nil {
+					return "", nil, err
+				}
+				if fresh {
+					r.lock(skill, loc, r.Client, ref, "", cached)
+					return ref, cached, nil
+				}
+				cachedEntry = e
+			}
+		}
+	}
+	if r.Offline {
+		if stale != nil {
+			return "", nil, fmt.Errorf("cached skill %q does not match %s (%v) and --offline is set", skill.ID, LockFile, stale)
+		}
+		return "", nil, fmt.Errorf("skill %q is not cached and --offline is set", skill.ID)
+	}
+
+	// An expired cache entry of a moving ref is revalidated: it is kept
+	// when the ref still resolves to the commit it was fetched at, or the
+	// registry confirms its ETag.
+	var files map[string][]byte
+	var served *Client
+	commit, revision := "", ""
+	if loc != nil {
+		if r.Lock != nil || isMovingRef(loc.Ref) {
+			commit = entry.Commit
+			if commit == "" {
+				if commit, err = loc.fetcher.Commit(ctx, loc); err != nil {
+					return "", nil, fmt.Errorf("skill %q: %w", skill.ID, err)
+				}
+			}
+		}
+		if cachedEntry != nil && commit != "" && commit == cachedEntry.Revision {
+			if err := r.Cache.Touch(skill.ID, ref); err != nil {
+				return "", nil, err
+			}
+			r.lock(skill, loc, r.Client, ref, commit, cached)
 			return ref, cached, nil
 		}
-		if r.Offline {
-			return "", nil, fmt.Errorf("skill %q is not cached and --offline is set", skill.ID)
+		at := *loc
+		if commit != "" {
+			at.Ref = commit
 		}
-		files, err := r.Installer.Fetch(ctx, loc)
+		files, err = loc.fetcher.Fetch(ctx, &at)
 		if err != nil {
 			return "", nil, fmt.Errorf("skill %q: %w", skill.ID, err)
 		}
-		if err := r.Cache.Put(skill.ID, ref, files); err != nil {
+		revision = commit
+	} else {
+		version := ref
+		if locked && version == "" {
+			version = entry.Version
+		}
+		etag := ""
+		if cachedEntry != nil {
+			etag = cachedEntry.Revision
+		}
+		fetched, err := r.Client.Fetch(ctx, skill.ID, version, etag)
+		if err != nil {
 			return "", nil, err
 		}
-		return ref, files, nil
+		if fetched.NotModified {
+			if err := r.Cache.Touch(skill.ID, ref); err != nil {
+				return "", nil, err
+			}
+			r.lock(skill, loc, fetched.Registry, ref, "", cached)
+			return ref, cached, nil
+		}
+		files = map[string][]byte{SkillFile: fetched.Body}
+		served, revision = fetched.Registry, fetched.ETag
 	}
 
-	ref := skill.Version
-	if cached, ok, err := r.Cache.Get(skill.ID, ref); err != nil {
+	if locked {
+		if err := entry.verify(files); err != nil {
+			return "", nil, fmt.Errorf("skill %q does not match %s: %v; run 'adl skills update %s' to accept the new content",
+				skill.ID, LockFile, err, skill.ID)
+		}
+	} else {
+		r.lock(skill, loc, served, ref, commit, files)
+	}
+	if err := r.Cache.PutRevision(skill.ID, ref, revision, files); err != nil {
 		return "", nil, err
-	} else if ok {
-		return ref, cached, nil
 	}
+	return ref, files, nil
+}
+
+// fresh reports whether a cache entry can be used without asking
+// upstream: its ref is immutable (a release or commit), the resolver is
+// offline, or it was fetched or revalidated within CacheTTL. Otherwise
+// it also returns the entry to revalidate.
+func (r *Resolver) fresh(id, ref string) (bool, *CacheEntry, error) {
+	if r.Offline || !isMovingRef(ref) {
+		return true, nil, nil
+	}
+	entry, ok, err := r.Cache.Entry(id, ref)
+	if err != nil || !ok {
+		return false, nil, err
+	}
+	ttl := r.CacheTTL
+	if ttl == 0 {
+		ttl = DefaultCacheTTL
+	}
+	if ttl > 0 && time.Since(entry.FetchedAt) < ttl {
+		return true, nil, nil
+	}
+	return false, entry, nil
+}
+
+// isMovingRef reports whether ref can point at different content over
+// time: a branch, HEAD or a registry skill's default version, as
+// opposed to a release version or commit SHA.
+func isMovingRef(ref string) bool {
+	if ref == "" || ref == "latest" {
+		return true
+	}
+	_, release := parseVersion(ref)
+	return !release && !commitSHARe.MatchString(ref)
+}
+
+// lock records files as the locked content of skill, when locking is
+// enabled. Registry skills record the URL of the registry that served
+// them and, for version ranges, the version ref the range resolved to.
+func (r *Resolver) lock(skill schema.Skill, loc *SourceLocation, served *Client, ref, commit string, files map[string][]byte) {
+	if r.Lock == nil {
+		return
+	}
+	entry := LockedSkill{
+		ID:       skill.ID,
+		Declared: declaredAs(skill),
+		Commit:   commit,
+		SHA256:   digestFiles(files),
+	}
+	if doc, err := ParseSkillDocument(files[SkillFile]); err == nil {
+		entry.Version = doc.Frontmatter.Version
+	}
+	if loc != nil {
+		pinned := *loc
+		if commit != "" {
+			pinned.Ref = commit
+		}
+		entry.Resolved = loc.fetcher.URL(&pinned)
+	} else {
+		if IsVersionRange(skill.Version) {
+			entry.Version = ref
+		}
+		version := ref
+		if version == "" {
+			version = entry.Version
+		}
+		entry.Resolved, _ = served.SkillURL(skill.ID, version)
+	}
+	r.Lock.Set(entry)
+}
+
+// Cached returns the cache entry of a non-bare skill without fetching
+// anything. The boolean is false when the skill is not cached; local
+// directories are never cached.
+func (r *Resolver) Cached(skill schema.Skill) (map[string][]byte, bool, error) {
+	loc, err := r.Locate(skill)
+	if err != nil {
+		return nil, false, err
+	}
+	ref := skill.Version
+	if loc != nil {
+		if loc.Local {
+			return nil, false, nil
+		}
+		ref = loc.Ref
+	} else if IsVersionRange(ref) {
+		c, err := ParseConstraint(ref)
+		if err != nil {
+			return nil, false, fmt.Errorf("skill %q: %w", skill.ID, err)
+		}
+		refs, err := r.Cache.Refs(skill.ID)
+		if err != nil {
+			return nil, false, err
+		}
+		if ref = c.Best(refs); ref == "" {
+			return nil, false, nil
+		}
+	}
+	return r.Cache.Get(skill.ID, ref)
+}
+
+// pickVersion resolves the version range of a registry skill to the
+// newest version it allows: the locked version while it still satisfies
+// the range, else the newest the registry index lists (or, offline, the
+// newest cached one).
+func (r *Resolver) pickVersion(ctx context.Context, skill schema.Skill, entry LockedSkill, locked bool) (string, error) {
+	c, err := ParseConstraint(skill.Version)
+	if err != nil {
+		return "", fmt.Errorf("skill %q: %w", skill.ID, err)
+	}
+	if locked && c.Allows(entry.Version) {
+		return entry.Version, nil
+	}
+
+	var versions []string
+	where := "the registry"
 	if r.Offline {
-		return "", nil, fmt.Errorf("skill %q is not cached and --offline is set", skill.ID)
+		versions, err = r.Cache.Refs(skill.ID)
+		where = "the skills cache"
+	} else {
+		versions, err = r.Client.Versions(ctx, skill.ID)
 	}
-	body, err := r.Client.FetchByID(ctx, skill.ID, skill.Version)
 	if err != nil {
-		return "", nil, err
+		return "", fmt.Errorf("skill %q: %w", skill.ID, err)
 	}
-	files := map[string][]byte{SkillFile: body}
-	if err := r.Cache.Put(skill.ID, ref, files); err != nil {
-		return "", nil, err
+	best := c.Best(versions)
+	if best == "" {
+		available := strings.Join(versions, ", ")
+		if available == "" {
+			available = "none"
+		}
+		return "", fmt.Errorf("no version of skill %q in %s satisfies %s (available: %s)", skill.ID, where, c, available)
 	}
-	return ref, files, nil
+	return best, nil
 }
 
-// ResolveAll resolves every skill in skills, returning the resolved
-// entries in the same order. Resolution fails fast on the first error.
+// ResolveAll resolves every skill in skills concurrently, returning the
+// resolved entries in the same order. Every skill is attempted; when any
+// fail, the returned error is a ResolveErrors listing all of them.
 func (r *Resolver) ResolveAll(ctx context.Context, skills []schema.Skill) ([]*ResolvedSkill, error) {
-	resolved := make([]*ResolvedSkill, 0, len(skills))
-	for _, skill := range skills {
-		rs, err := r.Resolve(ctx, skill)
+	limit := r.Concurrency
+	if limit <= 0 {
+		limit = DefaultConcurrency
+	}
+	resolved := make([]*ResolvedSkill, len(skills))
+	errs := make([]error, len(skills))
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for n, skill := range skills {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			resolved[n], errs[n] = r.Resolve(ctx, skill)
+		})
+	}
+	wg.Wait()
+
+	var failed ResolveErrors
+	for _, err := range errs {
 		if err != nil {
-			return nil, err
+			failed = append(failed, err)
 		}
-		resolved = append(resolved, rs)
+	}
+	if len(failed) > 0 {
+		return nil, failed
 	}
 	return resolved, nil
 }
@@ -3,10 +3,13 @@ package registry
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/inference-gateway/adl-cli/internal/schema"
 )
@@ -161,8 +164,14 @@ func TestResolver_SourceFetchesGitHubDirectory(t *testing.T) {
 		"skills/skill-creator/scripts/hello.sh": "#!/bin/sh\necho hi\n",
 	}
 
+	// main is a moving ref: it is resolved to a commit, and the tree is
+	// fetched at that commit.
+	const head = "0123456789abcdef0123456789abcdef01234567"
 	mux := http.NewServeMux()
-	mux.HandleFunc("/repos/acme/skills/git/trees/main", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc("/repos/acme/skills/commits/main", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(head))
+	})
+	mux.HandleFunc("/repos/acme/skills/git/trees/"+head, func(w http.ResponseWriter, r *http.Request) {
 		resp := treeResponse{
 			Tree: []treeEntry{
 				{Path: "skills/skill-creator", Type: "tree"},
@@ -234,6 +243,88 @@ func TestResolver_SourceFetchesGitHubDirectory(t *testing.T) {
 	if _, ok, err := resolver.Cache.Get("skill-creator", "main"); err != nil || !ok {
 		t.Errorf("expected cache to be populated after Resolve, got ok=%v err=%v", ok, err)
 	}
+	if entry, _, _ := resolver.Cache.Entry("skill-creator", "main"); entry == nil || entry.Revision != head {
+		t.Errorf("expected the cache entry to record commit %s, got %+v", head, entry)
+	}
+}
+
+// waitForPeak blocks until peak reaches want (or a generous deadline
+// passes), so a handler can prove that requests overlap.
+func waitForPeak(inFlight, peak *atomic.Int32, want int32) {
+	n := inFlight.Add(1)
+	for {
+		p := peak.Load()
+		if n <= p || peak.CompareAndSwap(p, n) {
+			break
+		}
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for peak.Load() < want && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	inFlight.Add(-1)
+}
+
+func TestResolver_ResolveAllConcurrent(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	resolver, closer := newTestResolver(t, func(w http.ResponseWriter, r *http.Request) {
+		waitForPeak(&inFlight, &peak, 3)
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/skills/"), ".md")
+		_, _ = w.Write([]byte("---\nname: " + id + "\ndescription: " + id + "\n---\nbody\n"))
+	})
+	defer closer()
+	resolver.Concurrency = 3
+
+	ids := []string{"a", "b", "c", "d", "e", "f"}
+	skills := make([]schema.Skill, len(ids))
+	for n, id := range ids {
+		skills[n] = schema.Skill{ID: id}
+	}
+	resolved, err := resolver.ResolveAll(context.Background(), skills)
+	if err != nil {
+		t.Fatalf("ResolveAll: %v", err)
+	}
+	for n, rs := range resolved {
+		if rs.ID != ids[n] || rs.Description != ids[n] {
+			t.Errorf("resolved[%d] = %s (%q), want %s", n, rs.ID, rs.Description, ids[n])
+		}
+	}
+	if got := peak.Load(); got != 3 {
+		t.Errorf("expected 3 concurrent registry requests, got %d", got)
+	}
+}
+
+func TestResolver_ResolveAllAggregatesErrors(t *testing.T) {
+	resolver, closer := newTestResolver(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "missing") {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("---\nname: ok\ndescription: ok\n---\nbody\n"))
+	})
+	defer closer()
+
+	_, err := resolver.ResolveAll(context.Background(), []schema.Skill{
+		{ID: "missing-one"},
+		{ID: "ok"},
+		{ID: "broken", Bare: true},
+		{ID: "missing-two"},
+	})
+	var failed ResolveErrors
+	if !errors.As(err, &failed) {
+		t.Fatalf("expected ResolveErrors, got %v", err)
+	}
+	if len(failed) != 3 {
+		t.Fatalf("expected every failing skill to be reported, got %d: %v", len(failed), err)
+	}
+	msg := err.Error()
+	if !strings.HasPrefix(msg, "3 skills failed to resolve:") {
+		t.Errorf("unexpected summary line: %s", msg)
+	}
+	one, broken, two := strings.Index(msg, "missing-one"), strings.Index(msg, `"broken"`), strings.Index(msg, "missing-two")
+	if one < 0 || broken < 0 || two < 0 || !(one < broken && broken < two) {
+		t.Errorf("expected failures in manifest order:\n%s", msg)
+	}
 }
 
 func keysOf(m map[string][]byte) []string {
@@ -243,3 +334,55 @@ func keysOf(m map[string][]byte) []string {
 	}
 	return keys
 }
+
+func TestResolver_RevalidatesMovingRefs(t *testing.T) {
+	var requests []string
+	etag, body := `"v1"`, "first"
+	resolver, closer := newTestResolver(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path+" "+r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte("---\nname: data-analysis\ndescription: " + body + "\n---\n"))
+	})
+	defer closer()
+	skill := schema.Skill{ID: "data-analysis"}
+	resolve := func() string {
+		t.Helper()
+		resolved, err := resolver.Resolve(context.Background(), skill)
+		if err != nil {
+			t.Fatalf("Resolve: %v", err)
+		}
+		return resolved.Description
+	}
+
+	// Within the TTL the cache is used as is.
+	if resolve() != "first" || resolve() != "first" || len(requests) != 1 {
+		t.Fatalf("expected one fetch within the TTL, got %v", requests)
+	}
+
+	// Once expired, the entry is revalidated with its ETag.
+	resolver.CacheTTL = -1
+	if resolve() != "first" || len(requests) != 2 || requests[1] != `/skills/data-analysis.md "v1"` {
+		t.Fatalf("expected a conditional request, got %v", requests)
+	}
+	etag, body = `"v2"`, "second"
+	if resolve() != "second" || len(requests) != 3 {
+		t.Fatalf("expected the changed skill to be fetched again, got %v", requests)
+	}
+
+	// Refresh bypasses the cache for moving refs only.
+	resolver.CacheTTL, resolver.Refresh = time.Hour, true
+	resolve()
+	if len(requests) != 4 || requests[3] != "/skills/data-analysis.md " {
+		t.Fatalf("expected an unconditional fetch on refresh, got %v", requests)
+	}
+	skill.Version = "1.0.0"
+	resolve()
+	resolve()
+	if len(requests) != 5 {
+		t.Fatalf("expected a pinned release to stay cached on refresh, got %v", requests)
+	}
+}
@@ -0,0 +1,172 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// defaultMaxRetries is how often a GitHub request is retried after a
+	// 429, a 5xx or a rate limit when Installer.MaxRetries is zero.
+	defaultMaxRetries = 4
+	// defaultMaxWait caps a single wait for Retry-After or a rate-limit
+	// reset when Installer.MaxWait is zero. Longer waits fail instead.
+	defaultMaxWait = time.Minute
+	// retryBaseDelay is the first backoff delay; it doubles per attempt.
+	retryBaseDelay = time.Second
+	retryMaxDelay  = 30 * time.Second
+)
+
+// get sends an authenticated GET to the GitHub API or raw host. Requests
+// that fail with 429, a 5xx, a network error or a rate limit are retried
+// with exponential backoff, honouring Retry-After and X-RateLimit-Reset.
+// Any other response is returned for the caller to interpret.
+func (i *Installer) get(ctx context.Context, target, accept string) (*http.Response, error) {
+	maxRetries := i.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = defaultMaxRetries
+	}
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request for %s: %w", target, err)
+		}
+		req.Header.Set("User-Agent", installerUA)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		if i.Token != "" {
+			req.Header.Set("Authorization", "Bearer "+i.Token)
+		}
+
+		resp, err := i.Client.Do(req)
+		var wait time.Duration
+		if err != nil {
+			if ctx.Err() != nil || attempt >= maxRetries {
+				return nil, err
+			}
+			wait = backoff(attempt)
+		} else {
+			var retry bool
+			if wait, retry = i.retryDelay(resp, attempt); !retry {
+				return resp, nil
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+			if limited(resp) && (attempt >= maxRetries || wait > i.maxWait()) {
+				return nil, i.rateLimitError(resp)
+			}
+			if attempt >= maxRetries {
+				return nil, fmt.Errorf("GitHub returned %s for %s after %d attempts", resp.Status, target, attempt+1)
+			}
+			if wait > i.maxWait() {
+				return nil, fmt.Errorf("GitHub returned %s for %s and asked to retry in %s; try again later", resp.Status, target, wait.Round(time.Second))
+			}
+		}
+
+		if err := i.wait(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryDelay reports whether resp should be retried and after how long.
+func (i *Installer) retryDelay(resp *http.Response, attempt int) (time.Duration, bool) {
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode >= http.StatusInternalServerError && resp.StatusCode != http.StatusNotImplemented,
+		limited(resp):
+	default:
+		return 0, false
+	}
+	if after, ok := retryAfter(resp); ok {
+		return after, true
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, ok := rateLimitReset(resp); ok {
+			return max(time.Until(reset), 0) + time.Second, true
+		}
+	}
+	return backoff(attempt), true
+}
+
+// limited reports whether resp is GitHub refusing a request because a
+// primary or secondary rate limit is exhausted (403 or 429 with the
+// rate-limit headers), as opposed to a permission error.
+func limited(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+	return resp.Header.Get("X-RateLimit-Remaining") == "0" || resp.Header.Get("Retry-After") != ""
+}
+
+// rateLimitError explains an exhausted rate limit: when it resets and,
+// for anonymous requests, how to raise it.
+func (i *Installer) rateLimitError(resp *http.Response) error {
+	msg := "GitHub API rate limit exhausted"
+	if limit := resp.Header.Get("X-RateLimit-Limit"); limit != "" {
+		msg += fmt.Sprintf(" (%s requests/hour)", limit)
+	}
+	if reset, ok := rateLimitReset(resp); ok {
+		msg += fmt.Sprintf("; it resets at %s (in %s)", reset.Local().Format("15:04:05"), max(time.Until(reset), 0).Round(time.Second))
+	} else if after, ok := retryAfter(resp); ok {
+		msg += fmt.Sprintf("; retry in %s", after.Round(time.Second))
+	}
+	if i.Token == "" {
+		msg += "; set GITHUB_TOKEN or GH_TOKEN to raise the limit to 5,000 requests/hour"
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+func (i *Installer) maxWait() time.Duration {
+	if i.MaxWait > 0 {
+		return i.MaxWait
+	}
+	return defaultMaxWait
+}
+
+func (i *Installer) wait(ctx context.Context, d time.Duration) error {
+	if i.sleep != nil {
+		return i.sleep(ctx, d)
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func backoff(attempt int) time.Duration {
+	return min(retryBaseDelay<<attempt, retryMaxDelay)
+}
+
+// retryAfter parses a Retry-After header, in seconds or as an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// rateLimitReset parses X-RateLimit-Reset (Unix seconds).
+func rateLimitReset(resp *http.Response) (time.Time, bool) {
+	secs, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(secs, 0), true
+}
@@ -0,0 +1,185 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordSleeps makes inst return immediately from retry waits, recording
+// each requested delay.
+func recordSleeps(inst *Installer) *[]time.Duration {
+	var waits []time.Duration
+	inst.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	return &waits
+}
+
+func TestInstaller_RetriesServerErrorsWithBackoff(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls <= 2 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("v1.0.0\n"))
+	}))
+	defer srv.Close()
+
+	inst := &Installer{Client: srv.Client(), APIBase: srv.URL}
+	waits := recordSleeps(inst)
+	sha, err := inst.Commit(context.Background(), &GitHubLocation{Owner: "acme", Repo: "skills", Ref: "main"})
+	if err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if sha != "v1.0.0" || calls != 3 {
+		t.Errorf("expected success on the third attempt, got %q after %d calls", sha, calls)
+	}
+	if want := []time.Duration{time.Second, 2 * time.Second}; !slices.Equal(*waits, want) {
+		t.Errorf("expected exponential backoff %v, got %v", want, *waits)
+	}
+}
+
+func TestInstaller_HonoursRetryAfter(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_ = json.NewEncoder(w).Encode([]tagEntry{{Name: "v1.0.0"}})
+	}))
+	defer srv.Close()
+
+	inst := &Installer{Client: srv.Client(), APIBase: srv.URL}
+	waits := recordSleeps(inst)
+	tags, err := inst.Tags(context.Background(), "acme", "skills")
+	if err != nil {
+		t.Fatalf("Tags: %v", err)
+	}
+	if len(tags) != 1 || !slices.Equal(*waits, []time.Duration{7 * time.Second}) {
+		t.Errorf("expected one retry after 7s, got tags=%v waits=%v", tags, *waits)
+	}
+}
+
+func TestInstaller_WaitsForShortRateLimitReset(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(10*time.Second).Unix(), 10))
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte("sha\n"))
+	}))
+	defer srv.Close()
+
+	inst := &Installer{Client: srv.Client(), APIBase: srv.URL}
+	waits := recordSleeps(inst)
+	if _, err := inst.Commit(context.Background(), &GitHubLocation{Owner: "acme", Repo: "skills", Ref: "main"}); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if len(*waits) != 1 || (*waits)[0] < 5*time.Second || (*waits)[0] > 12*time.Second {
+		t.Errorf("expected to wait until the reset, got %v", *waits)
+	}
+}
+
+func TestInstaller_ExhaustedRateLimit(t *testing.T) {
+	reset := time.Now().Add(42 * time.Minute)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "60")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	inst := &Installer{Client: srv.Client(), APIBase: srv.URL}
+	waits := recordSleeps(inst)
+	_, err := inst.Fetch(context.Background(), &GitHubLocation{Owner: "acme", Repo: "skills", Ref: "main", Path: "skills/foo"})
+	if err == nil {
+		t.Fatal("expected a rate-limit error")
+	}
+	for _, want := range []string{"rate limit exhausted (60 requests/hour)", "resets at " + reset.Local().Format("15:04:05"), "GITHUB_TOKEN or GH_TOKEN"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+	if len(*waits) != 0 {
+		t.Errorf("a reset beyond MaxWait must fail without waiting, got %v", *waits)
+	}
+}
+
+func TestInstaller_ForbiddenIsNotRetried(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	inst := &Installer{Client: srv.Client(), APIBase: srv.URL, Token: "secret"}
+	recordSleeps(inst)
+	_, err := inst.Tags(context.Background(), "acme", "private")
+	if err == nil || !strings.Contains(err.Error(), "access to acme/private denied") || strings.Contains(err.Error(), "GH_TOKEN") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("a permission error must not be retried, got %d calls", calls)
+	}
+}
+
+func TestInstaller_TokenAndEnterpriseContentsAPI(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/acme/skills/git/trees/main", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(treeResponse{Tree: []treeEntry{{Path: "skills/foo/SKILL.md", Type: "blob"}}})
+	})
+	mux.HandleFunc("/api/v3/repos/acme/skills/contents/skills/foo/SKILL.md", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("ref") != "main" || r.Header.Get("Accept") != "application/vnd.github.raw" {
+			t.Errorf("unexpected contents request: %s (Accept %q)", r.URL, r.Header.Get("Accept"))
+		}
+		_, _ = w.Write([]byte("skill body\n"))
+	})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer ghp_test" {
+			t.Errorf("%s: Authorization = %q", r.URL.Path, got)
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "ghp_test")
+	t.Setenv(EnvGitHubAPI, srv.URL+"/api/v3/")
+	inst := NewInstaller()
+	if inst.Token != "ghp_test" || inst.APIBase != srv.URL+"/api/v3" || inst.RawBase != "" {
+		t.Fatalf("NewInstaller did not pick up the environment: %+v", inst)
+	}
+	inst.Client = srv.Client()
+
+	files, err := inst.Fetch(context.Background(), &GitHubLocation{Owner: "acme", Repo: "skills", Ref: "main", Path: "skills/foo"})
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if string(files["SKILL.md"]) != "skill body\n" {
+		t.Errorf("unexpected files: %v", files)
+	}
+
+	t.Setenv(EnvGitHubAPI, "https://ghe.acme.dev/api/v3")
+	if _, err := ParseGitHubTreeURL("https://ghe.acme.dev/acme/skills/tree/main/skills/foo"); err != nil {
+		t.Errorf("expected Enterprise tree URLs to be accepted: %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package registry
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Constraint is a semver range a registry skill's `version:` can declare
+// instead of an exact version. It is a set of alternatives separated by
+// "||", each a space-separated list of comparisons that must all hold:
+//
+//	^1.2       >=1.2.0 <2.0.0 (below 1.0.0, the minor version is fixed: ^0.4 is <0.5.0)
+//	~0.4.0     >=0.4.0 <0.5.0 (~1 is <2.0.0)
+//	1.2.x, 1.* >=1.2.0 <1.3.0, >=1.0.0 <2.0.0
+//	>=1.0 <2.0 explicit bounds, with >, >=, <, <= and =
+//	*          any release
+//
+// Missing minor and patch numbers count as zero.
+type Constraint struct {
+	raw  string
+	sets [][]comparison
+}
+
+type comparison struct {
+	op string
+	v  [3]int
+}
+
+// IsVersionRange reports whether a skill version is a range to resolve
+// against the registry's versions rather than an exact version.
+func IsVersionRange(version string) bool {
+	return strings.ContainsAny(version, "^~<>=*| ") || slices.ContainsFunc(strings.Split(version, "."), isWildcard)
+}
+
+// ParseConstraint parses a semver range.
+func ParseConstraint(s string) (*Constraint, error) {
+	c := &Constraint{raw: s}
+	for alt := range strings.SplitSeq(s, "||") {
+		var set []comparison
+		fields := strings.Fields(alt)
+		for i := 0; i < len(fields); i++ {
+			term := fields[i]
+			// Allow a space between an operator and its version (">= 1.0").
+			if strings.Trim(term, "<>=^~") == "" && i+1 < len(fields) {
+				i++
+				term += fields[i]
+			}
+			cmps, err := parseTerm(term)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version range %q: %w", s, err)
+			}
+			set = append(set, cmps...)
+		}
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("invalid version range %q: empty alternative", s)
+		}
+		c.sets = append(c.sets, set)
+	}
+	return c, nil
+}
+
+// parseTerm expands one term of a range into the comparisons it means.
+func parseTerm(term string) ([]comparison, error) {
+	rest := strings.TrimLeft(term, "<>=^~")
+	op := term[:len(term)-len(rest)]
+	v, n, err := parsePartial(rest)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 && op != "" {
+		return nil, fmt.Errorf("%s needs a version", op)
+	}
+	switch op {
+	case "^":
+		switch {
+		case v[0] > 0 || n == 1:
+			return []comparison{{">=", v}, {"<", [3]int{v[0] + 1, 0, 0}}}, nil
+		case v[1] > 0 || n == 2:
+			return []comparison{{">=", v}, {"<", [3]int{0, v[1] + 1, 0}}}, nil
+		}
+		return []comparison{{">=", v}, {"<", [3]int{0, 0, v[2] + 1}}}, nil
+	case "~":
+		return []comparison{{">=", v}, {"<", next(v, min(n, 2))}}, nil
+	case "", "=":
+		switch n {
+		case 0:
+			return nil, nil
+		case 3:
+			return []comparison{{"=", v}}, nil
+		}
+		return []comparison{{">=", v}, {"<", next(v, n)}}, nil
+	case ">", "<=":
+		// >1.2 excludes all of 1.2.x, <=1.2 includes it.
+		if n < 3 {
+			if op == ">" {
+				return []comparison{{">=", next(v, n)}}, nil
+			}
+			return []comparison{{"<", next(v, n)}}, nil
+		}
+		return []comparison{{op, v}}, nil
+	case ">=", "<":
+		return []comparison{{op, v}}, nil
+	}
+	return nil, fmt.Errorf("unknown operator %q", op)
+}
+
+// next returns the first version after every version that starts with
+// the first n parts of v.
+func next(v [3]int, n int) [3]int {
+	switch n {
+	case 1:
+		return [3]int{v[0] + 1, 0, 0}
+	case 2:
+		return [3]int{v[0], v[1] + 1, 0}
+	}
+	return [3]int{v[0], v[1], v[2] + 1}
+}
+
+// parsePartial parses a version whose trailing parts may be missing or
+// wildcards, returning how many parts were given.
+func parsePartial(s string) ([3]int, int, error) {
+	var v [3]int
+	s = strings.TrimPrefix(s, "v")
+	if s == "" || isWildcard(s) {
+		return v, 0, nil
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return v, 0, fmt.Errorf("%q is not a version", s)
+	}
+	for i, p := range parts {
+		if isWildcard(p) {
+			return v, i, nil
+		}
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, 0, fmt.Errorf("%q is not a version", s)
+		}
+		v[i] = n
+	}
+	return v, len(parts), nil
+}
+
+func isWildcard(s string) bool {
+	return s == "x" || s == "X" || s == "*"
+}
+
+// Allows reports whether version satisfies the constraint. Versions that
+// are not MAJOR.MINOR.PATCH never do.
+func (c *Constraint) Allows(version string) bool {
+	v, ok := parseVersion(version)
+	if !ok {
+		return false
+	}
+	for _, set := range c.sets {
+		if !slices.ContainsFunc(set, func(cmp comparison) bool { return !cmp.holds(v) }) {
+			return true
+		}
+	}
+	return false
+}
+
+func (cmp comparison) holds(v [3]int) bool {
+	d := compareVersions(v, cmp.v)
+	switch cmp.op {
+	case ">":
+		return d > 0
+	case ">=":
+		return d >= 0
+	case "<":
+		return d < 0
+	case "<=":
+		return d <= 0
+	}
+	return d == 0
+}
+
+// Best returns the newest of versions the constraint allows, or "".
+func (c *Constraint) Best(versions []string) string {
+	return newestVersion(versions, c.Allows)
+}
+
+func (c *Constraint) String() string { return c.raw }
+
+// newestVersion returns the highest MAJOR.MINOR.PATCH version among
+// versions that keep accepts, or "".
+func newestVersion(versions []string, keep func(string) bool) string {
+	best, bestV := "", [3]int{-1}
+	for _, s := range versions {
+		v, ok := parseVersion(s)
+		if ok && keep(s) && compareVersions(v, bestV) > 0 {
+			best, bestV = s, v
+		}
+	}
+	return best
+}
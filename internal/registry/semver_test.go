@@ -0,0 +1,112 @@
+package registry
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+)
+
+func TestConstraint(t *testing.T) {
+	versions := []string{"0.4.0", "0.4.7", "0.5.0", "1.0.0", "1.2.0", "1.2.9", "1.9.3", "2.0.0", "2.1.0-rc.1", "latest"}
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{"^1.2", "1.9.3"},
+		{"^1.2.5", "1.9.3"},
+		{"^0.4", "0.4.7"},
+		{"~0.4.0", "0.4.7"},
+		{"~1", "1.9.3"},
+		{"~1.2", "1.2.9"},
+		{">=1.0 <2.0", "1.9.3"},
+		{">= 1.0, <1.2", ""},
+		{">1.2 <2", "1.9.3"},
+		{"<=1.2", "1.2.9"},
+		{"<1.0.0 || ^2", "2.0.0"},
+		{"1.2.x", "1.2.9"},
+		{"1.*", "1.9.3"},
+		{"*", "2.0.0"},
+		{"^3", ""},
+	}
+	for _, tt := range tests {
+		c, err := ParseConstraint(tt.constraint)
+		if err != nil {
+			if tt.want != "" {
+				t.Errorf("ParseConstraint(%q): %v", tt.constraint, err)
+			}
+			continue
+		}
+		if got := c.Best(versions); got != tt.want {
+			t.Errorf("%q: Best = %q, want %q", tt.constraint, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"^", ">=", "^1.2.3.4", "~abc", "1.0 ||"} {
+		if _, err := ParseConstraint(bad); err == nil {
+			t.Errorf("ParseConstraint(%q): expected an error", bad)
+		}
+	}
+	for version, want := range map[string]bool{"1.2.3": false, "latest": false, "": false, "^1.2": true, "1.x": true, ">=1.0 <2.0": true} {
+		if got := IsVersionRange(version); got != want {
+			t.Errorf("IsVersionRange(%q) = %v, want %v", version, got, want)
+		}
+	}
+}
+
+func TestResolver_VersionRange(t *testing.T) {
+	versions := `"1.1.0", "1.2.0", "1.3.0", "2.0.0"`
+	resolver, closer := newTestResolver(t, func(w http.ResponseWriter, r *http.Request) {
+		switch path := strings.TrimPrefix(r.URL.Path, "/skills/"); path {
+		case IndexFile:
+			_, _ = w.Write([]byte(`{"skills": [{"id": "data-analysis", "versions": [` + versions + `]}]}`))
+		default:
+			version := strings.TrimSuffix(strings.TrimPrefix(path, "data-analysis/"), ".md")
+			_, _ = w.Write([]byte("---\nname: data-analysis\ndescription: d\nversion: " + version + "\n---\n"))
+		}
+	})
+	defer closer()
+	resolver.Lock = &Lock{}
+	skill := schema.Skill{ID: "data-analysis", Version: "^1.2"}
+
+	resolved, err := resolver.Resolve(context.Background(), skill)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if resolved.Version != "1.3.0" {
+		t.Errorf("expected the newest 1.x release, got %s", resolved.Version)
+	}
+	entry, _ := resolver.Lock.Skill("data-analysis")
+	if entry.Declared != "data-analysis@^1.2" || entry.Version != "1.3.0" || !strings.HasSuffix(entry.Resolved, "/skills/data-analysis/1.3.0.md") {
+		t.Errorf("expected the lock to record the chosen version, got %+v", entry)
+	}
+
+	// A new release in range does not move a locked skill until the lock
+	// is updated.
+	versions += `, "1.4.0"`
+	resolver.Client = NewClient(resolver.Client.BaseURL)
+	if resolved, err = resolver.Resolve(context.Background(), skill); err != nil || resolved.Version != "1.3.0" {
+		t.Errorf("expected the locked 1.3.0, got %+v, %v", resolved, err)
+	}
+	resolver.UpdateLock = true
+	if resolved, err = resolver.Resolve(context.Background(), skill); err != nil || resolved.Version != "1.4.0" {
+		t.Errorf("expected an updated lock to move to 1.4.0, got %+v, %v", resolved, err)
+	}
+
+	// Offline, the newest cached release in range is used.
+	resolver.Offline, resolver.Lock = true, nil
+	if resolved, err = resolver.Resolve(context.Background(), schema.Skill{ID: "data-analysis", Version: "~1.3"}); err != nil || resolved.Version != "1.3.0" {
+		t.Errorf("expected the cached 1.3.0, got %+v, %v", resolved, err)
+	}
+	if _, ok, err := resolver.Cached(skill); !ok || err != nil {
+		t.Errorf("expected a cached release in range, got %v, %v", ok, err)
+	}
+
+	resolver.Offline = false
+	_, err = resolver.Resolve(context.Background(), schema.Skill{ID: "data-analysis", Version: "^3"})
+	if err == nil || !strings.Contains(err.Error(), "satisfies ^3 (available: 1.1.0, 1.2.0, 1.3.0, 2.0.0, 1.4.0)") {
+		t.Errorf("expected an unsatisfiable-range error, got %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+)
+
+// SourceLocation is a parsed spec.skills[].source: a directory inside a
+// repository at a ref, or a directory on disk.
+type SourceLocation struct {
+	// Repo identifies the repository: "owner/repo" on GitHub, or the
+	// clone URL of a git remote. Empty for local directories.
+	Repo string
+	// Ref is the branch, tag or commit the source tracks. Empty for
+	// local directories.
+	Ref string
+	// Path is the skill directory, relative to the repository root or,
+	// for local sources, on disk.
+	Path string
+	// Local is set for directories on disk. They are read on every
+	// resolve and never cached or locked: they are versioned with the
+	// ADL file itself.
+	Local bool
+
+	fetcher SourceFetcher
+}
+
+// SourceFetcher fetches skill directories from one kind of source. The
+// Resolver offers a skill's source to each fetcher in turn - its own
+// Fetchers first, then local directories, generic git remotes and
+// finally GitHub - and the first one that accepts it fetches the skill.
+type SourceFetcher interface {
+	// Parse returns the location source points at. ok is false when the
+	// fetcher does not handle this kind of source.
+	Parse(source string) (loc *SourceLocation, ok bool, err error)
+	// Fetch returns every file under loc.Path at loc.Ref, keyed by path
+	// relative to loc.Path.
+	Fetch(ctx context.Context, loc *SourceLocation) (map[string][]byte, error)
+	// Commit resolves loc.Ref to the commit it currently points at.
+	Commit(ctx context.Context, loc *SourceLocation) (string, error)
+	// Tags lists the tags of loc's repository.
+	Tags(ctx context.Context, loc *SourceLocation) ([]string, error)
+	// URL renders loc as a source string, as recorded in adl.lock.
+	URL(loc *SourceLocation) string
+}
+
+// ParseSource parses a skill source with the built-in fetchers. Relative
+// local paths are returned as written.
+func ParseSource(source string) (*SourceLocation, error) {
+	return (&Resolver{Installer: NewInstaller()}).parseSource(source)
+}
+
+// Locate parses the source of a non-bare skill. It returns nil for
+// registry skills.
+func (r *Resolver) Locate(skill schema.Skill) (*SourceLocation, error) {
+	if skill.Source == "" {
+		return nil, nil
+	}
+	loc, err := r.parseSource(skill.Source)
+	if err != nil {
+		return nil, fmt.Errorf("skill %q: %w", skill.ID, err)
+	}
+	return loc, nil
+}
+
+func (r *Resolver) parseSource(source string) (*SourceLocation, error) {
+	fetchers := append([]SourceFetcher{}, r.Fetchers...)
+	fetchers = append(fetchers, &LocalFetcher{BaseDir: r.BaseDir}, &GitFetcher{}, &githubFetcher{installer: r.Installer})
+	for _, f := range fetchers {
+		loc, ok, err := f.Parse(source)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			loc.fetcher = f
+			return loc, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported skill source %q", source)
+}
+
+// githubFetcher adapts Installer to SourceFetcher. It accepts every
+// source the other fetchers decline, so malformed sources are reported
+// against the GitHub URL grammar.
+type githubFetcher struct {
+	installer *Installer
+}
+
+func (f *githubFetcher) Parse(source string) (*SourceLocation, bool, error) {
+	gh, err := ParseGitHubTreeURL(ExpandShorthand(source))
+	if err != nil {
+		return nil, false, err
+	}
+	return &SourceLocation{Repo: gh.Owner + "/" + gh.Repo, Ref: gh.Ref, Path: gh.Path}, true, nil
+}
+
+func (f *githubFetcher) Fetch(ctx context.Context, loc *SourceLocation) (map[string][]byte, error) {
+	return f.installer.Fetch(ctx, githubLocation(loc))
+}
+
+func (f *githubFetcher) Commit(ctx context.Context, loc *SourceLocation) (string, error) {
+	return f.installer.Commit(ctx, githubLocation(loc))
+}
+
+func (f *githubFetcher) Tags(ctx context.Context, loc *SourceLocation) ([]string, error) {
+	gh := githubLocation(loc)
+	return f.installer.Tags(ctx, gh.Owner, gh.Repo)
+}
+
+func (f *githubFetcher) URL(loc *SourceLocation) string {
+	return fmt.Sprintf("https://github.com/%s/tree/%s/%s", loc.Repo, loc.Ref, loc.Path)
+}
+
+func githubLocation(loc *SourceLocation) *GitHubLocation {
+	owner, repo, _ := strings.Cut(loc.Repo, "/")
+	return &GitHubLocation{Owner: owner, Repo: repo, Ref: loc.Ref, Path: loc.Path}
+}
@@ -0,0 +1,162 @@
+package registry
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+)
+
+func TestParseSource(t *testing.T) {
+	tests := []struct {
+		source string
+		want   SourceLocation
+	}{
+		{"acme/skills/triage@v1.0.0", SourceLocation{Repo: "acme/skills", Ref: "v1.0.0", Path: "skills/triage"}},
+		{"./skills/triage", SourceLocation{Path: "skills/triage", Local: true}},
+		{"file:///srv/skills/triage", SourceLocation{Path: "/srv/skills/triage", Local: true}},
+		{"git+https://gitlab.com/acme/skills.git//skills/triage@v1.2.0", SourceLocation{Repo: "https://gitlab.com/acme/skills.git", Ref: "v1.2.0", Path: "skills/triage"}},
+		{"git+ssh://git@git.acme.dev/ai/skills.git//triage", SourceLocation{Repo: "ssh://git@git.acme.dev/ai/skills.git", Ref: "HEAD", Path: "triage"}},
+		{"git+https://gitea.acme.dev/ai/triage.git@main", SourceLocation{Repo: "https://gitea.acme.dev/ai/triage.git", Ref: "main"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			loc, err := ParseSource(tt.source)
+			if err != nil {
+				t.Fatalf("ParseSource: %v", err)
+			}
+			loc.fetcher = nil
+			if *loc != tt.want {
+				t.Errorf("ParseSource(%q) = %+v, want %+v", tt.source, *loc, tt.want)
+			}
+		})
+	}
+
+	for _, source := range []string{
+		"git+https://",
+		"git+https://gitlab.com/acme/skills.git@",
+		"https://example.com/skill.md",
+		"git+--upload-pack=touch /tmp/x;true://x@.",
+		"git+ext::sh -c touch% /tmp/x://x",
+		"git+https://gitlab.com/acme/skills.git@--upload-pack=touch",
+		"git+ssh://-oProxyCommand=touch /tmp/x/skills.git",
+		"git+https://example.com/r.git//../../../../etc@main",
+		"git+https://example.com/r.git//skills/../../etc",
+	} {
+		if _, err := ParseSource(source); err == nil {
+			t.Errorf("ParseSource(%q): expected an error", source)
+		}
+	}
+}
+
+func TestResolver_LocalSource(t *testing.T) {
+	root := t.TempDir()
+	skillDir := filepath.Join(root, "skills", "triage")
+	writeFiles(t, skillDir, map[string]string{
+		"SKILL.md":       "---\nname: triage\ndescription: local\n---\nbody\n",
+		"scripts/run.sh": "#!/bin/sh\n",
+		".git/HEAD":      "ref: refs/heads/main\n",
+	})
+
+	resolver, closer := newTestResolver(t, nil)
+	defer closer()
+	resolver.BaseDir = root
+	resolver.Lock = &Lock{}
+
+	skill := schema.Skill{ID: "triage", Source: "./skills/triage"}
+	resolved, err := resolver.Resolve(context.Background(), skill)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if resolved.Description != "local" || len(resolved.Files) != 2 || resolved.Files["scripts/run.sh"] == nil {
+		t.Errorf("unexpected resolved skill: %+v (files %v)", resolved, keysOf(resolved.Files))
+	}
+	if _, ok := resolver.Lock.Skill("triage"); ok {
+		t.Error("local skills must not be locked")
+	}
+	if _, ok, _ := resolver.Cached(skill); ok {
+		t.Error("local skills must not be cached")
+	}
+
+	// Edits are picked up on the next resolve, even offline.
+	writeFiles(t, skillDir, map[string]string{"SKILL.md": "---\nname: triage\ndescription: edited\n---\nbody\n"})
+	resolver.Offline = true
+	if resolved, err = resolver.Resolve(context.Background(), skill); err != nil || resolved.Description != "edited" {
+		t.Errorf("expected the edited skill, got %+v, %v", resolved, err)
+	}
+
+	_, err = resolver.Resolve(context.Background(), schema.Skill{ID: "missing", Source: "./skills/missing"})
+	if err == nil || !strings.Contains(err.Error(), `skill "missing"`) {
+		t.Errorf("expected a missing-directory error, got %v", err)
+	}
+}
+
+func TestResolver_GitSource(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	work := t.TempDir()
+	writeFiles(t, filepath.Join(work, "skills", "triage"), map[string]string{
+		"SKILL.md":   "---\nname: triage\ndescription: from git\n---\nbody\n",
+		"notes/a.md": "a\n",
+	})
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = work
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+			"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	git("init", "--quiet", "--initial-branch=main")
+	git("add", ".")
+	git("commit", "--quiet", "-m", "triage")
+	git("tag", "-a", "v1.0.0", "-m", "v1.0.0")
+	head := git("rev-parse", "HEAD")
+
+	remote := "file://" + filepath.ToSlash(work)
+	resolver, closer := newTestResolver(t, nil)
+	defer closer()
+	resolver.Lock = &Lock{}
+
+	skill := schema.Skill{ID: "triage", Source: "git+" + remote + "//skills/triage@v1.0.0"}
+	resolved, err := resolver.Resolve(context.Background(), skill)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if resolved.Description != "from git" || len(resolved.Files) != 2 {
+		t.Errorf("unexpected resolved skill: %+v (files %v)", resolved, keysOf(resolved.Files))
+	}
+	entry, _ := resolver.Lock.Skill("triage")
+	if entry.Commit != head || entry.Resolved != "git+"+remote+"//skills/triage@"+head {
+		t.Errorf("expected the lock to pin %s, got %+v", head, entry)
+	}
+
+	git("tag", "v1.1.0")
+	latest, err := resolver.LatestRef(context.Background(), skill)
+	if err != nil || latest != "v1.1.0" {
+		t.Errorf("LatestRef = %q, %v; want v1.1.0", latest, err)
+	}
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+)
+
+// LatestRef returns the newest release a pinned skill can be bumped to,
+// or "" when it is already up to date or not pinned to a release.
+// Registry skills are pinned by `version:` and compared against the
+// version in the registry's default SKILL.md; GitHub and git sources are
+// pinned by a semver tag (`owner/repo/skill@v1.2.0`) and compared against
+// the repository's tags. Skills tracking a branch and local directories
+// are never bumped.
+func (r *Resolver) LatestRef(ctx context.Context, skill schema.Skill) (string, error) {
+	if skill.Bare {
+		return "", nil
+	}
+
+	if skill.Source == "" {
+		current, ok := parseVersion(skill.Version)
+		if !ok {
+			return "", nil
+		}
+		body, err := r.Client.FetchByID(ctx, skill.ID, "")
+		if err != nil {
+			return "", err
+		}
+		doc, err := ParseSkillDocument(body)
+		if err != nil {
+			return "", fmt.Errorf("skill %q: %w", skill.ID, err)
+		}
+		latest, ok := parseVersion(doc.Frontmatter.Version)
+		if !ok || compareVersions(latest, current) <= 0 {
+			return "", nil
+		}
+		return doc.Frontmatter.Version, nil
+	}
+
+	loc, err := r.Locate(skill)
+	if err != nil {
+		return "", err
+	}
+	current, ok := parseVersion(loc.Ref)
+	if loc.Local || !ok {
+		return "", nil
+	}
+	tags, err := loc.fetcher.Tags(ctx, loc)
+	if err != nil {
+		return "", fmt.Errorf("skill %q: %w", skill.ID, err)
+	}
+	best, bestTag := current, ""
+	for _, tag := range tags {
+		if v, ok := parseVersion(tag); ok && compareVersions(v, best) > 0 {
+			best, bestTag = v, tag
+		}
+	}
+	return bestTag, nil
+}
+
+// Upgrade lists the releases a skill can move to.
+type Upgrade struct {
+	// Current is the release in use: the locked version, else the
+	// declared pin. It is empty for a range that was never resolved.
+	Current string
+	// Wanted is the newest release the declaration allows: the newest
+	// match of a version range, or the pin itself.
+	Wanted string
+	// Latest is the newest release available.
+	Latest string
+}
+
+// Upgrades reports the releases a skill can move to, from the registry
+// index (or the default SKILL.md when the registry has no index) or the
+// repository's tags. It returns nil for skills that do not track
+// releases: bare skills, local directories and sources on a branch.
+func (r *Resolver) Upgrades(ctx context.Context, skill schema.Skill) (*Upgrade, error) {
+	if skill.Bare {
+		return nil, nil
+	}
+
+	if skill.Source != "" {
+		loc, err := r.Locate(skill)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := parseVersion(loc.Ref); loc.Local || !ok {
+			return nil, nil
+		}
+		tags, err := loc.fetcher.Tags(ctx, loc)
+		if err != nil {
+			return nil, fmt.Errorf("skill %q: %w", skill.ID, err)
+		}
+		latest := newestVersion(append(tags, loc.Ref), func(string) bool { return true })
+		return &Upgrade{Current: loc.Ref, Wanted: loc.Ref, Latest: latest}, nil
+	}
+
+	up := &Upgrade{}
+	if !IsVersionRange(skill.Version) {
+		up.Current = skill.Version
+	}
+	if r.Lock != nil {
+		if entry, ok := r.Lock.Skill(skill.ID); ok && entry.Declared == declaredAs(skill) && entry.Version != "" {
+			up.Current = entry.Version
+		}
+	}
+
+	versions, err := r.Client.Versions(ctx, skill.ID)
+	if err != nil {
+		if IsVersionRange(skill.Version) {
+			return nil, fmt.Errorf("skill %q: %w", skill.ID, err)
+		}
+		body, fetchErr := r.Client.FetchByID(ctx, skill.ID, "")
+		if fetchErr != nil {
+			return nil, fetchErr
+		}
+		doc, parseErr := ParseSkillDocument(body)
+		if parseErr != nil {
+			return nil, fmt.Errorf("skill %q: %w", skill.ID, parseErr)
+		}
+		versions = []string{doc.Frontmatter.Version}
+	}
+	if up.Latest = newestVersion(versions, func(string) bool { return true }); up.Latest == "" {
+		return nil, nil
+	}
+
+	switch {
+	case IsVersionRange(skill.Version):
+		c, err := ParseConstraint(skill.Version)
+		if err != nil {
+			return nil, fmt.Errorf("skill %q: %w", skill.ID, err)
+		}
+		up.Wanted = c.Best(versions)
+	case skill.Version != "":
+		up.Wanted = skill.Version
+	default:
+		up.Wanted = up.Latest
+	}
+	return up, nil
+}
+
+// WithRef rewrites the ref of a skill source, in whichever form it was
+// written: a shorthand or git source gets a new `@<ref>` suffix and a
+// tree URL a new ref segment.
+func WithRef(source, ref string) (string, error) {
+	if IsLocalSource(source) {
+		return "", fmt.Errorf("local skill source %s has no ref", source)
+	}
+	if strings.HasPrefix(source, "git+") {
+		loc, _, err := (&GitFetcher{}).Parse(source)
+		if err != nil {
+			return "", err
+		}
+		loc.Ref = ref
+		return (&GitFetcher{}).URL(loc), nil
+	}
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		loc, err := ParseGitHubTreeURL(source)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("https://github.com/%s/%s/tree/%s/%s", loc.Owner, loc.Repo, ref, loc.Path), nil
+	}
+	body := strings.Trim(source, "/")
+	if at := strings.LastIndex(body, "@"); at >= 0 {
+		body = body[:at]
+	}
+	return body + "@" + ref, nil
+}
+
+// parseVersion parses a release version or tag ("1.2.3", "v1.2.3").
+// Pre-release and build suffixes are not releases and are rejected.
+func parseVersion(s string) ([3]int, bool) {
+	var v [3]int
+	parts := strings.Split(strings.TrimPrefix(s, "v"), ".")
+	if len(parts) != 3 {
+		return v, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
+
+func compareVersions(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
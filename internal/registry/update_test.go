@@ -0,0 +1,145 @@
+package registry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+)
+
+func TestResolver_LatestRefRegistry(t *testing.T) {
+	resolver, closer := newTestResolver(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/skills/data-analysis.md" {
+			t.Errorf("expected the unversioned SKILL.md to be fetched, got %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte("---\nname: data-analysis\ndescription: d\nversion: 1.4.0\n---\n"))
+	})
+	defer closer()
+
+	cases := map[string]string{
+		"1.2.0":  "1.4.0",
+		"1.4.0":  "",
+		"2.0.0":  "",
+		"":       "", // unpinned skills always track the default version
+		"latest": "",
+	}
+	for pinned, want := range cases {
+		got, err := resolver.LatestRef(context.Background(), schema.Skill{ID: "data-analysis", Version: pinned})
+		if err != nil {
+			t.Fatalf("LatestRef(%q): %v", pinned, err)
+		}
+		if got != want {
+			t.Errorf("LatestRef(%q) = %q, want %q", pinned, got, want)
+		}
+	}
+}
+
+func TestResolver_LatestRefGitHubTags(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/skills/tags", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"name":"v2.0.0-rc.1"},{"name":"v1.10.0"},{"name":"v1.9.0"},{"name":"nightly"}]`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	resolver := &Resolver{Installer: &Installer{Client: srv.Client(), APIBase: srv.URL, RawBase: srv.URL}}
+	cases := map[string]string{
+		"acme/skills/triage@v1.2.0":                                 "v1.10.0",
+		"https://github.com/acme/skills/tree/v1.10.0/skills/triage": "",
+		"acme/skills/triage@main":                                   "",
+		"acme/skills/triage":                                        "",
+	}
+	for source, want := range cases {
+		got, err := resolver.LatestRef(context.Background(), schema.Skill{ID: "triage", Source: source})
+		if err != nil {
+			t.Fatalf("LatestRef(%q): %v", source, err)
+		}
+		if got != want {
+			t.Errorf("LatestRef(%q) = %q, want %q", source, got, want)
+		}
+	}
+}
+
+func TestWithRef(t *testing.T) {
+	cases := map[string]string{
+		"acme/skills/triage@v1.2.0": "acme/skills/triage@v1.3.0",
+		"acme/skills/triage":        "acme/skills/triage@v1.3.0",
+		"triage@v1.2.0":             "triage@v1.3.0",
+		"https://github.com/acme/skills/tree/v1.2.0/skills/triage": "https://github.com/acme/skills/tree/v1.3.0/skills/triage",
+		"git+ssh://git@git.acme.dev/ai/skills.git//triage@v1.2.0":  "git+ssh://git@git.acme.dev/ai/skills.git//triage@v1.3.0",
+		"git+https://gitlab.com/acme/triage.git":                   "git+https://gitlab.com/acme/triage.git@v1.3.0",
+	}
+	for source, want := range cases {
+		got, err := WithRef(source, "v1.3.0")
+		if err != nil {
+			t.Fatalf("WithRef(%q): %v", source, err)
+		}
+		if got != want {
+			t.Errorf("WithRef(%q) = %q, want %q", source, got, want)
+		}
+	}
+	if _, err := WithRef("./skills/triage", "v1.3.0"); err == nil {
+		t.Error("expected WithRef to reject a local source")
+	}
+}
+
+func TestResolver_RefreshBypassesCache(t *testing.T) {
+	var calls int
+	resolver, closer := newTestResolver(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte("---\nname: data-analysis\ndescription: fresh\n---\n"))
+	})
+	defer closer()
+
+	skill := schema.Skill{ID: "data-analysis"}
+	if err := resolver.Cache.Put(skill.ID, "", map[string][]byte{SkillFile: []byte("---\nname: data-analysis\ndescription: stale\n---\n")}); err != nil {
+		t.Fatal(err)
+	}
+
+	resolver.Refresh = true
+	resolved, err := resolver.Resolve(context.Background(), skill)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if calls != 1 || resolved.Description != "fresh" {
+		t.Errorf("expected a fresh fetch, got calls=%d description=%q", calls, resolved.Description)
+	}
+	files, ok, err := resolver.Cached(skill)
+	if err != nil || !ok || string(files[SkillFile]) != "---\nname: data-analysis\ndescription: fresh\n---\n" {
+		t.Errorf("expected the cache entry to be overwritten, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestResolver_Upgrades(t *testing.T) {
+	resolver, closer := newTestResolver(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/skills/"+IndexFile {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"skills": [{"id": "data-analysis", "versions": ["1.2.0", "1.4.0", "2.1.0"]}]}`))
+	})
+	defer closer()
+	resolver.Lock = &Lock{}
+	resolver.Lock.Set(LockedSkill{ID: "data-analysis", Declared: "data-analysis@^1.2", Version: "1.2.0"})
+
+	cases := map[string]Upgrade{
+		"^1.2":  {Current: "1.2.0", Wanted: "1.4.0", Latest: "2.1.0"},
+		"1.4.0": {Current: "1.4.0", Wanted: "1.4.0", Latest: "2.1.0"},
+		"":      {Wanted: "2.1.0", Latest: "2.1.0"},
+	}
+	for version, want := range cases {
+		up, err := resolver.Upgrades(context.Background(), schema.Skill{ID: "data-analysis", Version: version})
+		if err != nil {
+			t.Fatalf("Upgrades(%q): %v", version, err)
+		}
+		if up == nil || *up != want {
+			t.Errorf("Upgrades(%q) = %+v, want %+v", version, up, want)
+		}
+	}
+
+	if up, err := resolver.Upgrades(context.Background(), schema.Skill{ID: "local", Source: "./skills/local"}); up != nil || err != nil {
+		t.Errorf("local skills have no releases, got %+v, %v", up, err)
+	}
+}
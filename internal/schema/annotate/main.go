@@ -5,7 +5,8 @@
 // The committed internal/schema/schema.json must stay byte-identical to
 // upstream inference-gateway/adl (task verify-schema diffs them), so we keep
 // the file untouched and feed go-jsonschema this annotated transient copy
-// instead.
+// instead. The copy also carries the CLI-only fields of
+// internal/schema/extensions.json, so types.go covers them too.
 //
 // Rule: for every property of an object schema that is NOT listed in the
 // parent's "required" array AND has type string/boolean/integer/number
@@ -21,6 +22,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
 )
 
 func main() {
@@ -41,6 +44,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := schema.MergeExtensions(doc); err != nil {
+		fmt.Fprintf(os.Stderr, "merge extensions: %v\n", err)
+		os.Exit(1)
+	}
+
 	walk(doc)
 
 	enc := json.NewEncoder(os.Stdout)
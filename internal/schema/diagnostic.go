@@ -0,0 +1,191 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severity classifies a Diagnostic.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is a single finding about a manifest. Path is a dotted JSON
+// path into the document (spec.tools[0].inject[1]); Line and Column are
+// 1-based and point at the closest node that exists in the YAML source,
+// or are zero when the position is unknown.
+type Diagnostic struct {
+	Severity Severity `json:"severity"`
+	RuleID   string   `json:"ruleId"`
+	Path     string   `json:"path,omitempty"`
+	Message  string   `json:"message"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+}
+
+// Rule IDs reported by the validator.
+const (
+	RuleYAMLSyntax       = "yaml-syntax"
+	RuleLegacyShape      = "legacy-shape"
+	RuleSchema           = "schema"
+	RuleToolContract     = "tool-contract"
+	RuleToolInject       = "tool-inject"
+	RuleBuiltinConfig    = "builtin-config"
+	RuleSkillMetadata    = "skill-metadata"
+	RuleSkillsNeedRead   = "skills-need-read"
+	RuleTelemetrySupport = "telemetry-support"
+	RuleMCPSupport       = "mcp-support"
+)
+
+// errorAt builds an error-severity diagnostic.
+func errorAt(rule, path, format string, args ...any) Diagnostic {
+	return Diagnostic{Severity: SeverityError, RuleID: rule, Path: path, Message: fmt.Sprintf(format, args...)}
+}
+
+// warningAt builds a warning-severity diagnostic.
+func warningAt(rule, path, format string, args ...any) Diagnostic {
+	return Diagnostic{Severity: SeverityWarning, RuleID: rule, Path: path, Message: fmt.Sprintf(format, args...)}
+}
+
+// HasErrors reports whether any diagnostic has error severity.
+func HasErrors(diags []Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// SortDiagnostics orders diagnostics by position, keeping the original
+// order for diagnostics on the same line and those without a position.
+func SortDiagnostics(diags []Diagnostic) {
+	sort.SliceStable(diags, func(i, j int) bool {
+		if diags[i].Line != diags[j].Line {
+			return diags[i].Line < diags[j].Line
+		}
+		return diags[i].Column < diags[j].Column
+	})
+}
+
+// yamlLineRe extracts the line number from yaml.v3 syntax errors
+// ("yaml: line 3: did not find expected key").
+var yamlLineRe = regexp.MustCompile(`line (\d+)`)
+
+// yamlErrorLine returns the line a yaml.v3 error refers to, or zero.
+func yamlErrorLine(err error) int {
+	m := yamlLineRe.FindStringSubmatch(err.Error())
+	if m == nil {
+		return 0
+	}
+	line, _ := strconv.Atoi(m[1])
+	return line
+}
+
+// SplitPath splits a diagnostic path into its segments: map keys as
+// strings and sequence indexes as ints.
+func SplitPath(path string) []any {
+	var segments []any
+	for _, part := range strings.Split(path, ".") {
+		if part == "" {
+			continue
+		}
+		key, rest, _ := strings.Cut(part, "[")
+		if key != "" {
+			segments = append(segments, key)
+		}
+		for rest != "" {
+			idx, after, _ := strings.Cut(rest, "]")
+			if n, err := strconv.Atoi(idx); err == nil {
+				segments = append(segments, n)
+			}
+			_, rest, _ = strings.Cut(after, "[")
+		}
+	}
+	return segments
+}
+
+// Locate resolves path against the YAML document root and returns the
+// 1-based line and column of the deepest node on the path that exists.
+// Mapping entries resolve to their key so editors underline the field
+// name. Zero is returned when root is nil.
+func Locate(root *yaml.Node, path string) (int, int) {
+	if root == nil {
+		return 0, 0
+	}
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	line, col := node.Line, node.Column
+
+	for _, seg := range SplitPath(path) {
+		next, at := child(node, seg)
+		if next == nil {
+			break
+		}
+		node = next
+		line, col = at.Line, at.Column
+	}
+	return line, col
+}
+
+// child returns the node stored under seg in node, plus the node whose
+// position should be reported for it (the key for mapping entries).
+func child(node *yaml.Node, seg any) (*yaml.Node, *yaml.Node) {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	switch s := seg.(type) {
+	case string:
+		if node.Kind != yaml.MappingNode {
+			return nil, nil
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == s {
+				return node.Content[i+1], node.Content[i]
+			}
+		}
+	case int:
+		if node.Kind == yaml.SequenceNode && s >= 0 && s < len(node.Content) {
+			return node.Content[s], node.Content[s]
+		}
+	}
+	return nil, nil
+}
+
+// jsonSchemaPath converts a gojsonschema field ("spec.tools.0.name",
+// "(root)") into a diagnostic path ("spec.tools[0].name", "").
+func jsonSchemaPath(field string) string {
+	if field == "(root)" {
+		return ""
+	}
+	var b strings.Builder
+	for _, part := range strings.Split(field, ".") {
+		if _, err := strconv.Atoi(part); err == nil {
+			b.WriteString("[" + part + "]")
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString(".")
+		}
+		b.WriteString(part)
+	}
+	return b.String()
+}
+
+// joinPath appends key to a diagnostic path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,112 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitPath(t *testing.T) {
+	got := SplitPath("spec.tools[2].inject[0]")
+	want := []any{"spec", "tools", 2, "inject", 0}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitPath() = %#v, want %#v", got, want)
+	}
+}
+
+func TestJSONSchemaPath(t *testing.T) {
+	cases := map[string]string{
+		"(root)":              "",
+		"spec.server.port":    "spec.server.port",
+		"spec.tools.0.name":   "spec.tools[0].name",
+		"spec.tools.1.tags.0": "spec.tools[1].tags[0]",
+	}
+	for in, want := range cases {
+		if got := jsonSchemaPath(in); got != want {
+			t.Errorf("jsonSchemaPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestValidator_Diagnose(t *testing.T) {
+	const header = `apiVersion: adl.inference-gateway.com/v1
+kind: Agent
+metadata:
+  name: diag-agent
+  description: Diagnostics agent
+  version: "1.0.0"
+spec:
+  capabilities:
+    streaming: true
+    pushNotifications: false
+    stateTransitionHistory: false
+  server:
+    port: 8080
+  language:
+    go:
+      module: github.com/example/diag-agent
+      version: "1.26.4"
+`
+	cases := []struct {
+		name string
+		adl  string
+		want []Diagnostic
+	}{
+		{
+			name: "valid manifest",
+			adl:  header,
+		},
+		{
+			name: "yaml syntax error",
+			adl:  "kind: Agent\nspec: [\n",
+			want: []Diagnostic{{Severity: SeverityError, RuleID: RuleYAMLSyntax, Line: 2}},
+		},
+		{
+			name: "schema type mismatch",
+			adl:  header + "  telemetry:\n    enabled: \"yes\"\n",
+			want: []Diagnostic{{Severity: SeverityError, RuleID: RuleSchema, Path: "spec.telemetry.enabled", Line: 19, Column: 5}},
+		},
+		{
+			name: "legacy shape",
+			adl:  header + "  sandbox:\n    flox:\n      enabled: true\n",
+			want: []Diagnostic{{Severity: SeverityError, RuleID: RuleLegacyShape, Path: "spec.sandbox", Line: 18, Column: 3}},
+		},
+		{
+			name: "every undefined injection is reported",
+			adl: header + `  tools:
+    - id: lookup
+      name: lookup
+      description: Look things up
+      tags: [search]
+      schema:
+        type: object
+      inject:
+        - database
+        - config.missing
+`,
+			want: []Diagnostic{
+				{Severity: SeverityError, RuleID: RuleToolInject, Path: "spec.tools[0].inject[0]", Line: 26, Column: 11},
+				{Severity: SeverityError, RuleID: RuleToolInject, Path: "spec.tools[0].inject[1]", Line: 27, Column: 11},
+			},
+		},
+	}
+
+	v := NewValidator()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := v.Diagnose([]byte(tc.adl))
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %d diagnostics, want %d: %+v", len(got), len(tc.want), got)
+			}
+			for i, d := range got {
+				w := tc.want[i]
+				if d.Severity != w.Severity || d.RuleID != w.RuleID || d.Line != w.Line ||
+					(w.Path != "" && d.Path != w.Path) || (w.Column != 0 && d.Column != w.Column) {
+					t.Errorf("diagnostic %d = %+v, want %+v", i, d, w)
+				}
+				if d.Message == "" {
+					t.Errorf("diagnostic %d has no message", i)
+				}
+			}
+		})
+	}
+}
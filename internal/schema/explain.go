@@ -0,0 +1,240 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// SchemaField describes one field of the embedded ADL schema, with every
+// $ref along the way resolved. It backs `adl explain`.
+type SchemaField struct {
+	// Path is the dotted path that was explained ("" for the document root).
+	Path string
+	// Definition is the schema definition the field resolves to, if any.
+	Definition  string
+	Type        string
+	Description string
+	Required    bool
+	Enum        []any
+	Const       any
+	Default     any
+	Pattern     string
+	Format      string
+	Minimum     *float64
+	Maximum     *float64
+	// OneOf lists the alternatives of a oneOf constraint, each rendered as
+	// the keys the alternative requires ("otlp", "prometheus").
+	OneOf []string
+	// Fields lists the properties of an object, of the items of an array,
+	// or of the values of a map.
+	Fields []SchemaProperty
+}
+
+// SchemaProperty is a one-line summary of a property listed under
+// SchemaField.Fields.
+type SchemaProperty struct {
+	Name     string
+	Type     string
+	Required bool
+}
+
+// schemaNode is the subset of JSON Schema draft-07 used by schema.json.
+type schemaNode struct {
+	Ref                  string                 `json:"$ref"`
+	Type                 any                    `json:"type"`
+	Description          string                 `json:"description"`
+	Enum                 []any                  `json:"enum"`
+	Const                any                    `json:"const"`
+	Default              any                    `json:"default"`
+	Pattern              string                 `json:"pattern"`
+	Format               string                 `json:"format"`
+	Minimum              *float64               `json:"minimum"`
+	Maximum              *float64               `json:"maximum"`
+	Required             []string               `json:"required"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	AdditionalProperties json.RawMessage        `json:"additionalProperties"`
+	Items                *schemaNode            `json:"items"`
+	OneOf                []*schemaNode          `json:"oneOf"`
+	Definitions          map[string]*schemaNode `json:"definitions"`
+}
+
+// schemaTree parses the embedded schema once; Explain is called on every
+// keystroke by the language server.
+var schemaTree = sync.OnceValues(func() (*schemaNode, error) {
+	var root schemaNode
+	if err := json.Unmarshal(schemaBytes, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded schema: %w", err)
+	}
+	return &root, nil
+})
+
+// SchemaJSON returns the ADL JSON Schema the CLI validates against: the
+// vendored upstream schema with the CLI extensions merged in.
+func SchemaJSON() []byte {
+	return bytes.Clone(schemaBytes)
+}
+
+// Explain describes the schema field at path, a dotted manifest path such
+// as "spec.telemetry.traces.exporter". Sequence indexes are optional:
+// "spec.tools.id", "spec.tools[].id" and "spec.tools[0].id" all describe
+// the id of a tool. Map values are addressed by any key
+// ("spec.services.database").
+func Explain(path string) (*SchemaField, error) {
+	root, err := schemaTree()
+	if err != nil {
+		return nil, err
+	}
+	e := explainer{defs: root.Definitions}
+
+	node := root
+	required := true
+	walked := ""
+	for _, seg := range SplitPath(path) {
+		node, _ = e.resolve(node)
+		switch seg := seg.(type) {
+		case int:
+			if node.Items == nil {
+				return nil, fmt.Errorf("%s is not a list", displayPath(walked))
+			}
+			node, required = node.Items, true
+			walked = fmt.Sprintf("%s[%d]", walked, seg)
+		case string:
+			if node.Items != nil && node.Properties == nil {
+				node, _ = e.resolve(node.Items)
+				walked += "[]"
+			}
+			next, ok := node.Properties[seg]
+			if ok {
+				required = slices.Contains(node.Required, seg)
+			} else if next = node.additional(); next != nil {
+				required = false
+			} else {
+				return nil, fmt.Errorf("%s has no field %q; known fields: %s", displayPath(walked), seg, strings.Join(slices.Sorted(maps.Keys(node.Properties)), ", "))
+			}
+			node = next
+			walked = joinPath(walked, seg)
+		}
+	}
+
+	resolved, def := e.resolve(node)
+	field := &SchemaField{
+		Path:        path,
+		Definition:  def,
+		Type:        e.typeName(node),
+		Description: node.Description,
+		Required:    required,
+		Enum:        resolved.Enum,
+		Const:       resolved.Const,
+		Default:     firstNonNil(node.Default, resolved.Default),
+		Pattern:     resolved.Pattern,
+		Format:      resolved.Format,
+		Minimum:     resolved.Minimum,
+		Maximum:     resolved.Maximum,
+	}
+	if field.Description == "" {
+		field.Description = resolved.Description
+	}
+	for _, alt := range resolved.OneOf {
+		if len(alt.Required) > 0 {
+			field.OneOf = append(field.OneOf, strings.Join(alt.Required, ", "))
+		}
+	}
+
+	container := resolved
+	if container.Items != nil {
+		container, _ = e.resolve(container.Items)
+	} else if v := container.additional(); v != nil && container.Properties == nil {
+		container, _ = e.resolve(v)
+	}
+	for _, name := range slices.Sorted(maps.Keys(container.Properties)) {
+		field.Fields = append(field.Fields, SchemaProperty{
+			Name:     name,
+			Type:     e.typeName(container.Properties[name]),
+			Required: slices.Contains(container.Required, name),
+		})
+	}
+	return field, nil
+}
+
+type explainer struct {
+	defs map[string]*schemaNode
+}
+
+// resolve follows $ref chains and returns the target node together with
+// the name of the last definition on the chain.
+func (e explainer) resolve(node *schemaNode) (*schemaNode, string) {
+	def := ""
+	for i := 0; node.Ref != "" && i < 32; i++ {
+		name := strings.TrimPrefix(node.Ref, "#/definitions/")
+		target, ok := e.defs[name]
+		if !ok {
+			break
+		}
+		node, def = target, name
+	}
+	return node, def
+}
+
+// typeName renders a node's type for humans: "string", "array of Tool",
+// "map of Service", or the definition name ("Server").
+func (e explainer) typeName(node *schemaNode) string {
+	resolved, def := e.resolve(node)
+	name := ""
+	switch t := resolved.Type.(type) {
+	case string:
+		name = t
+	case []any:
+		var parts []string
+		for _, p := range t {
+			parts = append(parts, fmt.Sprint(p))
+		}
+		name = strings.Join(parts, " | ")
+	}
+
+	switch {
+	case name == "array" && resolved.Items != nil:
+		return "array of " + e.typeName(resolved.Items)
+	case name == "object" && resolved.Properties == nil && resolved.additional() != nil:
+		return "map of " + e.typeName(resolved.additional())
+	case def != "":
+		return def
+	case name == "":
+		return "any"
+	}
+	return name
+}
+
+// additional returns the schema of additionalProperties, or nil when it
+// is absent or a boolean.
+func (n *schemaNode) additional() *schemaNode {
+	raw := bytes.TrimSpace(n.AdditionalProperties)
+	if len(raw) == 0 || raw[0] != '{' {
+		return nil
+	}
+	var v schemaNode
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil
+	}
+	return &v
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "the document root"
+	}
+	return path
+}
+
+func firstNonNil(values ...any) any {
+	for _, v := range values {
+		if v != nil {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	cases := []struct {
+		path       string
+		wantType   string
+		wantDef    string
+		required   bool
+		wantFields []string
+		check      func(t *testing.T, f *SchemaField)
+	}{
+		{
+			path:       "",
+			wantType:   "object",
+			required:   true,
+			wantFields: []string{"apiVersion", "kind", "metadata", "spec"},
+		},
+		{
+			path:       "spec.telemetry.traces.exporter",
+			wantType:   "TelemetryTracesExporter",
+			wantDef:    "TelemetryTracesExporter",
+			wantFields: []string{"otlp"},
+			check: func(t *testing.T, f *SchemaField) {
+				if len(f.OneOf) != 1 || f.OneOf[0] != "otlp" {
+					t.Errorf("OneOf = %v, want [otlp]", f.OneOf)
+				}
+				if !strings.Contains(f.Description, "OTEL_TRACES_EXPORTER") {
+					t.Errorf("description not resolved through $ref: %q", f.Description)
+				}
+			},
+		},
+		{
+			path:     "spec.server.authz.mode",
+			wantType: "string",
+			check: func(t *testing.T, f *SchemaField) {
+				if f.Default != "allow-all" {
+					t.Errorf("Default = %v, want allow-all", f.Default)
+				}
+				if len(f.Enum) != 3 {
+					t.Errorf("Enum = %v, want three modes", f.Enum)
+				}
+			},
+		},
+		{
+			path:     "spec.capabilities.streaming",
+			wantType: "boolean",
+			required: true,
+		},
+		{
+			path:     "spec.tools",
+			wantType: "array of Tool",
+		},
+		{path: "spec.tools.id", wantType: "string", required: true},
+		{path: "spec.tools[].id", wantType: "string", required: true},
+		{path: "spec.tools[2].id", wantType: "string", required: true},
+		{path: "spec.services.database", wantType: "Service", wantDef: "Service"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			f, err := Explain(tc.path)
+			if err != nil {
+				t.Fatalf("Explain(%q) failed: %v", tc.path, err)
+			}
+			if f.Type != tc.wantType {
+				t.Errorf("Type = %q, want %q", f.Type, tc.wantType)
+			}
+			if f.Definition != tc.wantDef {
+				t.Errorf("Definition = %q, want %q", f.Definition, tc.wantDef)
+			}
+			if f.Required != tc.required {
+				t.Errorf("Required = %v, want %v", f.Required, tc.required)
+			}
+			if tc.wantFields != nil {
+				var names []string
+				for _, p := range f.Fields {
+					names = append(names, p.Name)
+				}
+				if strings.Join(names, ",") != strings.Join(tc.wantFields, ",") {
+					t.Errorf("Fields = %v, want %v", names, tc.wantFields)
+				}
+			}
+			if tc.check != nil {
+				tc.check(t, f)
+			}
+		})
+	}
+}
+
+func TestExplain_UnknownPath(t *testing.T) {
+	_, err := Explain("spec.telemetry.tracez")
+	if err == nil {
+		t.Fatal("expected an error for an unknown field")
+	}
+	if !strings.Contains(err.Error(), `spec.telemetry has no field "tracez"`) || !strings.Contains(err.Error(), "traces") {
+		t.Errorf("error should name the parent and list the known fields: %v", err)
+	}
+
+	if _, err := Explain("spec.server[0]"); err == nil {
+		t.Error("expected an error when indexing a non-list field")
+	}
+}
+
+func TestSchemaJSON(t *testing.T) {
+	data := SchemaJSON()
+	if !bytes.Equal(data, schemaBytes) {
+		t.Fatal("SchemaJSON() must return the embedded schema unchanged")
+	}
+	data[0] = 'x'
+	if schemaBytes[0] == 'x' {
+		t.Error("SchemaJSON() must return a copy")
+	}
+	if !json.Valid(SchemaJSON()) {
+		t.Error("embedded schema is not valid JSON")
+	}
+}
@@ -0,0 +1,63 @@
+package schema
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+// extensionsBytes holds the fields this CLI supports ahead of the pinned
+// upstream schema. schema.json must stay byte-identical to upstream (task
+// verify-schema), so CLI-only fields are declared here instead and merged
+// into it when the schema is loaded, and by internal/schema/annotate before
+// go-jsonschema generates types.go. Once a field lands upstream, drop it
+// from extensions.json.
+//
+//go:embed extensions.json
+var extensionsBytes []byte
+
+// schemaBytes is the schema the CLI validates, explains and exports: the
+// vendored upstream schema with the CLI extensions merged in.
+var schemaBytes = mustExtendSchema(upstreamSchemaBytes)
+
+// MergeExtensions adds the CLI-only fields of extensions.json to doc, a
+// decoded ADL JSON Schema. Objects present in both are merged recursively;
+// any other value already in doc is kept, so upstream always wins.
+func MergeExtensions(doc map[string]any) error {
+	var ext map[string]any
+	if err := json.Unmarshal(extensionsBytes, &ext); err != nil {
+		return fmt.Errorf("failed to parse schema extensions: %w", err)
+	}
+	mergeSchema(doc, ext)
+	return nil
+}
+
+func mergeSchema(dst, src map[string]any) {
+	for key, value := range src {
+		existing, ok := dst[key]
+		if !ok {
+			dst[key] = value
+			continue
+		}
+		dstObj, dstIsObj := existing.(map[string]any)
+		srcObj, srcIsObj := value.(map[string]any)
+		if dstIsObj && srcIsObj {
+			mergeSchema(dstObj, srcObj)
+		}
+	}
+}
+
+func mustExtendSchema(upstream []byte) []byte {
+	var doc map[string]any
+	if err := json.Unmarshal(upstream, &doc); err != nil {
+		panic(fmt.Sprintf("failed to parse ADL schema: %v", err))
+	}
+	if err := MergeExtensions(doc); err != nil {
+		panic(err.Error())
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode ADL schema: %v", err))
+	}
+	return append(data, '\n')
+}
@@ -0,0 +1,69 @@
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestExtensions_NotUpstream fails once the pinned upstream schema defines
+// a field extensions.json still adds, so the extension gets dropped instead
+// of silently shadowing (or being shadowed by) the upstream definition.
+func TestExtensions_NotUpstream(t *testing.T) {
+	var upstream, ext map[string]any
+	if err := json.Unmarshal(upstreamSchemaBytes, &upstream); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(extensionsBytes, &ext); err != nil {
+		t.Fatal(err)
+	}
+
+	var walk func(path string, dst, src map[string]any)
+	walk = func(path string, dst, src map[string]any) {
+		for key, value := range src {
+			existing, ok := dst[key]
+			if !ok {
+				continue
+			}
+			dstObj, dstIsObj := existing.(map[string]any)
+			srcObj, srcIsObj := value.(map[string]any)
+			if !dstIsObj || !srcIsObj {
+				t.Errorf("%s%s is defined upstream now; remove it from extensions.json", path, key)
+				continue
+			}
+			walk(path+key+".", dstObj, srcObj)
+		}
+	}
+	walk("", upstream, ext)
+}
+
+func TestExtensions_Merged(t *testing.T) {
+	if !strings.Contains(string(SchemaJSON()), `"PythonConfig"`) {
+		t.Error("SchemaJSON() must include the CLI extensions")
+	}
+	if strings.Contains(string(upstreamSchemaBytes), `"PythonConfig"`) {
+		t.Error("schema.json must stay identical to upstream; declare CLI-only fields in extensions.json")
+	}
+
+	for path, definition := range map[string]string{
+		"spec.language.python": "PythonConfig",
+		"spec.language.kotlin": "KotlinConfig",
+		"spec.templates":       "TemplatesConfig",
+	} {
+		field, err := Explain(path)
+		if err != nil || field.Definition != definition || field.Description == "" {
+			t.Errorf("Explain(%s) = %+v, %v", path, field, err)
+		}
+	}
+
+	doc := map[string]any{"definitions": map[string]any{
+		"Language": map[string]any{"properties": map[string]any{"go": "upstream"}},
+	}}
+	if err := MergeExtensions(doc); err != nil {
+		t.Fatal(err)
+	}
+	props := doc["definitions"].(map[string]any)["Language"].(map[string]any)["properties"].(map[string]any)
+	if props["go"] != "upstream" || props["python"] == nil {
+		t.Errorf("MergeExtensions must add missing fields and keep upstream ones, got %v", props)
+	}
+}
@@ -0,0 +1,180 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LintRule is an opinionated check on a manifest that is valid against the
+// schema but probably not what its author wants to ship. Rules are
+// registered with RegisterLintRule and run by Lint.
+type LintRule struct {
+	// ID is the stable kebab-case identifier used in output and in
+	// adl-lint-disable directives.
+	ID       string
+	Severity Severity
+	// Summary is a one-line description shown by `adl lint --list-rules`.
+	Summary string
+	// Rationale explains why the rule exists and how to satisfy it.
+	Rationale string
+	// Check returns one diagnostic per finding. Only Path and Message need
+	// to be set; Lint fills in the rule ID, severity and position.
+	Check func(in *LintInput) []Diagnostic
+	// Fix rewrites the document so the rule no longer fires. It reports
+	// whether anything changed. Nil when the rule has no autofix.
+	Fix func(root *yaml.Node) (bool, error)
+}
+
+// LintInput is what a rule inspects: the decoded manifest and its YAML
+// node tree.
+type LintInput struct {
+	ADL  *ADL
+	Root *yaml.Node
+}
+
+var lintRules []LintRule
+
+// RegisterLintRule adds a rule to the registry. It panics on a duplicate
+// or empty ID, which is a programming error.
+func RegisterLintRule(r LintRule) {
+	if r.ID == "" || r.Check == nil {
+		panic("lint rule must have an ID and a Check function")
+	}
+	if _, ok := LookupLintRule(r.ID); ok {
+		panic(fmt.Sprintf("lint rule %q registered twice", r.ID))
+	}
+	lintRules = append(lintRules, r)
+}
+
+// LintRules returns the registered rules sorted by ID.
+func LintRules() []LintRule {
+	rules := append([]LintRule(nil), lintRules...)
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	return rules
+}
+
+// LookupLintRule returns the registered rule with the given ID.
+func LookupLintRule(id string) (LintRule, bool) {
+	for _, r := range lintRules {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return LintRule{}, false
+}
+
+// Lint validates the manifest in data and, when it is structurally valid,
+// runs every registered rule that the manifest does not disable. Schema
+// errors are returned as is since rules assume a valid document. The
+// validator's own warnings are registered rules, so they are reported
+// (and can be disabled) like any other finding.
+func (v *Validator) Lint(data []byte) []Diagnostic {
+	diags := v.Diagnose(data)
+	if HasErrors(diags) {
+		return diags
+	}
+
+	var root yaml.Node
+	var adl ADL
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return []Diagnostic{errorAt(RuleYAMLSyntax, "", "%v", err)}
+	}
+	if err := root.Decode(&adl); err != nil {
+		return []Diagnostic{errorAt(RuleSchema, "", "%v", err)}
+	}
+
+	disabled := parseLintDirectives(data)
+	in := &LintInput{ADL: &adl, Root: &root}
+	var findings []Diagnostic
+	for _, r := range LintRules() {
+		if disabled.file[r.ID] {
+			continue
+		}
+		for _, d := range r.Check(in) {
+			d.RuleID = r.ID
+			d.Severity = r.Severity
+			d.Line, d.Column = Locate(&root, d.Path)
+			if disabled.lines[d.Line][r.ID] {
+				continue
+			}
+			findings = append(findings, d)
+		}
+	}
+	SortDiagnostics(findings)
+	return findings
+}
+
+// LintFix applies the autofix of every rule that reported one of diags and
+// returns the rewritten manifest along with the IDs of the rules that
+// changed it. When nothing changes, data is returned as is.
+func LintFix(data []byte, diags []Diagnostic) ([]byte, []string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return data, nil, nil
+	}
+
+	fired := make(map[string]bool)
+	for _, d := range diags {
+		fired[d.RuleID] = true
+	}
+
+	var fixed []string
+	for _, r := range LintRules() {
+		if !fired[r.ID] || r.Fix == nil {
+			continue
+		}
+		changed, err := r.Fix(doc.Content[0])
+		if err != nil {
+			return nil, nil, fmt.Errorf("autofix for %s failed: %w", r.ID, err)
+		}
+		if changed {
+			fixed = append(fixed, r.ID)
+		}
+	}
+	if len(fixed) == 0 {
+		return data, nil, nil
+	}
+
+	out, err := encodeDocument(data, &doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, fixed, nil
+}
+
+// lintDirectiveRe matches `# adl-lint-disable rule-a, rule-b` (the whole
+// file) and `# adl-lint-disable-line rule-a` (findings on that line).
+var lintDirectiveRe = regexp.MustCompile(`#\s*adl-lint-disable(-line)?\s+([a-z0-9,\s-]+)`)
+
+type lintDirectives struct {
+	file  map[string]bool
+	lines map[int]map[string]bool
+}
+
+func parseLintDirectives(data []byte) lintDirectives {
+	d := lintDirectives{file: map[string]bool{}, lines: map[int]map[string]bool{}}
+	for i, line := range strings.Split(string(data), "\n") {
+		m := lintDirectiveRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		for _, id := range strings.FieldsFunc(m[2], func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+			if m[1] == "" {
+				d.file[id] = true
+				continue
+			}
+			if d.lines[i+1] == nil {
+				d.lines[i+1] = map[string]bool{}
+			}
+			d.lines[i+1][id] = true
+		}
+	}
+	return d
+}
@@ -0,0 +1,231 @@
+package schema
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Lint rule IDs. The skills-need-read, mcp-support and telemetry-support
+// rules are the validator's own warnings, so `adl validate` and `adl lint`
+// report them under the same ID.
+const (
+	RuleBashUnrestricted  = "bash-unrestricted"
+	RuleFetchUnrestricted = "fetch-unrestricted"
+	RuleAuthzAllowAll     = "authz-allow-all-with-auth"
+)
+
+func init() {
+	RegisterLintRule(LintRule{
+		ID:        RuleBashUnrestricted,
+		Severity:  SeverityWarning,
+		Summary:   "the bash built-in is enabled without a command whitelist",
+		Rationale: "An empty spec.config.tools.bash.whitelist lets the model run any command inside the agent's container. List the commands the agent needs in the whitelist.",
+		Check:     checkBashUnrestricted,
+	})
+	RegisterLintRule(LintRule{
+		ID:        RuleFetchUnrestricted,
+		Severity:  SeverityWarning,
+		Summary:   "the fetch built-in is enabled without allowed_domains",
+		Rationale: "An empty spec.config.tools.fetch.allowed_domains lets the model reach any host, including internal services reachable from the agent. List the hosts the agent needs (a leading '.' matches subdomains).",
+		Check:     checkFetchUnrestricted,
+	})
+	RegisterLintRule(LintRule{
+		ID:        RuleAuthzAllowAll,
+		Severity:  SeverityWarning,
+		Summary:   "authentication is enabled but authorization allows every request",
+		Rationale: "With spec.server.auth.enabled the agent knows who is calling, but spec.server.authz.mode allow-all (the default mode) lets every authenticated caller run every tool. Use mode custom and implement the generated callback, or deny-all while you do.",
+		Check:     checkAuthzAllowAll,
+	})
+	RegisterLintRule(LintRule{
+		ID:        RuleSkillsNeedRead,
+		Severity:  SeverityWarning,
+		Summary:   "skills are declared but the read built-in is not enabled",
+		Rationale: "The AVAILABLE SKILLS manifest only lists skills; the agent loads SKILL.md bodies with the read built-in. Without '- id: read' in spec.tools and spec.config.tools.read.enabled: true the skills cannot be used.",
+		Check: func(in *LintInput) []Diagnostic {
+			return warningsOnly(new(Validator).validateSkills(in.ADL))
+		},
+		Fix: fixSkillsNeedRead,
+	})
+	RegisterLintRule(LintRule{
+		ID:        RuleMCPSupport,
+		Severity:  SeverityWarning,
+		Summary:   "MCP servers the generated agent cannot reach",
+		Rationale: "The ADK MCP client is Go-only and streamable-HTTP-only. stdio and sse servers, or MCP on other languages, are silently dropped at runtime.",
+		Check: func(in *LintInput) []Diagnostic {
+			return new(Validator).validateMCP(in.ADL)
+		},
+	})
+	RegisterLintRule(LintRule{
+		ID:        RuleTelemetrySupport,
+		Severity:  SeverityWarning,
+		Summary:   "telemetry settings the target language ignores",
+		Rationale: "Rust, Python and Kotlin agents ignore spec.telemetry and TypeScript agents cannot export Prometheus metrics, so the configuration has no effect.",
+		Check: func(in *LintInput) []Diagnostic {
+			return new(Validator).validateTelemetry(in.ADL)
+		},
+	})
+}
+
+// warningsOnly drops error diagnostics; validateSkills reports skill
+// metadata errors that Diagnose has already surfaced.
+func warningsOnly(diags []Diagnostic) []Diagnostic {
+	var out []Diagnostic
+	for _, d := range diags {
+		if d.Severity == SeverityWarning {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// listedTool reports whether id appears in spec.tools.
+func listedTool(adl *ADL, id ReservedToolID) bool {
+	for _, tool := range adl.Spec.Tools {
+		if tool.ID == string(id) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkBashUnrestricted(in *LintInput) []Diagnostic {
+	if !listedTool(in.ADL, ReservedToolBash) {
+		return nil
+	}
+	cfg, err := ResolveBuiltinConfigs(in.ADL)
+	if err != nil || !cfg.Bash.Enabled || len(cfg.Bash.Whitelist) > 0 {
+		return nil
+	}
+	return []Diagnostic{{
+		Path:    "spec.config.tools.bash.enabled",
+		Message: "spec.config.tools.bash is enabled with an empty whitelist; the model can run any command",
+	}}
+}
+
+func checkFetchUnrestricted(in *LintInput) []Diagnostic {
+	if !listedTool(in.ADL, ReservedToolFetch) {
+		return nil
+	}
+	cfg, err := ResolveBuiltinConfigs(in.ADL)
+	if err != nil || !cfg.Fetch.Enabled || len(cfg.Fetch.AllowedDomains) > 0 {
+		return nil
+	}
+	return []Diagnostic{{
+		Path:    "spec.config.tools.fetch.enabled",
+		Message: "spec.config.tools.fetch is enabled without allowed_domains; the model can fetch any host",
+	}}
+}
+
+func checkAuthzAllowAll(in *LintInput) []Diagnostic {
+	server := in.ADL.Spec.Server
+	if server.Auth == nil || !server.Auth.Enabled || server.Authz == nil || !server.Authz.Enabled {
+		return nil
+	}
+	if server.Authz.Mode != "" && server.Authz.Mode != AuthzConfigModeAllowAll {
+		return nil
+	}
+	path := "spec.server.authz.mode"
+	if server.Authz.Mode == "" {
+		path = "spec.server.authz"
+	}
+	return []Diagnostic{{
+		Path:    path,
+		Message: "spec.server.auth is enabled but spec.server.authz.mode is allow-all; every authenticated caller can run every tool",
+	}}
+}
+
+// fixSkillsNeedRead lists the read built-in in spec.tools and enables it
+// under spec.config.tools.read.
+func fixSkillsNeedRead(root *yaml.Node) (bool, error) {
+	spec := mappingValue(root, "spec")
+	if spec == nil {
+		return false, nil
+	}
+
+	changed := false
+	tools := ensureSequence(spec, "tools")
+	hasRead := false
+	for _, item := range tools.Content {
+		if id := scalarValue(item, "id"); id == string(ReservedToolRead) {
+			hasRead = true
+			break
+		}
+	}
+	if !hasRead {
+		tools.Content = append(tools.Content, &yaml.Node{
+			Kind: yaml.MappingNode,
+			Tag:  "!!map",
+			Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: "id"},
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: string(ReservedToolRead)},
+			},
+		})
+		changed = true
+	}
+
+	read := ensureMapping(ensureMapping(ensureMapping(spec, "config"), "tools"), string(ReservedToolRead))
+	if scalarValue(read, "enabled") != "true" {
+		if err := setScalar(read, "enabled", "true", "!!bool"); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+// ensureSequence returns the sequence stored under key in node, appending
+// an empty block sequence when the key is absent.
+func ensureSequence(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != key {
+			continue
+		}
+		v := node.Content[i+1]
+		if v.Kind != yaml.SequenceNode {
+			*v = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", LineComment: v.LineComment}
+		}
+		return v
+	}
+	v := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	node.Content = append(node.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		v,
+	)
+	return v
+}
+
+// scalarValue returns the scalar stored under key in a mapping node, or
+// "" when absent.
+func scalarValue(node *yaml.Node, key string) string {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return ""
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key && node.Content[i+1].Kind == yaml.ScalarNode {
+			return node.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+// setScalar sets key to a scalar value in a mapping node, replacing an
+// existing value in place so its comments are kept.
+func setScalar(node *yaml.Node, key, value, tag string) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("cannot set %s: not a mapping", key)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			v := node.Content[i+1]
+			v.Kind, v.Tag, v.Value, v.Style = yaml.ScalarNode, tag, value, 0
+			v.Content = nil
+			return nil
+		}
+	}
+	node.Content = append(node.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value},
+	)
+	return nil
+}
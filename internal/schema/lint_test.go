@@ -0,0 +1,203 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+const lintHeader = `apiVersion: adl.inference-gateway.com/v1
+kind: Agent
+metadata:
+  name: lint-agent
+  description: Lint agent
+  version: "0.1.0"
+spec:
+  capabilities:
+    streaming: true
+    pushNotifications: false
+    stateTransitionHistory: false
+  agent:
+    provider: deepseek
+    model: deepseek-v4-flash
+  language:
+    go:
+      module: github.com/example/lint-agent
+      version: "1.26.4"
+`
+
+func ruleIDs(diags []Diagnostic) []string {
+	var ids []string
+	for _, d := range diags {
+		ids = append(ids, d.RuleID)
+	}
+	return ids
+}
+
+func TestValidator_Lint(t *testing.T) {
+	cases := []struct {
+		name  string
+		adl   string
+		want  []string
+		wantL int
+	}{
+		{
+			name: "clean manifest",
+			adl: lintHeader + `  server:
+    port: 8080
+`,
+		},
+		{
+			name: "bash without whitelist and fetch without allowed domains",
+			adl: lintHeader + `  server:
+    port: 8080
+  config:
+    tools:
+      bash:
+        enabled: true
+      fetch:
+        enabled: true
+  tools:
+    - id: bash
+    - id: fetch
+`,
+			want:  []string{RuleBashUnrestricted, RuleFetchUnrestricted},
+			wantL: 26,
+		},
+		{
+			name: "restricted built-ins are fine",
+			adl: lintHeader + `  server:
+    port: 8080
+  config:
+    tools:
+      bash:
+        enabled: true
+        whitelist: [ls]
+      fetch:
+        enabled: true
+        allowed_domains: [.example.com]
+  tools:
+    - id: bash
+    - id: fetch
+`,
+		},
+		{
+			name: "allow-all authorization behind authentication",
+			adl: lintHeader + `  server:
+    port: 8080
+    auth:
+      enabled: true
+    authz:
+      enabled: true
+      mode: allow-all
+`,
+			want:  []string{RuleAuthzAllowAll},
+			wantL: 25,
+		},
+		{
+			name: "validator warnings are lint findings",
+			adl: lintHeader + `  server:
+    port: 8080
+  skills:
+    - id: x
+      bare: true
+      name: x
+      description: x
+`,
+			want:  []string{RuleSkillsNeedRead},
+			wantL: 21,
+		},
+		{
+			name: "file-level disable directive",
+			adl: lintHeader + `  # adl-lint-disable bash-unrestricted, fetch-unrestricted
+  server:
+    port: 8080
+  config:
+    tools:
+      bash:
+        enabled: true
+      fetch:
+        enabled: true
+  tools:
+    - id: bash
+    - id: fetch
+`,
+		},
+		{
+			name: "line-level disable directive",
+			adl: lintHeader + `  server:
+    port: 8080
+  config:
+    tools:
+      bash:
+        enabled: true # adl-lint-disable-line bash-unrestricted
+      fetch:
+        enabled: true
+  tools:
+    - id: bash
+    - id: fetch
+`,
+			want:  []string{RuleFetchUnrestricted},
+			wantL: 26,
+		},
+	}
+
+	v := NewValidator()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := v.Lint([]byte(tc.adl))
+			if strings.Join(ruleIDs(got), ",") != strings.Join(tc.want, ",") {
+				t.Fatalf("findings = %v, want %v: %+v", ruleIDs(got), tc.want, got)
+			}
+			if len(got) > 0 && got[len(got)-1].Line != tc.wantL {
+				t.Errorf("last finding on line %d, want %d: %+v", got[len(got)-1].Line, tc.wantL, got)
+			}
+		})
+	}
+}
+
+func TestValidator_LintStopsOnSchemaErrors(t *testing.T) {
+	got := NewValidator().Lint([]byte(lintHeader + "  server:\n    port: \"8080\"\n"))
+	if len(got) != 1 || got[0].RuleID != RuleSchema {
+		t.Fatalf("expected a single schema error, got %+v", got)
+	}
+}
+
+func TestLintFix_SkillsNeedRead(t *testing.T) {
+	adl := lintHeader + `  server:
+    port: 8080
+  # skills the agent can load
+  skills:
+    - id: x
+      bare: true
+      name: x
+      description: x
+`
+	v := NewValidator()
+	diags := v.Lint([]byte(adl))
+	fixed, rules, err := LintFix([]byte(adl), diags)
+	if err != nil {
+		t.Fatalf("LintFix() failed: %v", err)
+	}
+	if strings.Join(rules, ",") != RuleSkillsNeedRead {
+		t.Fatalf("fixed rules = %v, want %s", rules, RuleSkillsNeedRead)
+	}
+	if !strings.Contains(string(fixed), "# skills the agent can load") {
+		t.Errorf("comments must survive the fix:\n%s", fixed)
+	}
+	if remaining := v.Lint(fixed); len(remaining) != 0 {
+		t.Errorf("findings after fix: %+v\n%s", remaining, fixed)
+	}
+}
+
+func TestLintRules_CoverValidatorWarnings(t *testing.T) {
+	for _, id := range []string{RuleSkillsNeedRead, RuleMCPSupport, RuleTelemetrySupport} {
+		if _, ok := LookupLintRule(id); !ok {
+			t.Errorf("validator warning %s has no registered lint rule", id)
+		}
+	}
+	for _, r := range LintRules() {
+		if r.Summary == "" || r.Rationale == "" || r.Severity == "" {
+			t.Errorf("rule %s is missing its summary, rationale or severity", r.ID)
+		}
+	}
+}
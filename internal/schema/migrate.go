@@ -0,0 +1,244 @@
+package schema
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Migration rewrites one superseded manifest shape into its successor.
+// Every breaking schema change should ship with a Migration appended to
+// migrations, alongside the matching rejection in checkLegacySpecFields.
+type Migration struct {
+	// ID names the migration after the schema release that introduced the
+	// new shape.
+	ID string
+	// Description is a one-line summary shown by `adl migrate --list`.
+	Description string
+	// Apply rewrites root (a YAML document node) in place and returns a
+	// human-readable line per change. It must be a no-op on manifests that
+	// are already in the new shape.
+	Apply func(root *yaml.Node) ([]string, error)
+}
+
+// MigrationChange is one rewrite performed by Migrate.
+type MigrationChange struct {
+	Migration string
+	Change    string
+}
+
+// migrations is applied in order; later steps may rely on the shape
+// produced by earlier ones (e.g. spec.ai is first moved under
+// spec.development, then its enabled flag is converted).
+var migrations = []Migration{
+	{
+		ID:          "v0.6.0-development",
+		Description: "move spec.sandbox and spec.ai under spec.development",
+		Apply:       migrateDevelopmentSection,
+	},
+	{
+		ID:          "v0.8.0-ai-enabled",
+		Description: "replace spec.development.ai.enabled with orchestrators.claudecode.enabled",
+		Apply:       migrateAIEnabled,
+	},
+	{
+		ID:          "orchestrators",
+		Description: "nest flat spec.development.ai.<agent> toggles under orchestrators",
+		Apply:       migrateFlatAgents,
+	},
+}
+
+// Migrations returns the registered migrations in the order Migrate
+// applies them.
+func Migrations() []Migration {
+	return append([]Migration(nil), migrations...)
+}
+
+// Migrate applies every registered migration to the manifest in data and
+// returns the rewritten manifest along with the changes made. Comments and
+// key order are preserved. When nothing changes, data is returned as is.
+func Migrate(data []byte) ([]byte, []MigrationChange, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return data, nil, nil
+	}
+
+	var changes []MigrationChange
+	for _, m := range migrations {
+		lines, err := m.Apply(doc.Content[0])
+		if err != nil {
+			return nil, nil, fmt.Errorf("migration %s failed: %w", m.ID, err)
+		}
+		for _, line := range lines {
+			changes = append(changes, MigrationChange{Migration: m.ID, Change: line})
+		}
+	}
+	if len(changes) == 0 {
+		return data, nil, nil
+	}
+
+	out, err := encodeDocument(data, &doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, changes, nil
+}
+
+// encodeDocument serialises a rewritten document with the two-space
+// indentation used throughout ADL manifests, keeping a leading "---" if
+// the original source had one.
+func encodeDocument(original []byte, doc *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	if bytes.HasPrefix(original, []byte("---")) {
+		buf.WriteString("---\n")
+	}
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// migrateDevelopmentSection moves the pre-v0.6.0 top-level spec.sandbox
+// and spec.ai blocks under spec.development.
+func migrateDevelopmentSection(root *yaml.Node) ([]string, error) {
+	spec := mappingValue(root, "spec")
+	if spec == nil {
+		return nil, nil
+	}
+
+	var changes []string
+	for _, key := range []string{"sandbox", "ai"} {
+		keyNode, value := removeKey(spec, key)
+		if keyNode == nil {
+			continue
+		}
+		dev := ensureMapping(spec, "development")
+		if mappingValue(dev, key) != nil {
+			return nil, fmt.Errorf("both spec.%s and spec.development.%s are set; merge them by hand", key, key)
+		}
+		dev.Content = append(dev.Content, keyNode, value)
+		changes = append(changes, fmt.Sprintf("moved spec.%s to spec.development.%s", key, key))
+	}
+	return changes, nil
+}
+
+// migrateAIEnabled converts the pre-v0.8.0 single AI flag into the
+// Claude Code toggle, which is what `adl init --ai` writes today.
+func migrateAIEnabled(root *yaml.Node) ([]string, error) {
+	ai := mappingValue(mappingValue(mappingValue(root, "spec"), "development"), "ai")
+	if ai == nil {
+		return nil, nil
+	}
+	keyNode, value := removeKey(ai, "enabled")
+	if keyNode == nil {
+		return nil, nil
+	}
+
+	orchestrators := ensureMapping(ai, "orchestrators")
+	if mappingValue(orchestrators, "claudecode") != nil {
+		return nil, fmt.Errorf("both spec.development.ai.enabled and spec.development.ai.orchestrators.claudecode are set; remove one by hand")
+	}
+	claudecode := ensureMapping(orchestrators, "claudecode")
+	claudecode.Content = append(claudecode.Content, keyNode, value)
+	return []string{fmt.Sprintf("replaced spec.development.ai.enabled with spec.development.ai.orchestrators.claudecode.enabled: %s", value.Value)}, nil
+}
+
+// legacyFlatAgents are the coding agents that used to be toggled directly
+// under spec.development.ai.
+var legacyFlatAgents = []string{"claudecode", "codex", "gemini", "opencode", "infer"}
+
+// migrateFlatAgents nests flat spec.development.ai.<agent> toggles under
+// spec.development.ai.orchestrators. A bare boolean toggle becomes
+// `<agent>: {enabled: <bool>}`.
+func migrateFlatAgents(root *yaml.Node) ([]string, error) {
+	ai := mappingValue(mappingValue(mappingValue(root, "spec"), "development"), "ai")
+	if ai == nil {
+		return nil, nil
+	}
+
+	var changes []string
+	for _, agent := range legacyFlatAgents {
+		keyNode, value := removeKey(ai, agent)
+		if keyNode == nil {
+			continue
+		}
+		orchestrators := ensureMapping(ai, "orchestrators")
+		if mappingValue(orchestrators, agent) != nil {
+			return nil, fmt.Errorf("both spec.development.ai.%s and spec.development.ai.orchestrators.%s are set; merge them by hand", agent, agent)
+		}
+		if value.Kind == yaml.ScalarNode {
+			value = &yaml.Node{
+				Kind: yaml.MappingNode,
+				Tag:  "!!map",
+				Content: []*yaml.Node{
+					{Kind: yaml.ScalarNode, Tag: "!!str", Value: "enabled"},
+					value,
+				},
+			}
+		}
+		orchestrators.Content = append(orchestrators.Content, keyNode, value)
+		changes = append(changes, fmt.Sprintf("moved spec.development.ai.%s to spec.development.ai.orchestrators.%s", agent, agent))
+	}
+	return changes, nil
+}
+
+// mappingValue returns the mapping stored under key in node, or nil when
+// node is not a mapping or the key is absent or not a mapping.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			if v := node.Content[i+1]; v.Kind == yaml.MappingNode {
+				return v
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// removeKey deletes key from the mapping node and returns its key and
+// value nodes, or nils when the key is absent.
+func removeKey(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			k, v := node.Content[i], node.Content[i+1]
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return k, v
+		}
+	}
+	return nil, nil
+}
+
+// ensureMapping returns the mapping stored under key in node, appending an
+// empty block mapping when the key is absent. An existing key whose value
+// is null (`key:` with nothing after it) is turned into a mapping.
+func ensureMapping(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != key {
+			continue
+		}
+		v := node.Content[i+1]
+		if v.Kind != yaml.MappingNode {
+			*v = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", LineComment: v.LineComment}
+		}
+		return v
+	}
+	v := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	node.Content = append(node.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		v,
+	)
+	return v
+}
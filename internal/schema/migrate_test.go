@@ -0,0 +1,133 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestMigrate(t *testing.T) {
+	cases := []struct {
+		name        string
+		in          string
+		want        string
+		wantChanges int
+		errSub      string
+	}{
+		{
+			name: "pre-v0.6.0 top-level sandbox and ai",
+			in: `---
+# agent manifest
+spec:
+  server:
+    port: 8080 # listen port
+  # flox sandbox
+  sandbox:
+    flox:
+      enabled: true
+  ai:
+    enabled: true
+`,
+			want: `---
+# agent manifest
+spec:
+  server:
+    port: 8080 # listen port
+  development:
+    # flox sandbox
+    sandbox:
+      flox:
+        enabled: true
+    ai:
+      orchestrators:
+        claudecode:
+          enabled: true
+`,
+			wantChanges: 3,
+		},
+		{
+			name: "flat per-agent toggles",
+			in: `spec:
+  development:
+    ai:
+      codex: true
+      gemini:
+        enabled: false # off for now
+`,
+			want: `spec:
+  development:
+    ai:
+      orchestrators:
+        codex:
+          enabled: true
+        gemini:
+          enabled: false # off for now
+`,
+			wantChanges: 2,
+		},
+		{
+			name: "current shape is untouched",
+			in: `spec:
+  development:
+    ai:
+      orchestrators:
+        claudecode:
+          enabled: true
+`,
+			want: `spec:
+  development:
+    ai:
+      orchestrators:
+        claudecode:
+          enabled: true
+`,
+		},
+		{
+			name: "conflicting legacy and current fields",
+			in: `spec:
+  sandbox:
+    flox:
+      enabled: true
+  development:
+    sandbox:
+      flox:
+        enabled: false
+`,
+			errSub: "merge them by hand",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, changes, err := Migrate([]byte(tc.in))
+			if tc.errSub != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errSub) {
+					t.Fatalf("expected error containing %q, got: %v", tc.errSub, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Migrate() failed: %v", err)
+			}
+			if len(changes) != tc.wantChanges {
+				t.Errorf("got %d changes, want %d: %+v", len(changes), tc.wantChanges, changes)
+			}
+			if string(out) != tc.want {
+				t.Errorf("unexpected output:\n%s\nwant:\n%s", out, tc.want)
+			}
+			if err := checkLegacySpecFields(mustParseYAML(t, out)); err != nil {
+				t.Errorf("migrated manifest is still rejected: %v", err)
+			}
+		})
+	}
+}
+
+func mustParseYAML(t *testing.T, data []byte) any {
+	t.Helper()
+	var v any
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		t.Fatalf("failed to parse migrated YAML: %v", err)
+	}
+	return v
+}
@@ -0,0 +1,160 @@
+package schema
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AddSkill appends skill to spec.skills in the manifest in data, creating
+// the list when needed. Comments and key order are preserved. It fails
+// when a skill with the same ID is already declared.
+func AddSkill(data []byte, skill Skill) ([]byte, error) {
+	return editDocument(data, func(root *yaml.Node) error {
+		spec := mappingValue(root, "spec")
+		if spec == nil {
+			return fmt.Errorf("manifest has no spec section")
+		}
+		skills := ensureSequence(spec, "skills")
+		if findSkill(skills, skill.ID) >= 0 {
+			return fmt.Errorf("skill %q is already declared in spec.skills", skill.ID)
+		}
+
+		skills.Content = append(skills.Content, skillNode(skill))
+		return nil
+	})
+}
+
+// skillNode encodes a skill entry with its keys in the order manifests
+// conventionally use (id first), omitting empty fields.
+func skillNode(skill Skill) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	add := func(key, value, tag string) {
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value},
+		)
+	}
+	add("id", skill.ID, "!!str")
+	for _, f := range []struct{ key, value string }{
+		{"version", skill.Version},
+		{"source", skill.Source},
+	} {
+		if f.value != "" {
+			add(f.key, f.value, "!!str")
+		}
+	}
+	if skill.Bare {
+		add("bare", "true", "!!bool")
+	}
+	for _, f := range []struct{ key, value string }{
+		{"name", skill.Name},
+		{"description", skill.Description},
+		{"license", string(skill.License)},
+	} {
+		if f.value != "" {
+			add(f.key, f.value, "!!str")
+		}
+	}
+	if len(skill.Tags) > 0 {
+		tags := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: yaml.FlowStyle}
+		for _, tag := range skill.Tags {
+			tags.Content = append(tags.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: tag})
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "tags"}, tags)
+	}
+	return node
+}
+
+// RemoveSkill deletes the spec.skills entry with the given ID. The list
+// itself is removed once it is empty.
+func RemoveSkill(data []byte, id string) ([]byte, error) {
+	return editDocument(data, func(root *yaml.Node) error {
+		spec := mappingValue(root, "spec")
+		skills := sequenceValue(spec, "skills")
+		i := findSkill(skills, id)
+		if i < 0 {
+			return fmt.Errorf("skill %q is not declared in spec.skills", id)
+		}
+		skills.Content = append(skills.Content[:i], skills.Content[i+1:]...)
+		if len(skills.Content) == 0 {
+			removeKey(spec, "skills")
+		}
+		return nil
+	})
+}
+
+// SetSkillField sets a string field (version, source, ...) of the
+// spec.skills entry with the given ID, keeping the entry's comments.
+func SetSkillField(data []byte, id, key, value string) ([]byte, error) {
+	return editDocument(data, func(root *yaml.Node) error {
+		skills := sequenceValue(mappingValue(root, "spec"), "skills")
+		i := findSkill(skills, id)
+		if i < 0 {
+			return fmt.Errorf("skill %q is not declared in spec.skills", id)
+		}
+		return setScalar(skills.Content[i], key, value, "!!str")
+	})
+}
+
+// PinRegistrySkill turns the spec.skills entry with the given ID into a
+// registry skill pinned to version, as after publishing a bare skill:
+// bare, source and the metadata the published SKILL.md now carries are
+// dropped. Comments on the entry are kept.
+func PinRegistrySkill(data []byte, id, version string) ([]byte, error) {
+	return editDocument(data, func(root *yaml.Node) error {
+		skills := sequenceValue(mappingValue(root, "spec"), "skills")
+		i := findSkill(skills, id)
+		if i < 0 {
+			return fmt.Errorf("skill %q is not declared in spec.skills", id)
+		}
+		entry := skills.Content[i]
+		for _, key := range []string{"bare", "source", "name", "description", "license", "tags"} {
+			removeKey(entry, key)
+		}
+		return setScalar(entry, "version", version, "!!str")
+	})
+}
+
+// editDocument parses data, applies edit to the root mapping and
+// re-encodes the document in the manifest's style.
+func editDocument(data []byte, edit func(root *yaml.Node) error) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("manifest is not a YAML mapping")
+	}
+	if err := edit(doc.Content[0]); err != nil {
+		return nil, err
+	}
+	return encodeDocument(data, &doc)
+}
+
+// sequenceValue returns the sequence stored under key in node, or nil.
+func sequenceValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key && node.Content[i+1].Kind == yaml.SequenceNode {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// findSkill returns the index of the entry with the given id in a
+// spec.skills sequence node, or -1.
+func findSkill(skills *yaml.Node, id string) int {
+	if skills == nil {
+		return -1
+	}
+	for i, item := range skills.Content {
+		if scalarValue(item, "id") == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,96 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSkillsEdit(t *testing.T) {
+	manifest := `spec:
+  server:
+    port: 8080 # public port
+`
+	out, err := AddSkill([]byte(manifest), Skill{ID: "triage", Source: "acme/skills/triage@v1.0.0"})
+	if err != nil {
+		t.Fatalf("AddSkill() failed: %v", err)
+	}
+	out, err = AddSkill(out, Skill{ID: "policy", Bare: true, Name: "policy", Description: "Rules", Tags: []string{"a", "b"}})
+	if err != nil {
+		t.Fatalf("AddSkill() failed: %v", err)
+	}
+	want := `spec:
+  server:
+    port: 8080 # public port
+  skills:
+    - id: triage
+      source: acme/skills/triage@v1.0.0
+    - id: policy
+      bare: true
+      name: policy
+      description: Rules
+      tags: [a, b]
+`
+	if string(out) != want {
+		t.Fatalf("AddSkill() =\n%s\nwant\n%s", out, want)
+	}
+
+	if _, err := AddSkill(out, Skill{ID: "triage"}); err == nil || !strings.Contains(err.Error(), "already declared") {
+		t.Errorf("expected a duplicate error, got %v", err)
+	}
+
+	out, err = SetSkillField(out, "triage", "source", "acme/skills/triage@v1.1.0")
+	if err != nil {
+		t.Fatalf("SetSkillField() failed: %v", err)
+	}
+	if !strings.Contains(string(out), "source: acme/skills/triage@v1.1.0") {
+		t.Errorf("source not updated:\n%s", out)
+	}
+
+	out, err = RemoveSkill(out, "triage")
+	if err != nil {
+		t.Fatalf("RemoveSkill() failed: %v", err)
+	}
+	out, err = RemoveSkill(out, "policy")
+	if err != nil {
+		t.Fatalf("RemoveSkill() failed: %v", err)
+	}
+	if string(out) != manifest {
+		t.Errorf("removing every skill should drop spec.skills:\n%s", out)
+	}
+	if _, err := RemoveSkill(out, "policy"); err == nil {
+		t.Error("expected removing an undeclared skill to fail")
+	}
+}
+
+func TestPinRegistrySkill(t *testing.T) {
+	manifest := `spec:
+  skills:
+    # company rules
+    - id: policy
+      bare: true
+      name: policy
+      description: Rules
+      license: MIT
+      tags: [a, b]
+    - id: triage
+      source: acme/skills/triage@v1.0.0
+`
+	out, err := PinRegistrySkill([]byte(manifest), "policy", "1.0.0")
+	if err != nil {
+		t.Fatalf("PinRegistrySkill() failed: %v", err)
+	}
+	want := `spec:
+  skills:
+    # company rules
+    - id: policy
+      version: 1.0.0
+    - id: triage
+      source: acme/skills/triage@v1.0.0
+`
+	if string(out) != want {
+		t.Fatalf("PinRegistrySkill() =\n%s\nwant\n%s", out, want)
+	}
+	if _, err := PinRegistrySkill(out, "missing", "1.0.0"); err == nil {
+		t.Error("expected an undeclared skill to fail")
+	}
+}
@@ -407,6 +407,21 @@ type InferConfig struct {
 	Enabled bool `json:"enabled" yaml:"enabled" mapstructure:"enabled"`
 }
 
+// Kotlin/JVM agent built with Gradle. 'packageName' is the Kotlin package the
+// sources live in (e.g. 'com.example.agent') and 'jvmVersion' the Java
+// toolchain version (e.g. '21'). Vendor entries use Maven 'group:artifact'
+// coordinates, e.g. 'io.ktor:ktor-client-cio@3.1.2'.
+type KotlinConfig struct {
+	// JvmVersion corresponds to the JSON schema field "jvmVersion".
+	JvmVersion string `json:"jvmVersion" yaml:"jvmVersion" mapstructure:"jvmVersion"`
+
+	// PackageName corresponds to the JSON schema field "packageName".
+	PackageName string `json:"packageName" yaml:"packageName" mapstructure:"packageName"`
+
+	// Vendor corresponds to the JSON schema field "vendor".
+	Vendor *VendorConfig `json:"vendor,omitempty,omitzero" yaml:"vendor,omitempty" mapstructure:"vendor,omitempty"`
+}
+
 type KubernetesConfig struct {
 	// Image corresponds to the JSON schema field "image".
 	Image *ImageConfig `json:"image,omitempty,omitzero" yaml:"image,omitempty" mapstructure:"image,omitempty"`
@@ -416,6 +431,12 @@ type Language struct {
 	// Go corresponds to the JSON schema field "go".
 	Go *GoConfig `json:"go,omitempty,omitzero" yaml:"go,omitempty" mapstructure:"go,omitempty"`
 
+	// Kotlin corresponds to the JSON schema field "kotlin".
+	Kotlin *KotlinConfig `json:"kotlin,omitempty,omitzero" yaml:"kotlin,omitempty" mapstructure:"kotlin,omitempty"`
+
+	// Python corresponds to the JSON schema field "python".
+	Python *PythonConfig `json:"python,omitempty,omitzero" yaml:"python,omitempty" mapstructure:"python,omitempty"`
+
 	// Rust corresponds to the JSON schema field "rust".
 	Rust *RustConfig `json:"rust,omitempty,omitzero" yaml:"rust,omitempty" mapstructure:"rust,omitempty"`
 
@@ -611,6 +632,21 @@ type OrchestratorsConfig struct {
 	Opencode *OpenCodeConfig `json:"opencode,omitempty,omitzero" yaml:"opencode,omitempty" mapstructure:"opencode,omitempty"`
 }
 
+// Python agent built on the A2A Python SDK and managed with uv. 'packageName'
+// is the distribution name (e.g. 'my-agent', imported as 'my_agent') and
+// 'pythonVersion' the interpreter version (e.g. '3.13'). Vendor entries use the
+// PyPI name, e.g. 'httpx@0.28.1'.
+type PythonConfig struct {
+	// PackageName corresponds to the JSON schema field "packageName".
+	PackageName string `json:"packageName" yaml:"packageName" mapstructure:"packageName"`
+
+	// PythonVersion corresponds to the JSON schema field "pythonVersion".
+	PythonVersion string `json:"pythonVersion" yaml:"pythonVersion" mapstructure:"pythonVersion"`
+
+	// Vendor corresponds to the JSON schema field "vendor".
+	Vendor *VendorConfig `json:"vendor,omitempty,omitzero" yaml:"vendor,omitempty" mapstructure:"vendor,omitempty"`
+}
+
 type ResourcesConfig struct {
 	// CPU corresponds to the JSON schema field "cpu".
 	CPU string `json:"cpu,omitempty,omitzero" yaml:"cpu,omitempty" mapstructure:"cpu,omitempty"`
@@ -899,6 +935,9 @@ type Spec struct {
 	// Telemetry corresponds to the JSON schema field "telemetry".
 	Telemetry *TelemetryConfig `json:"telemetry,omitempty,omitzero" yaml:"telemetry,omitempty" mapstructure:"telemetry,omitempty"`
 
+	// Templates corresponds to the JSON schema field "templates".
+	Templates *TemplatesConfig `json:"templates,omitempty,omitzero" yaml:"templates,omitempty" mapstructure:"templates,omitempty"`
+
 	// Tools corresponds to the JSON schema field "tools".
 	Tools []Tool `json:"tools,omitempty,omitzero" yaml:"tools,omitempty" mapstructure:"tools,omitempty"`
 }
@@ -1012,6 +1051,16 @@ type TelemetryTracesExporter struct {
 	Otlp *TelemetryOTLPExporter `json:"otlp,omitempty,omitzero" yaml:"otlp,omitempty" mapstructure:"otlp,omitempty"`
 }
 
+// Project-local template overlay. 'path' is a directory, relative to the ADL
+// file, that mirrors the CLI's embedded template tree (languages/<lang>/,
+// common/, sandbox/); every '<name>.tmpl' file in it replaces the embedded
+// template of the same name. It takes precedence over ~/.adl/templates and is
+// overridden by the --templates-dir flag.
+type TemplatesConfig struct {
+	// Path corresponds to the JSON schema field "path".
+	Path string `json:"path" yaml:"path" mapstructure:"path"`
+}
+
 // Function-call entrypoint the agent can invoke. Generated as code in the target
 // language. User-defined tools require name, description, tags, and schema;
 // reserved built-in IDs (e.g. read, bash, write, edit) may omit them and have
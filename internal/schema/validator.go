@@ -3,19 +3,21 @@ package schema
 import (
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/xeipuuv/gojsonschema"
 	"gopkg.in/yaml.v3"
 )
 
-// schemaBytes holds the canonical ADL JSON Schema, vendored from
+// upstreamSchemaBytes holds the canonical ADL JSON Schema, vendored from
 // github.com/inference-gateway/adl at the version pinned in Taskfile.yml
 // (ADL_SCHEMA_VERSION). Refresh with `task fetch-schema`.
 //
 //go:embed schema.json
-var schemaBytes []byte
+var upstreamSchemaBytes []byte
 
 // Validator validates ADL files against the schema
 type Validator struct {
@@ -38,16 +40,70 @@ func NewValidator() *Validator {
 // ValidateFile validates an ADL file. Returns any non-fatal warnings
 // that callers should surface to the user (e.g., a skills-using agent
 // that hasn't enabled the Read built-in). A nil error means the manifest
-// is structurally valid; warnings may still be present.
+// is structurally valid; warnings may still be present. Use Diagnose for
+// structured findings with source positions.
 func (v *Validator) ValidateFile(filePath string) ([]string, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
+	diags := v.Diagnose(data)
+	var warnings []string
+	var schemaErrors []string
+	for _, d := range diags {
+		switch {
+		case d.Severity == SeverityWarning:
+			warnings = append(warnings, d.Message)
+		case d.RuleID == RuleSchema:
+			schemaErrors = append(schemaErrors, d.Message)
+		default:
+			return nil, errors.New(validationErrorPrefix[d.RuleID] + d.Message)
+		}
+	}
+	if len(schemaErrors) > 0 {
+		return nil, fmt.Errorf("validation failed:\n- %s", strings.Join(schemaErrors, "\n- "))
+	}
+	return warnings, nil
+}
+
+// validationErrorPrefix keeps ValidateFile's error messages stable now that
+// the checks report diagnostics.
+var validationErrorPrefix = map[string]string{
+	RuleYAMLSyntax:    "failed to parse YAML: ",
+	RuleToolContract:  "tool validation failed: ",
+	RuleToolInject:    "tool validation failed: ",
+	RuleBuiltinConfig: "tool validation failed: ",
+	RuleSkillMetadata: "skill validation failed: ",
+}
+
+// Diagnose validates the manifest in data and returns every finding,
+// positioned against the YAML source. Checks run in stages: a legacy
+// shape or a schema violation stops validation before the semantic
+// checks, which assume a structurally valid document.
+func (v *Validator) Diagnose(data []byte) []Diagnostic {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return []Diagnostic{{
+			Severity: SeverityError,
+			RuleID:   RuleYAMLSyntax,
+			Message:  err.Error(),
+			Line:     yamlErrorLine(err),
+		}}
+	}
+	diags := v.diagnose(&root)
+	for i := range diags {
+		if diags[i].Line == 0 {
+			diags[i].Line, diags[i].Column = Locate(&root, diags[i].Path)
+		}
+	}
+	return diags
+}
+
+func (v *Validator) diagnose(root *yaml.Node) []Diagnostic {
 	var yamlData any
-	if err := yaml.Unmarshal(data, &yamlData); err != nil {
-		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	if err := root.Decode(&yamlData); err != nil {
+		return []Diagnostic{errorAt(RuleYAMLSyntax, "", "%v", err)}
 	}
 
 	// Reject pre-v0.6.0 manifests up front with a clear migration hint.
@@ -56,97 +112,109 @@ func (v *Validator) ValidateFile(filePath string) ([]string, error) {
 	// dropped at unmarshal time and the agent would generate without
 	// sandboxes or AI docs.
 	if err := checkLegacySpecFields(yamlData); err != nil {
-		return nil, err
+		var legacy *legacyShapeError
+		path := ""
+		if errors.As(err, &legacy) {
+			path = legacy.path
+		}
+		return []Diagnostic{errorAt(RuleLegacyShape, path, "%s", err.Error())}
 	}
 
 	jsonData, err := json.Marshal(yamlData)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert to JSON: %w", err)
+		return []Diagnostic{errorAt(RuleYAMLSyntax, "", "failed to convert to JSON: %v", err)}
 	}
 
-	documentLoader := gojsonschema.NewBytesLoader(jsonData)
-	result, err := v.schema.Validate(documentLoader)
+	result, err := v.schema.Validate(gojsonschema.NewBytesLoader(jsonData))
 	if err != nil {
-		return nil, fmt.Errorf("validation error: %w", err)
+		return []Diagnostic{errorAt(RuleSchema, "", "validation error: %v", err)}
 	}
-
 	if !result.Valid() {
-		var errors []string
+		var diags []Diagnostic
 		for _, desc := range result.Errors() {
-			errors = append(errors, desc.String())
+			path := jsonSchemaPath(desc.Field())
+			if prop, ok := desc.Details()["property"].(string); ok && desc.Type() == "required" {
+				path = joinPath(path, prop)
+			}
+			diags = append(diags, errorAt(RuleSchema, path, "%s", desc.String()))
 		}
-		return nil, fmt.Errorf("validation failed:\n- %s", fmt.Sprintf("\n- %s", errors))
+		return diags
 	}
 
 	// Additional validation: check that injected services are defined
 	var adl ADL
-	if err := yaml.Unmarshal(data, &adl); err != nil {
-		return nil, fmt.Errorf("failed to parse ADL for service validation: %w", err)
+	if err := root.Decode(&adl); err != nil {
+		return []Diagnostic{errorAt(RuleSchema, "", "failed to parse ADL for service validation: %v", err)}
 	}
 
-	if err := v.validateTools(&adl); err != nil {
-		return nil, fmt.Errorf("tool validation failed: %w", err)
+	diags := v.validateTools(&adl)
+	diags = append(diags, v.validateSkills(&adl)...)
+	if HasErrors(diags) {
+		return diags
 	}
-
-	warnings, err := v.validateSkills(&adl)
-	if err != nil {
-		return nil, fmt.Errorf("skill validation failed: %w", err)
-	}
-
-	warnings = append(warnings, v.validateTelemetry(&adl)...)
-	warnings = append(warnings, v.validateMCP(&adl)...)
-
-	return warnings, nil
+	diags = append(diags, v.validateTelemetry(&adl)...)
+	diags = append(diags, v.validateMCP(&adl)...)
+	return diags
 }
 
 // validateMCP surfaces non-fatal warnings for spec.agent.mcp. The ADK's built-in
 // MCP client is Go-only and streamable-HTTP-only: the block is ignored for
-// TypeScript/Rust agents, and stdio/sse servers cannot be reached so they are
+// TypeScript/Rust/Python/Kotlin agents, and stdio/sse servers cannot be reached so they are
 // dropped from the derived A2A_MCP_SERVERS.
-func (v *Validator) validateMCP(adl *ADL) []string {
+func (v *Validator) validateMCP(adl *ADL) []Diagnostic {
 	if adl.Spec.Agent == nil || adl.Spec.Agent.Mcp == nil || !adl.Spec.Agent.Mcp.Enabled {
 		return nil
 	}
 	if adl.Spec.Language.Go == nil {
-		return []string{
-			"spec.agent.mcp is enabled but the ADK MCP client is generated for Go agents only; the block is ignored for TypeScript and Rust agents.",
+		return []Diagnostic{warningAt(RuleMCPSupport, "spec.agent.mcp",
+			"spec.agent.mcp is enabled but the ADK MCP client is generated for Go agents only; the block is ignored for TypeScript, Rust, Python and Kotlin agents."),
 		}
 	}
 
-	var warnings []string
+	var warnings []Diagnostic
 	httpServers := 0
-	for _, s := range adl.Spec.Agent.Mcp.Servers {
+	for i, s := range adl.Spec.Agent.Mcp.Servers {
 		switch s.Transport {
 		case MCPServerTransportHttp:
 			if s.URL != "" {
 				httpServers++
 			}
 		case MCPServerTransportStdio, MCPServerTransportSse:
-			warnings = append(warnings, fmt.Sprintf(
+			warnings = append(warnings, warningAt(RuleMCPSupport, fmt.Sprintf("spec.agent.mcp.servers[%d].transport", i),
 				"spec.agent.mcp.servers[%q] uses the %q transport, but the ADK MCP client is streamable-HTTP-only; it is dropped from A2A_MCP_SERVERS.",
 				s.Name, s.Transport))
 		}
 	}
 	if httpServers == 0 {
-		warnings = append(warnings,
-			"spec.agent.mcp is enabled but no http servers are declared in spec.agent.mcp.servers; A2A_MCP_SERVERS will be empty and the agent will fail to start until it is set via the environment.")
+		warnings = append(warnings, warningAt(RuleMCPSupport, "spec.agent.mcp.servers",
+			"spec.agent.mcp is enabled but no http servers are declared in spec.agent.mcp.servers; A2A_MCP_SERVERS will be empty and the agent will fail to start until it is set via the environment."))
 	}
 	return warnings
 }
 
 // validateTelemetry surfaces non-fatal warnings for telemetry configurations the
-// generator cannot fully honor. Rust ignores spec.telemetry entirely, and the
-// TypeScript ADK does not support the Prometheus pull exporter yet - the OTLP
-// push exporter is the supported path there.
-func (v *Validator) validateTelemetry(adl *ADL) []string {
+// generator cannot fully honor. Rust, Python and Kotlin ignore spec.telemetry entirely,
+// and the TypeScript ADK does not support the Prometheus pull exporter yet - the
+// OTLP push exporter is the supported path there.
+func (v *Validator) validateTelemetry(adl *ADL) []Diagnostic {
 	tel := adl.Spec.Telemetry
 	if tel == nil {
 		return nil
 	}
 
 	if adl.Spec.Language.Rust != nil {
-		return []string{
-			"spec.telemetry is set but telemetry generation supports Go and TypeScript only; the block is ignored for Rust agents.",
+		return []Diagnostic{warningAt(RuleTelemetrySupport, "spec.telemetry",
+			"spec.telemetry is set but telemetry generation supports Go and TypeScript only; the block is ignored for Rust agents."),
+		}
+	}
+	if adl.Spec.Language.Python != nil {
+		return []Diagnostic{warningAt(RuleTelemetrySupport, "spec.telemetry",
+			"spec.telemetry is set but telemetry generation supports Go and TypeScript only; the block is ignored for Python agents."),
+		}
+	}
+	if adl.Spec.Language.Kotlin != nil {
+		return []Diagnostic{warningAt(RuleTelemetrySupport, "spec.telemetry",
+			"spec.telemetry is set but telemetry generation supports Go and TypeScript only; the block is ignored for Kotlin agents."),
 		}
 	}
 
@@ -154,11 +222,11 @@ func (v *Validator) validateTelemetry(adl *ADL) []string {
 		return nil
 	}
 
-	var warnings []string
+	var warnings []Diagnostic
 	if adl.Spec.Language.TypeScript != nil &&
 		tel.Metrics != nil && tel.Metrics.Exporter != nil && tel.Metrics.Exporter.Prometheus != nil {
-		warnings = append(warnings,
-			"spec.telemetry.metrics.exporter.prometheus is set but the TypeScript ADK does not support the Prometheus pull exporter yet; use spec.telemetry.metrics.exporter.otlp to push to a collector instead.")
+		warnings = append(warnings, warningAt(RuleTelemetrySupport, "spec.telemetry.metrics.exporter.prometheus",
+			"spec.telemetry.metrics.exporter.prometheus is set but the TypeScript ADK does not support the Prometheus pull exporter yet; use spec.telemetry.metrics.exporter.otlp to push to a collector instead."))
 	}
 	return warnings
 }
@@ -185,22 +253,24 @@ func checkLegacySpecFields(yamlData any) error {
 		return nil
 	}
 
-	var legacyV6 []string
+	var legacyV6, legacyV6Paths []string
 	if _, exists := spec["sandbox"]; exists {
 		legacyV6 = append(legacyV6, "spec.sandbox -> spec.development.sandbox")
+		legacyV6Paths = append(legacyV6Paths, "spec.sandbox")
 	}
 	if _, exists := spec["ai"]; exists {
 		legacyV6 = append(legacyV6, "spec.ai -> spec.development.ai")
+		legacyV6Paths = append(legacyV6Paths, "spec.ai")
 	}
 	if len(legacyV6) > 0 {
-		return fmt.Errorf("manifest uses pre-v0.6.0 schema fields; move them under spec.development:\n  - %s\nThe ADL schema is pinned at v0.6.0+ (see https://github.com/inference-gateway/adl/releases/tag/v0.6.0)",
-			joinWithIndent(legacyV6, "\n  - "))
+		return legacyShapeErrorf(legacyV6Paths[0], "manifest uses pre-v0.6.0 schema fields; move them under spec.development:\n  - %s\nThe ADL schema is pinned at v0.6.0+ (see https://github.com/inference-gateway/adl/releases/tag/v0.6.0).\nRun 'adl migrate' to rewrite the manifest automatically",
+			strings.Join(legacyV6, "\n  - "))
 	}
 
 	if dev, ok := spec["development"].(map[string]any); ok {
 		if ai, ok := dev["ai"].(map[string]any); ok {
 			if _, exists := ai["enabled"]; exists {
-				return fmt.Errorf("manifest uses the pre-v0.8.0 single-flag AI shape `spec.development.ai.enabled`; this field was removed in ADL v0.8.0. Move it to a per-agent toggle under spec.development.ai.orchestrators, e.g.:\n\n  spec:\n    development:\n      ai:\n        orchestrators:\n          claudecode:\n            enabled: true   # generates CLAUDE.md + .github/workflows/claude.yml\n          # codex / gemini / opencode / infer are independent toggles\n\nSee https://github.com/inference-gateway/adl/releases/tag/v0.8.0 for the full per-agent matrix")
+				return legacyShapeErrorf("spec.development.ai.enabled", "manifest uses the pre-v0.8.0 single-flag AI shape `spec.development.ai.enabled`; this field was removed in ADL v0.8.0. Move it to a per-agent toggle under spec.development.ai.orchestrators, e.g.:\n\n  spec:\n    development:\n      ai:\n        orchestrators:\n          claudecode:\n            enabled: true   # generates CLAUDE.md + .github/workflows/claude.yml\n          # codex / gemini / opencode / infer are independent toggles\n\nSee https://github.com/inference-gateway/adl/releases/tag/v0.8.0 for the full per-agent matrix.\nRun 'adl migrate' to rewrite the manifest automatically")
 			}
 
 			var flatAgents []string
@@ -210,8 +280,8 @@ func checkLegacySpecFields(yamlData any) error {
 				}
 			}
 			if len(flatAgents) > 0 {
-				return fmt.Errorf("manifest uses the legacy flat AI shape; coding-agent toggles now nest under spec.development.ai.orchestrators:\n  - %s\nMove them under an `orchestrators` block, e.g.:\n\n  spec:\n    development:\n      ai:\n        orchestrators:\n          claudecode:\n            enabled: true\n          # codex / gemini / opencode / infer are independent toggles\n\nSee inference-gateway/adl#27 for the orchestrators refactor",
-					joinWithIndent(flatAgents, "\n  - "))
+				return legacyShapeErrorf(flatAgents[0], "manifest uses the legacy flat AI shape; coding-agent toggles now nest under spec.development.ai.orchestrators:\n  - %s\nMove them under an `orchestrators` block, e.g.:\n\n  spec:\n    development:\n      ai:\n        orchestrators:\n          claudecode:\n            enabled: true\n          # codex / gemini / opencode / infer are independent toggles\n\nSee inference-gateway/adl#27 for the orchestrators refactor.\nRun 'adl migrate' to rewrite the manifest automatically",
+					strings.Join(flatAgents, "\n  - "))
 			}
 		}
 	}
@@ -219,17 +289,17 @@ func checkLegacySpecFields(yamlData any) error {
 	return nil
 }
 
-// joinWithIndent is a tiny helper so we don't pull in strings just for one
-// formatted error.
-func joinWithIndent(items []string, sep string) string {
-	out := ""
-	for i, item := range items {
-		if i > 0 {
-			out += sep
-		}
-		out += item
-	}
-	return out
+// legacyShapeError is returned by checkLegacySpecFields; path locates the
+// first offending field for diagnostics.
+type legacyShapeError struct {
+	path string
+	msg  string
+}
+
+func (e *legacyShapeError) Error() string { return e.msg }
+
+func legacyShapeErrorf(path, format string, args ...any) error {
+	return &legacyShapeError{path: path, msg: fmt.Sprintf(format, args...)}
 }
 
 // reservedConfigSection is the namespace inside spec.config dedicated to
@@ -239,7 +309,7 @@ const reservedConfigSection = "tools"
 
 // validateTools enforces the contract for both user-defined and reserved
 // (built-in) tool IDs and the integrity of spec.config.tools.<id>.
-func (v *Validator) validateTools(adl *ADL) error {
+func (v *Validator) validateTools(adl *ADL) []Diagnostic {
 	definedServices := map[string]bool{"logger": true, "config": true}
 	for serviceName := range adl.Spec.Services {
 		definedServices[serviceName] = true
@@ -252,57 +322,59 @@ func (v *Validator) validateTools(adl *ADL) error {
 
 	toolsCfg := adl.Spec.Config[reservedConfigSection]
 
-	for _, tool := range adl.Spec.Tools {
+	var diags []Diagnostic
+	for i, tool := range adl.Spec.Tools {
+		toolPath := fmt.Sprintf("spec.tools[%d]", i)
 		if IsReservedToolID(tool.ID) {
 			if tool.Name != "" {
-				return fmt.Errorf("reserved tool '%s' must not set 'name' (the generator supplies it)", tool.ID)
+				diags = append(diags, errorAt(RuleToolContract, toolPath+".name", "reserved tool '%s' must not set 'name' (the generator supplies it)", tool.ID))
 			}
 			if tool.Description != "" {
-				return fmt.Errorf("reserved tool '%s' must not set 'description' (the generator supplies it)", tool.ID)
+				diags = append(diags, errorAt(RuleToolContract, toolPath+".description", "reserved tool '%s' must not set 'description' (the generator supplies it)", tool.ID))
 			}
 			if len(tool.Schema) > 0 {
-				return fmt.Errorf("reserved tool '%s' must not set 'schema' (the generator supplies it)", tool.ID)
+				diags = append(diags, errorAt(RuleToolContract, toolPath+".schema", "reserved tool '%s' must not set 'schema' (the generator supplies it)", tool.ID))
 			}
 			if len(tool.Inject) > 0 {
-				return fmt.Errorf("reserved tool '%s' must not set 'inject' (built-ins do not use service injection)", tool.ID)
+				diags = append(diags, errorAt(RuleToolContract, toolPath+".inject", "reserved tool '%s' must not set 'inject' (built-ins do not use service injection)", tool.ID))
 			}
 			if raw, ok := toolsCfg[tool.ID]; ok {
 				if _, err := DecodeBuiltinToolConfig(tool.ID, raw); err != nil {
-					return err
+					diags = append(diags, errorAt(RuleBuiltinConfig, "spec.config.tools."+tool.ID, "%v", err))
 				}
 			}
 			continue
 		}
 
 		if tool.Name == "" {
-			return fmt.Errorf("tool '%s' must set 'name' (only reserved built-in IDs may omit it)", tool.ID)
+			diags = append(diags, errorAt(RuleToolContract, toolPath, "tool '%s' must set 'name' (only reserved built-in IDs may omit it)", tool.ID))
 		}
 		if tool.Description == "" {
-			return fmt.Errorf("tool '%s' must set 'description'", tool.ID)
+			diags = append(diags, errorAt(RuleToolContract, toolPath, "tool '%s' must set 'description'", tool.ID))
 		}
 		if len(tool.Tags) == 0 {
-			return fmt.Errorf("tool '%s' must set 'tags' (at least one)", tool.ID)
+			diags = append(diags, errorAt(RuleToolContract, toolPath, "tool '%s' must set 'tags' (at least one)", tool.ID))
 		}
 		if len(tool.Schema) == 0 {
-			return fmt.Errorf("tool '%s' must set 'schema'", tool.ID)
+			diags = append(diags, errorAt(RuleToolContract, toolPath, "tool '%s' must set 'schema'", tool.ID))
 		}
 
-		for _, injectedService := range tool.Inject {
+		for j, injectedService := range tool.Inject {
+			injectPath := fmt.Sprintf("%s.inject[%d]", toolPath, j)
 			if len(injectedService) > 7 && injectedService[:7] == "config." {
 				configSection := injectedService[7:]
 				if configSection == reservedConfigSection {
-					return fmt.Errorf("tool '%s' injects reserved namespace 'config.tools'; this namespace is owned by built-in tools and cannot be inject-referenced", tool.ID)
-				}
-				if !definedConfigSections[configSection] {
-					return fmt.Errorf("tool '%s' injects config section '%s' that is not defined in spec.config", tool.ID, configSection)
+					diags = append(diags, errorAt(RuleToolInject, injectPath, "tool '%s' injects reserved namespace 'config.tools'; this namespace is owned by built-in tools and cannot be inject-referenced", tool.ID))
+				} else if !definedConfigSections[configSection] {
+					diags = append(diags, errorAt(RuleToolInject, injectPath, "tool '%s' injects config section '%s' that is not defined in spec.config", tool.ID, configSection))
 				}
 			} else if !definedServices[injectedService] {
-				return fmt.Errorf("tool '%s' injects service '%s' that is not defined in spec.services", tool.ID, injectedService)
+				diags = append(diags, errorAt(RuleToolInject, injectPath, "tool '%s' injects service '%s' that is not defined in spec.services", tool.ID, injectedService))
 			}
 		}
 	}
 
-	return nil
+	return diags
 }
 
 // validateSkills enforces bare-skill metadata and surfaces non-fatal
@@ -312,20 +384,25 @@ func (v *Validator) validateTools(adl *ADL) error {
 // warnings (not errors) for the read-tool case so partially configured
 // manifests still pass validation - the warning prompts the user to fix
 // it.
-func (v *Validator) validateSkills(adl *ADL) ([]string, error) {
-	for _, skill := range adl.Spec.Skills {
+func (v *Validator) validateSkills(adl *ADL) []Diagnostic {
+	var diags []Diagnostic
+	for i, skill := range adl.Spec.Skills {
 		if skill.Bare {
+			skillPath := fmt.Sprintf("spec.skills[%d]", i)
 			if skill.Name == "" {
-				return nil, fmt.Errorf("skill '%s' has bare: true but is missing name", skill.ID)
+				diags = append(diags, errorAt(RuleSkillMetadata, skillPath, "skill '%s' has bare: true but is missing name", skill.ID))
 			}
 			if skill.Description == "" {
-				return nil, fmt.Errorf("skill '%s' has bare: true but is missing description", skill.ID)
+				diags = append(diags, errorAt(RuleSkillMetadata, skillPath, "skill '%s' has bare: true but is missing description", skill.ID))
 			}
 		}
 	}
+	if len(diags) > 0 {
+		return diags
+	}
 
 	if len(adl.Spec.Skills) == 0 || adl.Spec.Agent == nil {
-		return nil, nil
+		return nil
 	}
 
 	hasReadTool := false
@@ -336,28 +413,28 @@ func (v *Validator) validateSkills(adl *ADL) ([]string, error) {
 		}
 	}
 	if !hasReadTool {
-		return []string{
-			"spec.skills is non-empty but spec.tools is missing '- id: read'; the AVAILABLE SKILLS manifest will be added to the system prompt but the agent has no Read built-in to load SKILL.md bodies. Add '- id: read' to spec.tools and set spec.config.tools.read.enabled: true.",
-		}, nil
+		return []Diagnostic{warningAt(RuleSkillsNeedRead, "spec.skills",
+			"spec.skills is non-empty but spec.tools is missing '- id: read'; the AVAILABLE SKILLS manifest will be added to the system prompt but the agent has no Read built-in to load SKILL.md bodies. Add '- id: read' to spec.tools and set spec.config.tools.read.enabled: true."),
+		}
 	}
 
 	toolsCfg := adl.Spec.Config[reservedConfigSection]
 	readRaw, ok := toolsCfg[string(ReservedToolRead)]
 	if !ok {
-		return []string{
-			"spec.skills is non-empty and '- id: read' is listed, but spec.config.tools.read is missing; the Read built-in will register in a disabled state and fail at runtime. Set spec.config.tools.read.enabled: true.",
-		}, nil
+		return []Diagnostic{warningAt(RuleSkillsNeedRead, "spec.config.tools.read",
+			"spec.skills is non-empty and '- id: read' is listed, but spec.config.tools.read is missing; the Read built-in will register in a disabled state and fail at runtime. Set spec.config.tools.read.enabled: true."),
+		}
 	}
 	decoded, err := DecodeBuiltinToolConfig(string(ReservedToolRead), readRaw)
 	if err != nil {
-		return nil, err
+		return []Diagnostic{errorAt(RuleSkillMetadata, "spec.config.tools.read", "%v", err)}
 	}
 	readCfg, ok := decoded.(*ReadBuiltinConfig)
 	if !ok || !readCfg.Enabled {
-		return []string{
-			"spec.skills is non-empty but spec.config.tools.read.enabled is not true; the Read built-in will register in a disabled state and fail at runtime. Set spec.config.tools.read.enabled: true so the agent can load SKILL.md bodies.",
-		}, nil
+		return []Diagnostic{warningAt(RuleSkillsNeedRead, "spec.config.tools.read.enabled",
+			"spec.skills is non-empty but spec.config.tools.read.enabled is not true; the Read built-in will register in a disabled state and fail at runtime. Set spec.config.tools.read.enabled: true so the agent can load SKILL.md bodies."),
+		}
 	}
 
-	return nil, nil
+	return nil
 }
@@ -67,7 +67,7 @@ func TestValidator_validateMCP(t *testing.T) {
 	ts := &ADL{}
 	ts.Spec.Language.TypeScript = &TypeScriptConfig{}
 	ts.Spec.Agent = &Agent{Mcp: &MCP{Enabled: true}}
-	if w := v.validateMCP(ts); len(w) != 1 || !strings.Contains(w[0], "Go agents only") {
+	if w := v.validateMCP(ts); len(w) != 1 || !strings.Contains(w[0].Message, "Go agents only") {
 		t.Errorf("TypeScript MCP warnings = %v, want the Go-only notice", w)
 	}
 
@@ -78,7 +78,7 @@ func TestValidator_validateMCP(t *testing.T) {
 	if len(w) != 2 {
 		t.Fatalf("stdio-only MCP warnings = %v, want 2", w)
 	}
-	if !strings.Contains(w[0], "stdio") || !strings.Contains(w[1], "no http servers") {
+	if !strings.Contains(w[0].Message, "stdio") || !strings.Contains(w[1].Message, "no http servers") {
 		t.Errorf("unexpected warnings: %v", w)
 	}
 
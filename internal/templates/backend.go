@@ -0,0 +1,189 @@
+package templates
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+)
+
+// LanguageBackend is what the generator knows about one target language.
+// Each supported language registers one implementation in backends;
+// adding a language means writing a backend and its templates under
+// languages/<name>.
+type LanguageBackend interface {
+	// Name is the language identifier: the spec.language key, the
+	// languages/<name> template directory and the Context.Language value.
+	Name() string
+	// DisplayName is the name shown to users, e.g. "TypeScript".
+	DisplayName() string
+	// Selected reports whether adl configures this language.
+	Selected(adl *schema.ADL) bool
+	// Validate checks the required fields of the spec.language block.
+	Validate(adl *schema.ADL) error
+	// Isolate returns lang with every other language's block removed.
+	Isolate(lang schema.Language) schema.Language
+
+	// Files returns the language-specific part of the generated file
+	// map, from output path to template key. Registry.GetFiles adds the
+	// files every language shares.
+	Files(adl *schema.ADL) map[string]string
+	// ToolTemplate and ServiceTemplate are the template keys rendered
+	// once per spec.tools[] and spec.services entry, or "" when the
+	// language scaffolds none.
+	ToolTemplate() string
+	ServiceTemplate() string
+	// EntryContext returns the extra values the tool and service
+	// templates receive, such as the Go module path.
+	EntryContext(adl *schema.ADL) map[string]any
+	// IsToolFile and IsServiceFile report whether a generated file is a
+	// user-owned tool or service implementation. Those files carry no
+	// generated header.
+	IsToolFile(templateKey, path string) bool
+	IsServiceFile(templateKey, path string) bool
+
+	// UserFiles returns the scaffolded files a fresh .adl-ignore
+	// protects: the TODO placeholders the user is expected to complete.
+	UserFiles(adl *schema.ADL) []string
+	// IgnoreDefaults returns the examples and dependency files the
+	// comments of a fresh .adl-ignore mention.
+	IgnoreDefaults(adl *schema.ADL) IgnoreDefaults
+	// PostGenerateCommands returns the commands run in the output
+	// directory after generation when spec.hooks.post is empty.
+	PostGenerateCommands() []string
+
+	// HeaderExtensions lists the extensions of the source files that
+	// get the generated-file header, commented with CommentPrefix.
+	HeaderExtensions() []string
+	CommentPrefix() string
+
+	// VendorBuiltins returns the dependencies the manifest template
+	// always declares; spec.language.<name>.vendor entries colliding
+	// with them are dropped.
+	VendorBuiltins() (deps, devdeps map[string]string)
+}
+
+// IgnoreDefaults is the language-specific part of a fresh .adl-ignore.
+type IgnoreDefaults struct {
+	ExampleFile string
+	ExampleGlob string
+	ExampleDir  string
+	CustomFile  string
+	// DepsHeader is the comment introducing DepsFiles, the lock files
+	// of the language's package manager.
+	DepsHeader string
+	DepsFiles  []string
+}
+
+// backends holds the registered language backends, in the order
+// DetectLanguageFromADL probes them.
+var backends = []LanguageBackend{
+	goBackend{},
+	rustBackend{},
+	typeScriptBackend{},
+	pythonBackend{},
+	kotlinBackend{},
+}
+
+// Backends returns the registered language backends.
+func Backends() []LanguageBackend {
+	return slices.Clone(backends)
+}
+
+// LookupBackend returns the backend of the named language.
+func LookupBackend(name string) (LanguageBackend, bool) {
+	for _, b := range backends {
+		if b.Name() == name {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+// BackendFor returns the backend of the language adl configures,
+// defaulting to Go.
+func BackendFor(adl *schema.ADL) LanguageBackend {
+	for _, b := range backends {
+		if b.Selected(adl) {
+			return b
+		}
+	}
+	return goBackend{}
+}
+
+// SelectedBackends returns the backends of every language adl
+// configures, in registration order.
+func SelectedBackends(adl *schema.ADL) []LanguageBackend {
+	var selected []LanguageBackend
+	for _, b := range backends {
+		if b.Selected(adl) {
+			selected = append(selected, b)
+		}
+	}
+	return selected
+}
+
+// WithLanguage returns a shallow copy of adl that configures b's
+// language only, so a manifest defining several languages can be
+// generated one language at a time.
+func WithLanguage(adl *schema.ADL, b LanguageBackend) *schema.ADL {
+	single := *adl
+	single.Spec.Language = b.Isolate(adl.Spec.Language)
+	return &single
+}
+
+// DetectLanguageFromADL detects the programming language from ADL
+func DetectLanguageFromADL(adl *schema.ADL) string {
+	return BackendFor(adl).Name()
+}
+
+// FileType returns the GetGeneratedFileHeader file type of fileName: the
+// name of the backend whose sources it is, "yaml", "dockerfile" or
+// "taskfile". It returns "" for files that carry no header.
+func FileType(fileName string) string {
+	ext := strings.ToLower(filepath.Ext(fileName))
+	baseName := strings.ToLower(filepath.Base(fileName))
+
+	for _, b := range backends {
+		if slices.Contains(b.HeaderExtensions(), ext) {
+			return b.Name()
+		}
+	}
+	switch {
+	case ext == ".yaml" || ext == ".yml":
+		return "yaml"
+	case baseName == "dockerfile":
+		return "dockerfile"
+	case baseName == "taskfile.yml":
+		return "taskfile"
+	}
+	return ""
+}
+
+// snakeCase turns a tool or service ID into the file stem the generator
+// looks its entry up by.
+func snakeCase(id string) string {
+	return strings.ReplaceAll(id, "-", "_")
+}
+
+// userTools returns the non-reserved spec.tools[] entries: the ones a
+// language scaffolds a tool file for.
+func userTools(adl *schema.ADL) []schema.Tool {
+	var tools []schema.Tool
+	for _, tool := range adl.Spec.Tools {
+		if !schema.IsReservedToolID(tool.ID) {
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+// addVercelFiles adds the Vercel project files when spec.deployment.type
+// is vercel.
+func addVercelFiles(adl *schema.ADL, files map[string]string) {
+	if adl.Spec.Deployment != nil && adl.Spec.Deployment.Type == schema.DeploymentConfigTypeVercel {
+		files["vercel.json"] = "vercel/vercel.json"
+		files[".vercel/project.json"] = "vercel/project.json"
+	}
+}
@@ -0,0 +1,125 @@
+package templates
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+	"github.com/inference-gateway/adl-cli/internal/vendor"
+)
+
+// goBackend generates agents built on the Go ADK.
+type goBackend struct{}
+
+func (goBackend) Name() string        { return "go" }
+func (goBackend) DisplayName() string { return "Go" }
+
+func (goBackend) Selected(adl *schema.ADL) bool { return adl.Spec.Language.Go != nil }
+
+func (goBackend) Validate(adl *schema.ADL) error {
+	if adl.Spec.Language.Go.Module == "" {
+		return fmt.Errorf("spec.language.go.module is required")
+	}
+	if adl.Spec.Language.Go.Version == "" {
+		return fmt.Errorf("spec.language.go.version is required")
+	}
+	return nil
+}
+
+func (goBackend) Isolate(lang schema.Language) schema.Language {
+	return schema.Language{Go: lang.Go}
+}
+
+func (goBackend) Files(adl *schema.ADL) map[string]string {
+	files := map[string]string{
+		"main.go":                   "main.go",
+		"go.mod":                    "go.mod",
+		"config/config.go":          "config.go",
+		"Dockerfile":                "docker/dockerfile.go",
+		"internal/logger/logger.go": "logger.go",
+	}
+	addVercelFiles(adl, files)
+
+	for _, tool := range adl.Spec.Tools {
+		if schema.IsReservedToolID(tool.ID) {
+			files[fmt.Sprintf("tools/%s.go", tool.ID)] = fmt.Sprintf("builtin/%s.go", tool.ID)
+			files[fmt.Sprintf("tools/%s_test.go", tool.ID)] = fmt.Sprintf("builtin/%s_test.go", tool.ID)
+			continue
+		}
+		files[fmt.Sprintf("tools/%s.go", snakeCase(tool.ID))] = "tool.go"
+	}
+
+	if telemetryEnabled(adl) && hasBuiltinTool(adl) {
+		files["tools/telemetry.go"] = "telemetry.go"
+	}
+
+	if adl.Spec.Server.Authz != nil && adl.Spec.Server.Authz.Enabled {
+		files["internal/authz/authz.go"] = "authz.go"
+	}
+
+	for serviceName := range adl.Spec.Services {
+		name := snakeCase(serviceName)
+		files[fmt.Sprintf("internal/%s/%s.go", name, name)] = "service.go"
+	}
+
+	return files
+}
+
+func (goBackend) ToolTemplate() string    { return "tool.go" }
+func (goBackend) ServiceTemplate() string { return "service.go" }
+
+func (goBackend) EntryContext(adl *schema.ADL) map[string]any {
+	return map[string]any{"GoModule": adl.Spec.Language.Go.Module}
+}
+
+func (goBackend) IsToolFile(templateKey, path string) bool {
+	if templateKey == "telemetry.go" {
+		return false
+	}
+	return templateKey == "tool.go" || (strings.HasPrefix(path, "tools/") && filepath.Ext(path) == ".go")
+}
+
+func (goBackend) IsServiceFile(templateKey, path string) bool {
+	return templateKey == "service.go" ||
+		(strings.Contains(path, "/internal/") && strings.HasSuffix(path, ".go") && !strings.Contains(path, "/logger/"))
+}
+
+func (goBackend) UserFiles(adl *schema.ADL) []string {
+	var files []string
+	for _, tool := range userTools(adl) {
+		files = append(files, fmt.Sprintf("tools/%s.go", snakeCase(tool.ID)))
+	}
+	for _, serviceName := range slices.Sorted(maps.Keys(adl.Spec.Services)) {
+		name := snakeCase(serviceName)
+		files = append(files, fmt.Sprintf("internal/%s/%s.go", name, name))
+	}
+	if adl.Spec.Server.Authz != nil && adl.Spec.Server.Authz.Enabled {
+		files = append(files, "internal/authz/authz.go")
+	}
+	return files
+}
+
+func (goBackend) IgnoreDefaults(*schema.ADL) IgnoreDefaults {
+	return IgnoreDefaults{
+		ExampleFile: "tools/agent_tool.go",
+		ExampleGlob: "*.go",
+		ExampleDir:  "build/",
+		CustomFile:  "my-custom-file.go",
+		DepsHeader:  "# Go dependency files",
+		DepsFiles:   []string{"go.sum"},
+	}
+}
+
+func (goBackend) PostGenerateCommands() []string {
+	return []string{"go mod tidy", "go fmt ./..."}
+}
+
+func (goBackend) HeaderExtensions() []string { return []string{".go"} }
+func (goBackend) CommentPrefix() string      { return "// " }
+
+func (goBackend) VendorBuiltins() (deps, devdeps map[string]string) {
+	return vendor.GoBuiltins, nil
+}
@@ -0,0 +1,130 @@
+package templates
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+	"github.com/inference-gateway/adl-cli/internal/vendor"
+)
+
+// kotlinBackend generates Ktor-based agents built with Gradle. Sources
+// live in the standard Gradle layout under the directory
+// KotlinPackagePath derives from spec.language.kotlin.packageName.
+type kotlinBackend struct{}
+
+func (kotlinBackend) Name() string        { return "kotlin" }
+func (kotlinBackend) DisplayName() string { return "Kotlin" }
+
+func (kotlinBackend) Selected(adl *schema.ADL) bool { return adl.Spec.Language.Kotlin != nil }
+
+func (kotlinBackend) Validate(adl *schema.ADL) error {
+	if adl.Spec.Language.Kotlin.PackageName == "" {
+		return fmt.Errorf("spec.language.kotlin.packageName is required")
+	}
+	if adl.Spec.Language.Kotlin.JvmVersion == "" {
+		return fmt.Errorf("spec.language.kotlin.jvmVersion is required")
+	}
+	return nil
+}
+
+func (kotlinBackend) Isolate(lang schema.Language) schema.Language {
+	return schema.Language{Kotlin: lang.Kotlin}
+}
+
+func (kotlinBackend) Files(adl *schema.ADL) map[string]string {
+	pkg := kotlinSourceDir(adl)
+	files := map[string]string{
+		pkg + "Main.kt":                  "Main.kt",
+		pkg + "A2A.kt":                   "A2A.kt",
+		pkg + "Config.kt":                "Config.kt",
+		pkg + "Executor.kt":              "Executor.kt",
+		pkg + "Logger.kt":                "Logger.kt",
+		pkg + "Skills.kt":                "Skills.kt",
+		pkg + "ToolBox.kt":               "ToolBox.kt",
+		"src/main/resources/logback.xml": "logback.xml",
+		"src/test/kotlin/" + KotlinPackagePath(adl.Spec.Language.Kotlin) + "/ConfigTest.kt": "ConfigTest.kt",
+		"build.gradle.kts":    "build.gradle.kts",
+		"settings.gradle.kts": "settings.gradle.kts",
+		"gradle.properties":   "gradle.properties",
+		"Dockerfile":          "docker/dockerfile.kotlin",
+	}
+
+	tools := userTools(adl)
+	for _, tool := range tools {
+		files[fmt.Sprintf("%stools/%s.kt", pkg, snakeCase(tool.ID))] = "tool.kt"
+	}
+	if len(tools) > 0 {
+		files[pkg+"tools/Tools.kt"] = "tools.kt"
+	}
+
+	for serviceName := range adl.Spec.Services {
+		files[fmt.Sprintf("%sservices/%s.kt", pkg, snakeCase(serviceName))] = "service.kt"
+	}
+
+	return files
+}
+
+func (kotlinBackend) ToolTemplate() string    { return "tool.kt" }
+func (kotlinBackend) ServiceTemplate() string { return "service.kt" }
+
+func (kotlinBackend) EntryContext(adl *schema.ADL) map[string]any {
+	return map[string]any{"KotlinPackage": adl.Spec.Language.Kotlin.PackageName}
+}
+
+func (kotlinBackend) IsToolFile(templateKey, _ string) bool {
+	return templateKey == "tool.kt"
+}
+
+func (kotlinBackend) IsServiceFile(templateKey, _ string) bool {
+	return templateKey == "service.kt"
+}
+
+func (kotlinBackend) UserFiles(adl *schema.ADL) []string {
+	pkg := kotlinSourceDir(adl)
+	var files []string
+	for _, tool := range userTools(adl) {
+		files = append(files, fmt.Sprintf("%stools/%s.kt", pkg, snakeCase(tool.ID)))
+	}
+	for _, serviceName := range slices.Sorted(maps.Keys(adl.Spec.Services)) {
+		files = append(files, fmt.Sprintf("%sservices/%s.kt", pkg, snakeCase(serviceName)))
+	}
+	return files
+}
+
+func (kotlinBackend) IgnoreDefaults(adl *schema.ADL) IgnoreDefaults {
+	return IgnoreDefaults{
+		ExampleFile: kotlinSourceDir(adl) + "tools/agent_tool.kt",
+		ExampleGlob: "*.kt",
+		ExampleDir:  "build/",
+		CustomFile:  "my-custom-file.kt",
+		DepsHeader:  "# Gradle dependency files",
+		DepsFiles:   []string{"gradle.lockfile"},
+	}
+}
+
+func (kotlinBackend) PostGenerateCommands() []string {
+	return []string{"gradle ktlintFormat"}
+}
+
+func (kotlinBackend) HeaderExtensions() []string { return []string{".kt", ".kts"} }
+func (kotlinBackend) CommentPrefix() string      { return "// " }
+
+func (kotlinBackend) VendorBuiltins() (deps, devdeps map[string]string) {
+	return vendor.MavenBuiltinDeps, vendor.MavenBuiltinDevDeps
+}
+
+// KotlinPackagePath returns the source directory of a Kotlin package,
+// e.g. "com/example/agent" for "com.example.agent".
+func KotlinPackagePath(cfg *schema.KotlinConfig) string {
+	if cfg == nil {
+		return ""
+	}
+	return strings.ReplaceAll(cfg.PackageName, ".", "/")
+}
+
+func kotlinSourceDir(adl *schema.ADL) string {
+	return "src/main/kotlin/" + KotlinPackagePath(adl.Spec.Language.Kotlin) + "/"
+}
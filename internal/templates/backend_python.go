@@ -0,0 +1,130 @@
+package templates
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+	"github.com/inference-gateway/adl-cli/internal/vendor"
+)
+
+// pythonBackend generates agents built on the A2A Python SDK. Sources
+// live in a src/ layout under the import package PythonModule derives
+// from spec.language.python.packageName.
+type pythonBackend struct{}
+
+func (pythonBackend) Name() string        { return "python" }
+func (pythonBackend) DisplayName() string { return "Python" }
+
+func (pythonBackend) Selected(adl *schema.ADL) bool { return adl.Spec.Language.Python != nil }
+
+func (pythonBackend) Validate(adl *schema.ADL) error {
+	if adl.Spec.Language.Python.PackageName == "" {
+		return fmt.Errorf("spec.language.python.packageName is required")
+	}
+	if adl.Spec.Language.Python.PythonVersion == "" {
+		return fmt.Errorf("spec.language.python.pythonVersion is required")
+	}
+	return nil
+}
+
+func (pythonBackend) Isolate(lang schema.Language) schema.Language {
+	return schema.Language{Python: lang.Python}
+}
+
+func (pythonBackend) Files(adl *schema.ADL) map[string]string {
+	pkg := pythonPackageDir(adl)
+	files := map[string]string{
+		pkg + "__init__.py":    "init.py",
+		pkg + "__main__.py":    "main.py",
+		pkg + "config.py":      "config.py",
+		pkg + "logger.py":      "logger.py",
+		pkg + "skills.py":      "skills.py",
+		pkg + "toolbox.py":     "toolbox.py",
+		pkg + "executor.py":    "executor.py",
+		"tests/test_config.py": "test_config.py",
+		"pyproject.toml":       "pyproject.toml",
+		".python-version":      "python-version",
+		"Dockerfile":           "docker/dockerfile.python",
+	}
+
+	tools := userTools(adl)
+	for _, tool := range tools {
+		files[fmt.Sprintf("%stools/%s.py", pkg, snakeCase(tool.ID))] = "tool.py"
+	}
+	if len(tools) > 0 {
+		files[pkg+"tools/__init__.py"] = "tools.init.py"
+	}
+
+	for serviceName := range adl.Spec.Services {
+		files[fmt.Sprintf("%sservices/%s.py", pkg, snakeCase(serviceName))] = "service.py"
+	}
+	if len(adl.Spec.Services) > 0 {
+		files[pkg+"services/__init__.py"] = "services.init.py"
+	}
+
+	return files
+}
+
+func (pythonBackend) ToolTemplate() string    { return "tool.py" }
+func (pythonBackend) ServiceTemplate() string { return "service.py" }
+
+func (pythonBackend) EntryContext(*schema.ADL) map[string]any { return nil }
+
+func (pythonBackend) IsToolFile(templateKey, _ string) bool {
+	return templateKey == "tool.py"
+}
+
+func (pythonBackend) IsServiceFile(templateKey, _ string) bool {
+	return templateKey == "service.py"
+}
+
+func (pythonBackend) UserFiles(adl *schema.ADL) []string {
+	pkg := pythonPackageDir(adl)
+	var files []string
+	for _, tool := range userTools(adl) {
+		files = append(files, fmt.Sprintf("%stools/%s.py", pkg, snakeCase(tool.ID)))
+	}
+	for _, serviceName := range slices.Sorted(maps.Keys(adl.Spec.Services)) {
+		files = append(files, fmt.Sprintf("%sservices/%s.py", pkg, snakeCase(serviceName)))
+	}
+	return files
+}
+
+func (pythonBackend) IgnoreDefaults(adl *schema.ADL) IgnoreDefaults {
+	return IgnoreDefaults{
+		ExampleFile: pythonPackageDir(adl) + "tools/agent_tool.py",
+		ExampleGlob: "*.py",
+		ExampleDir:  ".venv/",
+		CustomFile:  "my-custom-file.py",
+		DepsHeader:  "# Python dependency files",
+		DepsFiles:   []string{"uv.lock"},
+	}
+}
+
+func (pythonBackend) PostGenerateCommands() []string {
+	return []string{"uv run ruff format ."}
+}
+
+func (pythonBackend) HeaderExtensions() []string { return []string{".py"} }
+func (pythonBackend) CommentPrefix() string      { return "# " }
+
+func (pythonBackend) VendorBuiltins() (deps, devdeps map[string]string) {
+	return vendor.PipBuiltinDeps, vendor.PipBuiltinDevDeps
+}
+
+// PythonModule returns the import package name of a Python project: the
+// package name lowercased, with the '-' and '.' a distribution name may
+// contain replaced by '_'.
+func PythonModule(cfg *schema.PythonConfig) string {
+	if cfg == nil {
+		return ""
+	}
+	return strings.ToLower(strings.NewReplacer("-", "_", ".", "_").Replace(cfg.PackageName))
+}
+
+func pythonPackageDir(adl *schema.ADL) string {
+	return "src/" + PythonModule(adl.Spec.Language.Python) + "/"
+}
@@ -0,0 +1,104 @@
+package templates
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+	"github.com/inference-gateway/adl-cli/internal/vendor"
+)
+
+// rustBackend generates agents built on the Rust ADK. Tools are only
+// scaffolded for agents with an LLM (spec.agent).
+type rustBackend struct{}
+
+func (rustBackend) Name() string        { return "rust" }
+func (rustBackend) DisplayName() string { return "Rust" }
+
+func (rustBackend) Selected(adl *schema.ADL) bool { return adl.Spec.Language.Rust != nil }
+
+func (rustBackend) Validate(adl *schema.ADL) error {
+	if adl.Spec.Language.Rust.PackageName == "" {
+		return fmt.Errorf("spec.language.rust.packageName is required")
+	}
+	if adl.Spec.Language.Rust.Version == "" {
+		return fmt.Errorf("spec.language.rust.version is required")
+	}
+	if adl.Spec.Language.Rust.Edition == "" {
+		return fmt.Errorf("spec.language.rust.edition is required")
+	}
+	return nil
+}
+
+func (rustBackend) Isolate(lang schema.Language) schema.Language {
+	return schema.Language{Rust: lang.Rust}
+}
+
+func (rustBackend) Files(adl *schema.ADL) map[string]string {
+	files := map[string]string{
+		"src/main.rs": "main.rs",
+		"Cargo.toml":  "Cargo.toml",
+		"Dockerfile":  "docker/dockerfile.rust",
+	}
+	addVercelFiles(adl, files)
+
+	if adl.Spec.Agent != nil {
+		for _, tool := range adl.Spec.Tools {
+			if schema.IsReservedToolID(tool.ID) {
+				files[fmt.Sprintf("src/tools/%s.rs", tool.ID)] = fmt.Sprintf("builtin/%s.rs", tool.ID)
+				continue
+			}
+			files[fmt.Sprintf("src/tools/%s.rs", snakeCase(tool.ID))] = "tool.rs"
+		}
+
+		if len(adl.Spec.Tools) > 0 {
+			files["src/tools/mod.rs"] = "tool.mod.rs"
+		}
+	}
+
+	return files
+}
+
+func (rustBackend) ToolTemplate() string    { return "tool.rs" }
+func (rustBackend) ServiceTemplate() string { return "" }
+
+func (rustBackend) EntryContext(*schema.ADL) map[string]any { return nil }
+
+func (rustBackend) IsToolFile(templateKey, path string) bool {
+	return templateKey == "tool.rs" || templateKey == "tool.mod.rs" ||
+		(strings.HasPrefix(path, "src/tools/") && filepath.Ext(path) == ".rs")
+}
+
+func (rustBackend) IsServiceFile(string, string) bool { return false }
+
+func (rustBackend) UserFiles(adl *schema.ADL) []string {
+	if adl.Spec.Agent == nil {
+		return nil
+	}
+	var files []string
+	for _, tool := range userTools(adl) {
+		files = append(files, fmt.Sprintf("src/tools/%s.rs", snakeCase(tool.ID)))
+	}
+	return files
+}
+
+func (rustBackend) IgnoreDefaults(*schema.ADL) IgnoreDefaults {
+	return IgnoreDefaults{
+		ExampleFile: "src/tools/agent_tool.rs",
+		ExampleGlob: "*.rs",
+		ExampleDir:  "target/",
+		CustomFile:  "my-custom-file.rs",
+		DepsHeader:  "# Rust dependency files",
+		DepsFiles:   []string{"Cargo.lock"},
+	}
+}
+
+func (rustBackend) PostGenerateCommands() []string { return []string{"cargo fmt"} }
+
+func (rustBackend) HeaderExtensions() []string { return []string{".rs"} }
+func (rustBackend) CommentPrefix() string      { return "// " }
+
+func (rustBackend) VendorBuiltins() (deps, devdeps map[string]string) {
+	return vendor.CargoBuiltinDeps, vendor.CargoBuiltinDevDeps
+}
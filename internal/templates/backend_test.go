@@ -0,0 +1,128 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	schema "github.com/inference-gateway/adl-cli/internal/schema"
+)
+
+// TestBackends_TemplatesLoad checks every registered backend against its
+// languages/<name> directory: the registry loads, the per-entry tool and
+// service templates exist, and every file the backend maps resolves.
+func TestBackends_TemplatesLoad(t *testing.T) {
+	adls := map[string]*schema.ADL{
+		"go":         minimalGoADL(),
+		"rust":       minimalRustADL(),
+		"typescript": minimalTypeScriptADL(),
+		"python":     minimalPythonADL(),
+		"kotlin":     minimalKotlinADL(),
+	}
+
+	for _, b := range Backends() {
+		t.Run(b.Name(), func(t *testing.T) {
+			adl, ok := adls[b.Name()]
+			if !ok {
+				t.Fatalf("no minimal ADL for backend %q; add one to this test", b.Name())
+			}
+			if !b.Selected(adl) {
+				t.Fatalf("backend %q does not select its own minimal ADL", b.Name())
+			}
+			if got := DetectLanguageFromADL(adl); got != b.Name() {
+				t.Errorf("DetectLanguageFromADL = %q, want %q", got, b.Name())
+			}
+			if err := b.Validate(adl); err != nil {
+				t.Errorf("Validate: %v", err)
+			}
+
+			r, err := NewRegistry(b.Name())
+			if err != nil {
+				t.Fatalf("NewRegistry: %v", err)
+			}
+			for _, key := range []string{b.ToolTemplate(), b.ServiceTemplate()} {
+				if key == "" {
+					continue
+				}
+				if _, err := r.GetTemplate(key); err != nil {
+					t.Errorf("per-entry template %q not loaded: %v", key, err)
+				}
+			}
+			for path, key := range b.Files(adl) {
+				if _, err := r.GetTemplate(key); err != nil {
+					t.Errorf("files[%q] = %q: %v", path, key, err)
+				}
+			}
+		})
+	}
+}
+
+func TestLookupBackend_Unknown(t *testing.T) {
+	if _, ok := LookupBackend("cobol"); ok {
+		t.Error("LookupBackend(cobol) should fail")
+	}
+	if got := DetectLanguageFromADL(&schema.ADL{}); got != "go" {
+		t.Errorf("DetectLanguageFromADL(no language) = %q, want go", got)
+	}
+}
+
+func TestFileType(t *testing.T) {
+	cases := map[string]string{
+		"main.go":                   "go",
+		"src/main.rs":               "rust",
+		"src/index.ts":              "",
+		"src/agent/__main__.py":     "python",
+		"build.gradle.kts":          "kotlin",
+		"src/main/kotlin/a/Main.kt": "kotlin",
+		"k8s/deployment.yaml":       "yaml",
+		"Taskfile.yml":              "yaml",
+		"Dockerfile":                "dockerfile",
+		"README.md":                 "",
+	}
+	for name, want := range cases {
+		if got := FileType(name); got != want {
+			t.Errorf("FileType(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestGetGeneratedFileHeader_CommentPrefix(t *testing.T) {
+	now := time.Unix(0, 0)
+	cases := map[string]string{
+		"go":         "// Code generated",
+		"typescript": "// Code generated",
+		"javascript": "// Code generated",
+		"python":     "# Code generated",
+		"yaml":       "# Code generated",
+		"dockerfile": "# Code generated",
+	}
+	for fileType, want := range cases {
+		if got := GetGeneratedFileHeader(fileType, "1.0.0", now); !strings.HasPrefix(got, want) {
+			t.Errorf("GetGeneratedFileHeader(%q) = %q, want prefix %q", fileType, got, want)
+		}
+	}
+	if got := GetGeneratedFileHeader("markdown", "1.0.0", now); got != "" {
+		t.Errorf("GetGeneratedFileHeader(markdown) = %q, want none", got)
+	}
+}
+
+func TestWithLanguage(t *testing.T) {
+	adl := minimalGoADL()
+	adl.Spec.Language.TypeScript = minimalTypeScriptADL().Spec.Language.TypeScript
+
+	selected := SelectedBackends(adl)
+	if len(selected) != 2 || selected[0].Name() != "go" || selected[1].Name() != "typescript" {
+		t.Fatalf("SelectedBackends = %v, want [go typescript]", selected)
+	}
+
+	ts := WithLanguage(adl, selected[1])
+	if ts.Spec.Language.Go != nil || ts.Spec.Language.TypeScript == nil {
+		t.Errorf("WithLanguage(typescript) kept %+v", ts.Spec.Language)
+	}
+	if got := DetectLanguageFromADL(ts); got != "typescript" {
+		t.Errorf("DetectLanguageFromADL = %q, want typescript", got)
+	}
+	if adl.Spec.Language.Go == nil {
+		t.Error("WithLanguage must not modify the original ADL")
+	}
+}
@@ -0,0 +1,119 @@
+package templates
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+	"github.com/inference-gateway/adl-cli/internal/vendor"
+)
+
+// typeScriptBackend generates agents built on the TypeScript ADK. Its
+// sources carry no generated header.
+type typeScriptBackend struct{}
+
+func (typeScriptBackend) Name() string        { return "typescript" }
+func (typeScriptBackend) DisplayName() string { return "TypeScript" }
+
+func (typeScriptBackend) Selected(adl *schema.ADL) bool { return adl.Spec.Language.TypeScript != nil }
+
+func (typeScriptBackend) Validate(adl *schema.ADL) error {
+	if adl.Spec.Language.TypeScript.PackageName == "" {
+		return fmt.Errorf("spec.language.typescript.packageName is required")
+	}
+	if adl.Spec.Language.TypeScript.NodeVersion == "" {
+		return fmt.Errorf("spec.language.typescript.nodeVersion is required")
+	}
+	return nil
+}
+
+func (typeScriptBackend) Isolate(lang schema.Language) schema.Language {
+	return schema.Language{TypeScript: lang.TypeScript}
+}
+
+func (typeScriptBackend) Files(adl *schema.ADL) map[string]string {
+	files := map[string]string{
+		"src/index.ts":        "index.ts",
+		"src/config.ts":       "config.ts",
+		"src/logger.ts":       "logger.ts",
+		"package.json":        "package.json",
+		"pnpm-workspace.yaml": "pnpm-workspace.yaml",
+		"tsconfig.json":       "tsconfig.json",
+		"Dockerfile":          "docker/dockerfile.ts",
+	}
+	addVercelFiles(adl, files)
+
+	if adl.Spec.Deployment != nil && adl.Spec.Deployment.Type == schema.DeploymentConfigTypeCloudflare {
+		// Cloudflare Workers run on the V8-isolate edge runtime and are
+		// deployed from source via wrangler. The Worker entrypoint is
+		// TypeScript-specific; the other backends intentionally omit it.
+		files["wrangler.toml"] = "cloudflare/wrangler.toml"
+		files["src/worker.ts"] = "worker.ts"
+	}
+
+	tools := userTools(adl)
+	for _, tool := range tools {
+		files[fmt.Sprintf("src/tools/%s.ts", snakeCase(tool.ID))] = "tool.ts"
+	}
+	if len(tools) > 0 {
+		files["src/tools/index.ts"] = "tools.index.ts"
+	}
+
+	for serviceName := range adl.Spec.Services {
+		files[fmt.Sprintf("src/services/%s.ts", snakeCase(serviceName))] = "service.ts"
+	}
+
+	return files
+}
+
+func (typeScriptBackend) ToolTemplate() string    { return "tool.ts" }
+func (typeScriptBackend) ServiceTemplate() string { return "service.ts" }
+
+func (typeScriptBackend) EntryContext(*schema.ADL) map[string]any { return nil }
+
+func (typeScriptBackend) IsToolFile(templateKey, path string) bool {
+	return templateKey == "tool.ts" || (strings.HasPrefix(path, "src/tools/") && filepath.Ext(path) == ".ts")
+}
+
+func (typeScriptBackend) IsServiceFile(templateKey, _ string) bool {
+	return templateKey == "service.ts"
+}
+
+func (typeScriptBackend) UserFiles(adl *schema.ADL) []string {
+	var files []string
+	for _, tool := range userTools(adl) {
+		files = append(files, fmt.Sprintf("src/tools/%s.ts", snakeCase(tool.ID)))
+	}
+	for _, serviceName := range slices.Sorted(maps.Keys(adl.Spec.Services)) {
+		files = append(files, fmt.Sprintf("src/services/%s.ts", snakeCase(serviceName)))
+	}
+	// The Cloudflare Worker entrypoint ships as a scaffold with a TODO;
+	// preserve the user's completed handler across regenerations.
+	if adl.Spec.Deployment != nil && adl.Spec.Deployment.Type == schema.DeploymentConfigTypeCloudflare {
+		files = append(files, "src/worker.ts")
+	}
+	return files
+}
+
+func (typeScriptBackend) IgnoreDefaults(*schema.ADL) IgnoreDefaults {
+	return IgnoreDefaults{
+		ExampleFile: "src/tools/agent_tool.ts",
+		ExampleGlob: "*.ts",
+		ExampleDir:  "node_modules/",
+		CustomFile:  "my-custom-file.ts",
+		DepsHeader:  "# Node dependency files",
+		DepsFiles:   []string{"package-lock.json", "pnpm-lock.yaml", "yarn.lock"},
+	}
+}
+
+func (typeScriptBackend) PostGenerateCommands() []string { return nil }
+
+func (typeScriptBackend) HeaderExtensions() []string { return nil }
+func (typeScriptBackend) CommentPrefix() string      { return "// " }
+
+func (typeScriptBackend) VendorBuiltins() (deps, devdeps map[string]string) {
+	return vendor.NpmBuiltinDeps, vendor.NpmBuiltinDevDeps
+}
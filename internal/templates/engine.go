@@ -4,8 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 	"text/template"
 	"unicode"
@@ -250,7 +250,45 @@ type Context struct {
 	BuiltinConfigs  schema.ResolvedBuiltinConfigs
 	Vendor          vendor.View
 	SandboxDeps     sandbox.View
-	customAcronyms  map[string]string
+	// Languages lists the languages of a multi-language project, each
+	// generated into the subdirectory of the same name. It is only set
+	// for the files shared at the project root.
+	Languages []string
+	// Subdir is the subdirectory of a multi-language project the
+	// current language is generated into. Empty at the project root and
+	// for single-language projects.
+	Subdir         string
+	customAcronyms map[string]string
+}
+
+// Project is one implementation a root workflow builds: its language
+// and the directory it lives in, relative to the project root.
+type Project struct {
+	Language string
+	Dir      string
+}
+
+// Path returns name, a path inside the project, relative to the project
+// root.
+func (p Project) Path(name string) string {
+	if p.Dir == "" {
+		return name
+	}
+	return p.Dir + "/" + name
+}
+
+// Projects returns the implementations of the project: one per language
+// of a multi-language project, each in the subdirectory of the same
+// name, or the single language at the root.
+func (c Context) Projects() []Project {
+	if len(c.Languages) == 0 {
+		return []Project{{Language: c.Language}}
+	}
+	projects := make([]Project, 0, len(c.Languages))
+	for _, language := range c.Languages {
+		projects = append(projects, Project{Language: language, Dir: language})
+	}
+	return projects
 }
 
 // New creates a new template engine
@@ -277,6 +315,17 @@ func toJson(v any) string {
 	return string(jsonBytes)
 }
 
+// kotlinString renders v as a Kotlin string literal. JSON string escapes
+// are valid Kotlin escapes; '$' is escaped on top so the value is never
+// read as a string template.
+func kotlinString(v any) string {
+	s, ok := v.(string)
+	if !ok {
+		s = fmt.Sprint(v)
+	}
+	return strings.ReplaceAll(toJson(s), "$", `\$`)
+}
+
 // toGoMap converts a value to Go map literal string representation
 func toGoMap(v any) string {
 	return convertToGoMapLiteral(v)
@@ -306,13 +355,16 @@ func convertToGoMapLiteral(v any) string {
 		}
 		result := "map[string]any{"
 		first := true
-		iter := rv.MapRange()
-		for iter.Next() {
+		// Emit keys in sorted order so the rendered literal is stable
+		// across runs.
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, key := range keys {
 			if !first {
 				result += ", "
 			}
 			first = false
-			result += fmt.Sprintf(`"%s": %s`, iter.Key().String(), convertToGoMapLiteral(iter.Value().Interface()))
+			result += fmt.Sprintf(`"%s": %s`, key.String(), convertToGoMapLiteral(rv.MapIndex(key).Interface()))
 		}
 		result += "}"
 		return result
@@ -374,6 +426,9 @@ func customFuncMap() template.FuncMap {
 	funcMap["mcpEnvVars"] = mcpEnvVars
 	funcMap["cardSecuritySchemes"] = cardSecuritySchemes
 	funcMap["cardSecurity"] = cardSecurity
+	funcMap["pythonModule"] = PythonModule
+	funcMap["kotlinString"] = kotlinString
+	funcMap["kotlinPackagePath"] = KotlinPackagePath
 	return funcMap
 }
 
@@ -409,6 +464,9 @@ func customFuncMapWithAcronyms(acronyms map[string]string) template.FuncMap {
 	funcMap["mcpEnvVars"] = mcpEnvVars
 	funcMap["cardSecuritySchemes"] = cardSecuritySchemes
 	funcMap["cardSecurity"] = cardSecurity
+	funcMap["pythonModule"] = PythonModule
+	funcMap["kotlinString"] = kotlinString
+	funcMap["kotlinPackagePath"] = KotlinPackagePath
 	return funcMap
 }
 
@@ -453,22 +511,8 @@ func (e *Engine) ExecuteWithHeader(templateContent string, ctx Context, fileName
 		return "", err
 	}
 
-	ext := strings.ToLower(filepath.Ext(fileName))
-	baseName := strings.ToLower(filepath.Base(fileName))
-
-	var fileType string
-	switch {
-	case ext == ".go":
-		fileType = "go"
-	case ext == ".rs":
-		fileType = "rust"
-	case ext == ".yaml" || ext == ".yml":
-		fileType = "yaml"
-	case baseName == "dockerfile":
-		fileType = "dockerfile"
-	case baseName == "taskfile.yml":
-		fileType = "taskfile"
-	default:
+	fileType := FileType(fileName)
+	if fileType == "" {
 		return content, nil
 	}
 
@@ -12,11 +12,24 @@ func GetGeneratedFileHeader(fileType string, cliVersion string, generatedAt time
 This file was automatically generated from an ADL (Agent Definition Language) specification.
 Manual changes to this file may be overwritten during regeneration.`, cliVersion)
 
+	prefix := commentPrefix(fileType)
+	if prefix == "" {
+		return ""
+	}
+	return prefix + strings.ReplaceAll(headerText, "\n", "\n"+prefix) + "\n\n"
+}
+
+// commentPrefix returns the line comment marker of a FileType, or "" for
+// file types that carry no header.
+func commentPrefix(fileType string) string {
+	if b, ok := LookupBackend(fileType); ok {
+		return b.CommentPrefix()
+	}
 	switch fileType {
-	case "go", "rust", "typescript", "javascript":
-		return "// " + strings.ReplaceAll(headerText, "\n", "\n// ") + "\n\n"
+	case "javascript":
+		return "// "
 	case "yaml", "dockerfile", "taskfile":
-		return "# " + strings.ReplaceAll(headerText, "\n", "\n# ") + "\n\n"
+		return "# "
 	default:
 		return ""
 	}
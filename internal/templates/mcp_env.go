@@ -24,7 +24,7 @@ type MCPEnvVar struct {
 // (stdio/sse servers cannot be reached and are dropped; the validator warns).
 //
 // Returns nil unless MCP is enabled on a Go agent: the ADK MCP client exists only
-// in the Go ADK, so TypeScript/Rust agents get no A2A_MCP_* block.
+// in the Go ADK, so TypeScript/Rust/Python/Kotlin agents get no A2A_MCP_* block.
 func mcpEnvVars(adl *schema.ADL) []MCPEnvVar {
 	if adl == nil || adl.Spec.Agent == nil || adl.Spec.Agent.Mcp == nil || !adl.Spec.Agent.Mcp.Enabled {
 		return nil
@@ -0,0 +1,288 @@
+package templates
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+)
+
+// A template name is the path of a template in the embedded tree without
+// its .tmpl suffix, e.g. "languages/go/main.go" or
+// "common/docker/dockerfile.go". An overlay directory mirrors that tree:
+// <dir>/languages/go/main.go.tmpl replaces the main.go template of Go
+// projects.
+
+// EjectedFile records, relative to an overlay directory, the embedded
+// version of every template 'adl templates eject' copied into it, so
+// later CLI versions can tell when the embedded template moved on.
+const EjectedFile = ".ejected.json"
+
+// templateRoots are the top-level directories of the embedded tree.
+var templateRoots = []string{"languages", "common", "sandbox"}
+
+// DefaultOverlayDir returns ~/.adl/templates, the overlay directory that
+// applies to every project.
+func DefaultOverlayDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve user home directory: %w", err)
+	}
+	return filepath.Join(home, ".adl", "templates"), nil
+}
+
+// OverlayDirs returns the overlay directories that apply to adl, in
+// increasing order of precedence: userDir (skipped when it does not
+// exist), spec.templates.path resolved against the directory of adlFile,
+// then flagDir. The last two must exist.
+func OverlayDirs(userDir string, adl *schema.ADL, adlFile, flagDir string) ([]string, error) {
+	var dirs []string
+	if userDir != "" {
+		if info, err := os.Stat(userDir); err == nil && info.IsDir() {
+			dirs = append(dirs, userDir)
+		}
+	}
+
+	var explicit []string
+	if adl != nil && adl.Spec.Templates != nil && adl.Spec.Templates.Path != "" {
+		dir := adl.Spec.Templates.Path
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(filepath.Dir(adlFile), dir)
+		}
+		explicit = append(explicit, dir)
+	}
+	if flagDir != "" {
+		explicit = append(explicit, flagDir)
+	}
+	for _, dir := range explicit {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return nil, fmt.Errorf("template overlay directory %s: %w", dir, err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("template overlay %s is not a directory", dir)
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs, nil
+}
+
+// EmbeddedTemplateNames returns the name of every embedded template,
+// sorted.
+func EmbeddedTemplateNames() []string {
+	var names []string
+	for _, root := range templateRoots {
+		_ = fs.WalkDir(templateFS, root, func(filePath string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() && strings.HasSuffix(filePath, ".tmpl") {
+				names = append(names, strings.TrimSuffix(filePath, ".tmpl"))
+			}
+			return err
+		})
+	}
+	slices.Sort(names)
+	return names
+}
+
+// EmbeddedTemplate returns the content of the named embedded template.
+func EmbeddedTemplate(name string) (string, error) {
+	content, err := templateFS.ReadFile(name + ".tmpl")
+	if err != nil {
+		return "", fmt.Errorf("unknown template %s; run 'adl templates list' to see the available names", name)
+	}
+	return string(content), nil
+}
+
+// ejectedTemplates maps template names to their EjectedFile records.
+type ejectedTemplates map[string]ejectedTemplate
+
+type ejectedTemplate struct {
+	// SHA256 is the digest of the embedded template when it was ejected.
+	SHA256     string `json:"sha256"`
+	CLIVersion string `json:"cliVersion"`
+}
+
+func loadEjected(dir string) (ejectedTemplates, error) {
+	data, err := os.ReadFile(filepath.Join(dir, EjectedFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ejectedTemplates{}, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", EjectedFile, err)
+	}
+	ejected := ejectedTemplates{}
+	if err := json.Unmarshal(data, &ejected); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Join(dir, EjectedFile), err)
+	}
+	return ejected, nil
+}
+
+func (e ejectedTemplates) write(dir string) error {
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", EjectedFile, err)
+	}
+	return os.WriteFile(filepath.Join(dir, EjectedFile), append(data, '\n'), 0644)
+}
+
+// Eject copies the named embedded template into the overlay directory dir
+// and records the embedded version it was copied from. An existing copy
+// is only replaced when overwrite is set. It returns the written path.
+func Eject(dir, name, cliVersion string, overwrite bool) (string, error) {
+	content, err := EmbeddedTemplate(name)
+	if err != nil {
+		return "", err
+	}
+
+	target := filepath.Join(dir, filepath.FromSlash(name)+".tmpl")
+	if _, err := os.Stat(target); err == nil && !overwrite {
+		return "", fmt.Errorf("%s already exists; pass --force to replace it", target)
+	}
+
+	ejected, err := loadEjected(dir)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory for %s: %w", target, err)
+	}
+	if err := os.WriteFile(target, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", target, err)
+	}
+
+	ejected[name] = ejectedTemplate{SHA256: digest(content), CLIVersion: cliVersion}
+	if err := ejected.write(dir); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+// OverlayTemplate is one template file found in an overlay directory.
+type OverlayTemplate struct {
+	Dir  string
+	Name string
+	// Unknown is set when no embedded template has this name, so the
+	// file is never rendered.
+	Unknown bool
+	// Modified is set when the file differs from the embedded template.
+	Modified bool
+	// Drifted is set when the file was ejected and the embedded
+	// template has changed since; EjectedFrom is the CLI version it was
+	// ejected from.
+	Drifted     bool
+	EjectedFrom string
+
+	content string
+}
+
+// ScanOverlay lists the template files of the overlay directory dir,
+// sorted by name.
+func ScanOverlay(dir string) ([]OverlayTemplate, error) {
+	ejected, err := loadEjected(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []OverlayTemplate
+	fsys := os.DirFS(dir)
+	for _, root := range templateRoots {
+		err := fs.WalkDir(fsys, root, func(filePath string, d fs.DirEntry, err error) error {
+			if errors.Is(err, fs.ErrNotExist) && filePath == root {
+				return fs.SkipDir
+			}
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !strings.HasSuffix(filePath, ".tmpl") {
+				return nil
+			}
+			content, err := fs.ReadFile(fsys, filePath)
+			if err != nil {
+				return fmt.Errorf("failed to read template %s: %w", filePath, err)
+			}
+
+			t := OverlayTemplate{Dir: dir, Name: strings.TrimSuffix(filePath, ".tmpl"), content: string(content)}
+			embedded, err := EmbeddedTemplate(t.Name)
+			if err != nil {
+				t.Unknown = true
+			} else {
+				t.Modified = string(content) != embedded
+				if e, ok := ejected[t.Name]; ok {
+					t.Drifted = e.SHA256 != digest(embedded)
+					t.EjectedFrom = e.CLIVersion
+				}
+			}
+			found = append(found, t)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan template overlay %s: %w", dir, err)
+		}
+	}
+	slices.SortFunc(found, func(a, b OverlayTemplate) int { return strings.Compare(a.Name, b.Name) })
+	return found, nil
+}
+
+// OverlayWarnings returns a warning for every overlay template that will
+// not behave as its author expects: files matching no embedded template,
+// and ejected templates whose embedded version has changed since.
+func OverlayWarnings(dirs []string) ([]string, error) {
+	var warnings []string
+	for _, dir := range dirs {
+		found, err := ScanOverlay(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range found {
+			file := filepath.Join(dir, filepath.FromSlash(t.Name)+".tmpl")
+			switch {
+			case t.Unknown:
+				warnings = append(warnings, fmt.Sprintf("template overlay %s does not match any embedded template and is ignored", file))
+			case t.Drifted:
+				warnings = append(warnings, fmt.Sprintf("template overlay %s was ejected from CLI %s and the embedded template has changed since; compare it with 'adl templates eject %s --stdout'", file, t.EjectedFrom, t.Name))
+			}
+		}
+	}
+	return warnings, nil
+}
+
+// overlayKey maps a template name onto the key the registry of language
+// stores it under, or returns false when the template belongs to another
+// language.
+func overlayKey(name, language string) (string, bool) {
+	if rest, ok := strings.CutPrefix(name, "languages/"); ok {
+		lang, key, _ := strings.Cut(rest, "/")
+		return key, lang == language
+	}
+	root, key, _ := strings.Cut(name, "/")
+	return key, slices.Contains(templateRoots, root)
+}
+
+// loadOverlay reads the templates of the overlay directory dir that apply
+// to the registry's language.
+func (r *Registry) loadOverlay(dir string) error {
+	found, err := ScanOverlay(dir)
+	if err != nil {
+		return err
+	}
+	for _, t := range found {
+		key, ok := overlayKey(t.Name, r.language)
+		if !ok || t.Unknown {
+			continue
+		}
+		r.overlay[key] = t.content
+	}
+	return nil
+}
+
+func digest(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,146 @@
+package templates
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/inference-gateway/adl-cli/internal/schema"
+)
+
+func writeOverlay(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name)+".tmpl")
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRegistry_Overlays(t *testing.T) {
+	user, project := t.TempDir(), t.TempDir()
+	writeOverlay(t, user, map[string]string{
+		"common/docker/dockerfile.go": "FROM user",
+		"common/docs/LICENSE":         "user license",
+	})
+	writeOverlay(t, project, map[string]string{
+		"common/docker/dockerfile.go": "FROM project",
+		"languages/go/main.go":        "package main // project",
+		"languages/rust/main.rs":      "fn main() {}",
+	})
+
+	r, err := NewRegistryWithOptions(RegistryOptions{Language: "go", Overlays: []string{user, project}})
+	if err != nil {
+		t.Fatalf("NewRegistryWithOptions: %v", err)
+	}
+	for key, want := range map[string]string{
+		"docker/dockerfile.go": "FROM project",
+		"docs/LICENSE":         "user license",
+		"main.go":              "package main // project",
+	} {
+		if got, err := r.GetTemplate(key); err != nil || got != want {
+			t.Errorf("GetTemplate(%q) = %q, %v; want %q", key, got, err, want)
+		}
+	}
+
+	embedded, err := EmbeddedTemplate("common/docker/dockerfile.rust")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := r.GetTemplate("docker/dockerfile.rust"); got != embedded {
+		t.Error("templates without an overlay must come from the embedded tree")
+	}
+
+	rust, err := NewRegistryWithOptions(RegistryOptions{Language: "rust", Overlays: []string{project}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := rust.GetTemplate("main.rs"); got != "fn main() {}" {
+		t.Errorf("rust main.rs = %q, want the overlay", got)
+	}
+	if got, _ := r.GetTemplate("main.rs"); got == "fn main() {}" {
+		t.Error("a Go registry must not load Rust overlays")
+	}
+}
+
+func TestEject_Drift(t *testing.T) {
+	dir := t.TempDir()
+	path, err := Eject(dir, "common/docker/dockerfile.go", "1.0.0", false)
+	if err != nil {
+		t.Fatalf("Eject: %v", err)
+	}
+	if want := filepath.Join(dir, "common", "docker", "dockerfile.go.tmpl"); path != want {
+		t.Errorf("Eject path = %q, want %q", path, want)
+	}
+	if _, err := Eject(dir, "common/docker/dockerfile.go", "1.0.0", false); err == nil {
+		t.Error("ejecting over an existing copy without overwrite should fail")
+	}
+	if _, err := Eject(dir, "common/docker/nope", "1.0.0", false); err == nil {
+		t.Error("ejecting an unknown template should fail")
+	}
+	writeOverlay(t, dir, map[string]string{"common/docker/nope": "stray"})
+
+	found, err := ScanOverlay(dir)
+	if err != nil {
+		t.Fatalf("ScanOverlay: %v", err)
+	}
+	if len(found) != 2 || found[0].Name != "common/docker/dockerfile.go" || found[0].Modified || found[0].Drifted || !found[1].Unknown {
+		t.Fatalf("ScanOverlay = %+v", found)
+	}
+	if warnings, err := OverlayWarnings([]string{dir}); err != nil || len(warnings) != 1 || !strings.Contains(warnings[0], "does not match any embedded template") {
+		t.Errorf("OverlayWarnings = %q, %v; want only the unknown template", warnings, err)
+	}
+
+	// Pretend the embedded template changed since the copy was ejected.
+	record := map[string]map[string]string{
+		"common/docker/dockerfile.go": {"sha256": strings.Repeat("0", 64), "cliVersion": "0.9.0"},
+	}
+	data, _ := json.Marshal(record)
+	if err := os.WriteFile(filepath.Join(dir, EjectedFile), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	found, err = ScanOverlay(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found[0].Drifted || found[0].EjectedFrom != "0.9.0" {
+		t.Errorf("expected a drifted template ejected from 0.9.0, got %+v", found[0])
+	}
+	warnings, err := OverlayWarnings([]string{dir})
+	if err != nil || len(warnings) != 2 || !strings.Contains(warnings[0], "ejected from CLI 0.9.0") {
+		t.Errorf("OverlayWarnings = %q, %v; want a drift warning", warnings, err)
+	}
+}
+
+func TestOverlayDirs(t *testing.T) {
+	root := t.TempDir()
+	user, project, flag := filepath.Join(root, "user"), filepath.Join(root, "project", "tpl"), filepath.Join(root, "flag")
+	for _, dir := range []string{user, project, flag} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	adl := &schema.ADL{Spec: schema.Spec{Templates: &schema.TemplatesConfig{Path: "tpl"}}}
+	adlFile := filepath.Join(root, "project", "agent.yaml")
+
+	dirs, err := OverlayDirs(user, adl, adlFile, flag)
+	if err != nil {
+		t.Fatalf("OverlayDirs: %v", err)
+	}
+	if want := []string{user, project, flag}; strings.Join(dirs, ",") != strings.Join(want, ",") {
+		t.Errorf("OverlayDirs = %v, want %v", dirs, want)
+	}
+
+	if dirs, err := OverlayDirs(filepath.Join(root, "missing"), nil, adlFile, ""); err != nil || len(dirs) != 0 {
+		t.Errorf("a missing user directory should be skipped, got %v, %v", dirs, err)
+	}
+	if _, err := OverlayDirs("", nil, adlFile, filepath.Join(root, "missing")); err == nil {
+		t.Error("a missing --templates-dir should fail")
+	}
+}
@@ -13,6 +13,9 @@ import (
 // Registry manages template loading and lookup
 type Registry struct {
 	templates map[string]string
+	// overlay holds the templates read from the overlay directories,
+	// which GetTemplate prefers over the embedded ones.
+	overlay   map[string]string
 	language  string
 	enableAI  bool
 	aiToggles schema.AIAgentToggles
@@ -28,6 +31,9 @@ type RegistryOptions struct {
 	Language  string
 	EnableAI  bool
 	AIToggles schema.AIAgentToggles
+	// Overlays are template overlay directories, in increasing order of
+	// precedence. See OverlayDirs.
+	Overlays []string
 }
 
 // NewRegistry creates a new template registry for the specified language
@@ -42,6 +48,7 @@ func NewRegistry(language string) (*Registry, error) {
 func NewRegistryWithOptions(opts RegistryOptions) (*Registry, error) {
 	r := &Registry{
 		templates: make(map[string]string),
+		overlay:   make(map[string]string),
 		language:  opts.Language,
 		enableAI:  opts.EnableAI,
 		aiToggles: opts.AIToggles,
@@ -51,6 +58,12 @@ func NewRegistryWithOptions(opts RegistryOptions) (*Registry, error) {
 		return nil, fmt.Errorf("failed to load templates: %w", err)
 	}
 
+	for _, dir := range opts.Overlays {
+		if err := r.loadOverlay(dir); err != nil {
+			return nil, err
+		}
+	}
+
 	return r, nil
 }
 
@@ -109,190 +122,36 @@ func (r *Registry) createTemplateKey(filePath string) string {
 	return key
 }
 
-// GetTemplate retrieves a template by key
+// GetTemplate retrieves a template by key. Overlay templates take
+// precedence over the embedded ones.
 func (r *Registry) GetTemplate(key string) (string, error) {
-	// Try exact match first
-	if tmpl, ok := r.templates[key]; ok {
-		return tmpl, nil
-	}
-
 	langKey := fmt.Sprintf("%s.%s", key, r.language)
-	if tmpl, ok := r.templates[langKey]; ok {
-		return tmpl, nil
-	}
-
-	return "", fmt.Errorf("template not found: %s", key)
-}
-
-// GetFiles returns all files that should be generated for the current language
-func (r *Registry) GetFiles(adl *schema.ADL) map[string]string {
-	switch r.language {
-	case "go":
-		return r.getGoFiles(adl)
-	case "rust":
-		return r.getRustFiles(adl)
-	case "typescript":
-		return r.getTypeScriptFiles(adl)
-	default:
-		return r.getGoFiles(adl)
-	}
-}
-
-// getGoFiles returns the file mapping for Go projects
-func (r *Registry) getGoFiles(adl *schema.ADL) map[string]string {
-	files := map[string]string{
-		"main.go":                     "main.go",
-		"go.mod":                      "go.mod",
-		"config/config.go":            "config.go",
-		".well-known/agent-card.json": "config/agent.json",
-		"Taskfile.yml":                "taskfile/taskfile.yml",
-		"Dockerfile":                  "docker/dockerfile.go",
-		".dockerignore":               "config/dockerignore",
-		".gitignore":                  "config/gitignore",
-		".gitattributes":              "config/gitattributes",
-		".editorconfig":               "config/editorconfig",
-		"README.md":                   "docs/README.md",
-		"CONFIGURATIONS.md":           "docs/CONFIGURATIONS.md",
-		"LICENSE":                     "docs/LICENSE",
-	}
-
-	if adl.Spec.Deployment != nil {
-		switch adl.Spec.Deployment.Type {
-		case schema.DeploymentConfigTypeKubernetes:
-			files["k8s/deployment.yaml"] = "kubernetes/deployment.yaml"
-		case schema.DeploymentConfigTypeCloudRun:
-			// CloudRun deployment is handled via Taskfile
-		case schema.DeploymentConfigTypeVercel:
-			files["vercel.json"] = "vercel/vercel.json"
-			files[".vercel/project.json"] = "vercel/project.json"
-		}
-	}
-
-	for _, tool := range adl.Spec.Tools {
-		if schema.IsReservedToolID(tool.ID) {
-			files[fmt.Sprintf("tools/%s.go", tool.ID)] = fmt.Sprintf("builtin/%s.go", tool.ID)
-			files[fmt.Sprintf("tools/%s_test.go", tool.ID)] = fmt.Sprintf("builtin/%s_test.go", tool.ID)
-			continue
+	for _, source := range []map[string]string{r.overlay, r.templates} {
+		// Try exact match first
+		if tmpl, ok := source[key]; ok {
+			return tmpl, nil
 		}
-		snakeCaseName := strings.ReplaceAll(tool.ID, "-", "_")
-		files[fmt.Sprintf("tools/%s.go", snakeCaseName)] = "tool.go"
-	}
-
-	if telemetryEnabled(adl) && hasBuiltinTool(adl) {
-		files["tools/telemetry.go"] = "telemetry.go"
-	}
-
-	for _, skill := range adl.Spec.Skills {
-		if skill.Bare {
-			files[fmt.Sprintf(".agents/skills/%s/SKILL.md", skill.ID)] = "skills/skill.md"
+		if tmpl, ok := source[langKey]; ok {
+			return tmpl, nil
 		}
 	}
 
-	files["internal/logger/logger.go"] = "logger.go"
-
-	if adl.Spec.Server.Authz != nil && adl.Spec.Server.Authz.Enabled {
-		files["internal/authz/authz.go"] = "authz.go"
-	}
-
-	for serviceName := range adl.Spec.Services {
-		snakeCaseName := strings.ReplaceAll(serviceName, "-", "_")
-		files[fmt.Sprintf("internal/%s/%s.go", snakeCaseName, snakeCaseName)] = "service.go"
-	}
-
-	if adl.Spec.Development != nil &&
-		adl.Spec.Development.Sandbox != nil &&
-		adl.Spec.Development.Sandbox.DockerCompose != nil &&
-		adl.Spec.Development.Sandbox.DockerCompose.Enabled {
-		files["docker-compose.yaml"] = "docker/docker-compose.yaml"
-		files[".env.example"] = "config/env.example"
-	}
-
-	r.addSandboxFiles(adl, files)
-	r.addAIFiles(files)
-	r.addIssueTemplateFiles(adl, files)
-	r.addDependabotFiles(adl, files)
-
-	return files
+	return "", fmt.Errorf("template not found: %s", key)
 }
 
-// getRustFiles returns the file mapping for Rust projects
-func (r *Registry) getRustFiles(adl *schema.ADL) map[string]string {
-	files := map[string]string{
-		"src/main.rs":                 "main.rs",
-		"Cargo.toml":                  "Cargo.toml",
-		".well-known/agent-card.json": "config/agent.json",
-		"Taskfile.yml":                "taskfile/taskfile.yml",
-		"Dockerfile":                  "docker/dockerfile.rust",
-		".dockerignore":               "config/dockerignore",
-		".gitignore":                  "config/gitignore",
-		".gitattributes":              "config/gitattributes",
-		".editorconfig":               "config/editorconfig",
-		"README.md":                   "docs/README.md",
-		"CONFIGURATIONS.md":           "docs/CONFIGURATIONS.md",
-		"LICENSE":                     "docs/LICENSE",
-	}
-
-	if adl.Spec.Deployment != nil {
-		switch adl.Spec.Deployment.Type {
-		case schema.DeploymentConfigTypeKubernetes:
-			files["k8s/deployment.yaml"] = "kubernetes/deployment.yaml"
-		case schema.DeploymentConfigTypeCloudRun:
-			// CloudRun deployment is handled via Taskfile
-		case schema.DeploymentConfigTypeVercel:
-			files["vercel.json"] = "vercel/vercel.json"
-			files[".vercel/project.json"] = "vercel/project.json"
-		}
-	}
-
-	if adl.Spec.Agent != nil {
-		for _, tool := range adl.Spec.Tools {
-			if schema.IsReservedToolID(tool.ID) {
-				files[fmt.Sprintf("src/tools/%s.rs", tool.ID)] = fmt.Sprintf("builtin/%s.rs", tool.ID)
-				continue
-			}
-			snakeCaseName := strings.ReplaceAll(tool.ID, "-", "_")
-			files[fmt.Sprintf("src/tools/%s.rs", snakeCaseName)] = "tool.rs"
-		}
-
-		if len(adl.Spec.Tools) > 0 {
-			files["src/tools/mod.rs"] = "tool.mod.rs"
-		}
-	}
-
-	for _, skill := range adl.Spec.Skills {
-		if skill.Bare {
-			files[fmt.Sprintf(".agents/skills/%s/SKILL.md", skill.ID)] = "skills/skill.md"
-		}
-	}
-
-	if adl.Spec.Development != nil &&
-		adl.Spec.Development.Sandbox != nil &&
-		adl.Spec.Development.Sandbox.DockerCompose != nil &&
-		adl.Spec.Development.Sandbox.DockerCompose.Enabled {
-		files["docker-compose.yaml"] = "docker/docker-compose.yaml"
-		files[".env.example"] = "config/env.example"
+// GetFiles returns all files that should be generated for the current
+// language: the language backend's own files plus the files every
+// language shares.
+func (r *Registry) GetFiles(adl *schema.ADL) map[string]string {
+	backend, ok := LookupBackend(r.language)
+	if !ok {
+		backend = goBackend{}
 	}
 
-	r.addSandboxFiles(adl, files)
-	r.addAIFiles(files)
-	r.addIssueTemplateFiles(adl, files)
-	r.addDependabotFiles(adl, files)
-
-	return files
-}
-
-// getTypeScriptFiles returns the file mapping for TypeScript projects
-func (r *Registry) getTypeScriptFiles(adl *schema.ADL) map[string]string {
-	files := map[string]string{
-		"src/index.ts":                "index.ts",
-		"src/config.ts":               "config.ts",
-		"src/logger.ts":               "logger.ts",
-		"package.json":                "package.json",
-		"pnpm-workspace.yaml":         "pnpm-workspace.yaml",
-		"tsconfig.json":               "tsconfig.json",
+	files := backend.Files(adl)
+	for path, key := range map[string]string{
 		".well-known/agent-card.json": "config/agent.json",
 		"Taskfile.yml":                "taskfile/taskfile.yml",
-		"Dockerfile":                  "docker/dockerfile.ts",
 		".dockerignore":               "config/dockerignore",
 		".gitignore":                  "config/gitignore",
 		".gitattributes":              "config/gitattributes",
@@ -300,43 +159,12 @@ func (r *Registry) getTypeScriptFiles(adl *schema.ADL) map[string]string {
 		"README.md":                   "docs/README.md",
 		"CONFIGURATIONS.md":           "docs/CONFIGURATIONS.md",
 		"LICENSE":                     "docs/LICENSE",
+	} {
+		files[path] = key
 	}
 
-	if adl.Spec.Deployment != nil {
-		switch adl.Spec.Deployment.Type {
-		case schema.DeploymentConfigTypeKubernetes:
-			files["k8s/deployment.yaml"] = "kubernetes/deployment.yaml"
-		case schema.DeploymentConfigTypeCloudRun:
-			// CloudRun deployment is handled via Taskfile
-		case schema.DeploymentConfigTypeVercel:
-			files["vercel.json"] = "vercel/vercel.json"
-			files[".vercel/project.json"] = "vercel/project.json"
-		case schema.DeploymentConfigTypeCloudflare:
-			// Cloudflare Workers run on the V8-isolate edge runtime and are
-			// deployed from source via wrangler. The Worker entrypoint is
-			// TypeScript-specific; see the Go/Rust maps, which intentionally
-			// omit it.
-			files["wrangler.toml"] = "cloudflare/wrangler.toml"
-			files["src/worker.ts"] = "worker.ts"
-		}
-	}
-
-	hasUserTools := false
-	for _, tool := range adl.Spec.Tools {
-		if schema.IsReservedToolID(tool.ID) {
-			continue
-		}
-		snakeCaseName := strings.ReplaceAll(tool.ID, "-", "_")
-		files[fmt.Sprintf("src/tools/%s.ts", snakeCaseName)] = "tool.ts"
-		hasUserTools = true
-	}
-	if hasUserTools {
-		files["src/tools/index.ts"] = "tools.index.ts"
-	}
-
-	for serviceName := range adl.Spec.Services {
-		snakeCaseName := strings.ReplaceAll(serviceName, "-", "_")
-		files[fmt.Sprintf("src/services/%s.ts", snakeCaseName)] = "service.ts"
+	if adl.Spec.Deployment != nil && adl.Spec.Deployment.Type == schema.DeploymentConfigTypeKubernetes {
+		files["k8s/deployment.yaml"] = "kubernetes/deployment.yaml"
 	}
 
 	for _, skill := range adl.Spec.Skills {
@@ -427,7 +255,7 @@ func (r *Registry) addIssueTemplateFiles(adl *schema.ADL, files map[string]strin
 
 // addDependabotFiles adds the GitHub Dependabot configuration when enabled.
 // The generated manifest enumerates ecosystems based on the ADL spec
-// (gomod/cargo/npm by language plus github-actions, docker, and
+// (gomod/cargo/npm/uv by language plus github-actions, docker, and
 // devcontainers when applicable). Only emitted for the GitHub SCM
 // provider - GitLab/Bitbucket equivalents are out of scope for now.
 func (r *Registry) addDependabotFiles(adl *schema.ADL, files map[string]string) {
@@ -456,17 +284,3 @@ func hasBuiltinTool(adl *schema.ADL) bool {
 	}
 	return false
 }
-
-// DetectLanguageFromADL detects the programming language from ADL
-func DetectLanguageFromADL(adl *schema.ADL) string {
-	if adl.Spec.Language.Go != nil {
-		return "go"
-	}
-	if adl.Spec.Language.Rust != nil {
-		return "rust"
-	}
-	if adl.Spec.Language.TypeScript != nil {
-		return "typescript"
-	}
-	return "go"
-}
@@ -19,6 +19,8 @@ func TestRegistry_Dockerignore_AllLanguages(t *testing.T) {
 	}{
 		{name: "go", language: "go", makeADL: minimalGoADL},
 		{name: "rust", language: "rust", makeADL: minimalRustADL},
+		{name: "python", language: "python", makeADL: minimalPythonADL},
+		{name: "kotlin", language: "kotlin", makeADL: minimalKotlinADL},
 	}
 
 	for _, tc := range cases {
@@ -88,6 +90,8 @@ func TestRegistry_EnvExample_GatedOnDockerCompose(t *testing.T) {
 	}{
 		{name: "go", language: "go", makeADL: minimalGoADL},
 		{name: "rust", language: "rust", makeADL: minimalRustADL},
+		{name: "python", language: "python", makeADL: minimalPythonADL},
+		{name: "kotlin", language: "kotlin", makeADL: minimalKotlinADL},
 	}
 
 	for _, tc := range cases {
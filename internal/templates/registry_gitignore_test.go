@@ -40,6 +40,8 @@ func TestRegistry_Gitignore_AllLanguages(t *testing.T) {
 		{name: "go", language: "go", makeADL: minimalGoADL},
 		{name: "rust", language: "rust", makeADL: minimalRustADL},
 		{name: "typescript", language: "typescript", makeADL: minimalTypeScriptADL},
+		{name: "python", language: "python", makeADL: minimalPythonADL},
+		{name: "kotlin", language: "kotlin", makeADL: minimalKotlinADL},
 	}
 
 	for _, tc := range cases {
@@ -152,3 +154,33 @@ func TestGitignoreTemplate_Rust(t *testing.T) {
 		}
 	}
 }
+
+func TestGitignoreTemplate_Python(t *testing.T) {
+	out := renderGitignore(t, "python", minimalPythonADL())
+
+	for _, want := range []string{"__pycache__/", ".venv/"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("python .gitignore missing %s\n---\n%s", want, out)
+		}
+	}
+	for _, banned := range []string{"node_modules/", "go.work", "*.test"} {
+		if strings.Contains(out, banned) {
+			t.Errorf("python .gitignore should not contain %q\n---\n%s", banned, out)
+		}
+	}
+}
+
+func TestGitignoreTemplate_Kotlin(t *testing.T) {
+	out := renderGitignore(t, "kotlin", minimalKotlinADL())
+
+	for _, want := range []string{".gradle/", "build/", "*.class"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("kotlin .gitignore missing %s\n---\n%s", want, out)
+		}
+	}
+	for _, banned := range []string{"node_modules/", "go.work", "__pycache__/"} {
+		if strings.Contains(out, banned) {
+			t.Errorf("kotlin .gitignore should not contain %q\n---\n%s", banned, out)
+		}
+	}
+}
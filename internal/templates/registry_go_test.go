@@ -29,10 +29,10 @@ func minimalGoADL() *schema.ADL {
 	}
 }
 
-// TestRegistry_getGoFiles_ScaffoldsTestForEachBuiltin verifies that each
+// TestRegistry_GoFiles_ScaffoldsTestForEachBuiltin verifies that each
 // reserved built-in tool emits both an implementation and a unit-test
 // file - the second AC of #138.
-func TestRegistry_getGoFiles_ScaffoldsTestForEachBuiltin(t *testing.T) {
+func TestRegistry_GoFiles_ScaffoldsTestForEachBuiltin(t *testing.T) {
 	r, err := NewRegistry("go")
 	if err != nil {
 		t.Fatalf("NewRegistry: %v", err)
@@ -47,7 +47,7 @@ func TestRegistry_getGoFiles_ScaffoldsTestForEachBuiltin(t *testing.T) {
 		{ID: "fetch"},
 	}
 
-	files := r.getGoFiles(adl)
+	files := r.GetFiles(adl)
 
 	for _, id := range []string{"read", "bash", "write", "edit", "fetch"} {
 		implPath := "tools/" + id + ".go"
@@ -73,10 +73,10 @@ func TestRegistry_getGoFiles_ScaffoldsTestForEachBuiltin(t *testing.T) {
 	}
 }
 
-// TestRegistry_getGoFiles_NoTestForCustomTools ensures the test scaffold
+// TestRegistry_GoFiles_NoTestForCustomTools ensures the test scaffold
 // only ships with built-in tools. Custom tools deliberately don't get a
 // test file - users can crib from the built-in tests as an example.
-func TestRegistry_getGoFiles_NoTestForCustomTools(t *testing.T) {
+func TestRegistry_GoFiles_NoTestForCustomTools(t *testing.T) {
 	r, err := NewRegistry("go")
 	if err != nil {
 		t.Fatalf("NewRegistry: %v", err)
@@ -93,7 +93,7 @@ func TestRegistry_getGoFiles_NoTestForCustomTools(t *testing.T) {
 		},
 	}
 
-	files := r.getGoFiles(adl)
+	files := r.GetFiles(adl)
 
 	if _, ok := files["tools/weather_test.go"]; ok {
 		t.Errorf("custom tool should not receive a _test.go scaffold; got entry in files map")
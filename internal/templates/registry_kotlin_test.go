@@ -0,0 +1,180 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+
+	schema "github.com/inference-gateway/adl-cli/internal/schema"
+	"github.com/inference-gateway/adl-cli/internal/vendor"
+)
+
+func minimalKotlinADL() *schema.ADL {
+	return &schema.ADL{
+		APIVersion: "adl.inference-gateway.com/v1",
+		Kind:       "Agent",
+		Metadata: schema.Metadata{
+			Name:        "kotlin-agent",
+			Description: "test",
+			Version:     "0.1.0",
+		},
+		Spec: schema.Spec{
+			Capabilities: schema.Capabilities{Streaming: true},
+			Server:       schema.Server{Port: 8080},
+			Language: schema.Language{
+				Kotlin: &schema.KotlinConfig{
+					PackageName: "com.example.agent",
+					JvmVersion:  "21",
+				},
+			},
+		},
+	}
+}
+
+func renderKotlin(t *testing.T, key string, adl *schema.ADL) string {
+	t.Helper()
+	registry, err := NewRegistry("kotlin")
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+	view, err := vendor.ResolveADL(adl)
+	if err != nil {
+		t.Fatalf("ResolveADL: %v", err)
+	}
+	out, err := NewWithRegistry("minimal", registry).ExecuteTemplate(key, Context{
+		ADL:      adl,
+		Language: "kotlin",
+		Vendor:   view,
+	})
+	if err != nil {
+		t.Fatalf("ExecuteTemplate(%q): %v", key, err)
+	}
+	return out
+}
+
+func TestKotlinPackagePath(t *testing.T) {
+	cases := map[string]string{
+		"com.example.agent": "com/example/agent",
+		"agent":             "agent",
+	}
+	for name, want := range cases {
+		if got := KotlinPackagePath(&schema.KotlinConfig{PackageName: name}); got != want {
+			t.Errorf("KotlinPackagePath(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestKotlinString(t *testing.T) {
+	cases := map[string]string{
+		"plain":        `"plain"`,
+		`say "hi"`:     `"say \"hi\""`,
+		"cost: $5":     `"cost: \$5"`,
+		"line\nbreak":  `"line\nbreak"`,
+		"${injection}": `"\${injection}"`,
+	}
+	for in, want := range cases {
+		if got := kotlinString(in); got != want {
+			t.Errorf("kotlinString(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestRegistry_KotlinFiles_Layout(t *testing.T) {
+	r, err := NewRegistry("kotlin")
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+
+	adl := minimalKotlinADL()
+	adl.Spec.Tools = []schema.Tool{
+		{ID: "read"},
+		{ID: "get-weather", Name: "get_weather", Description: "Weather"},
+	}
+	adl.Spec.Services = map[string]schema.Service{
+		"weather-api": {Type: "service", Interface: "WeatherAPI", Factory: "NewWeatherAPI", Description: "API"},
+	}
+
+	files := r.GetFiles(adl)
+	pkg := "src/main/kotlin/com/example/agent/"
+	want := map[string]string{
+		"build.gradle.kts":                                "build.gradle.kts",
+		"settings.gradle.kts":                             "settings.gradle.kts",
+		"gradle.properties":                               "gradle.properties",
+		pkg + "Main.kt":                                   "Main.kt",
+		pkg + "A2A.kt":                                    "A2A.kt",
+		pkg + "Executor.kt":                               "Executor.kt",
+		pkg + "tools/Tools.kt":                            "tools.kt",
+		pkg + "tools/get_weather.kt":                      "tool.kt",
+		pkg + "services/weather_api.kt":                   "service.kt",
+		"src/main/resources/logback.xml":                  "logback.xml",
+		"src/test/kotlin/com/example/agent/ConfigTest.kt": "ConfigTest.kt",
+		"Dockerfile":                                      "docker/dockerfile.kotlin",
+		".well-known/agent-card.json":                     "config/agent.json",
+	}
+	for path, key := range want {
+		if got, ok := files[path]; !ok || got != key {
+			t.Errorf("files[%q] = %q (present=%v), want %q", path, got, ok, key)
+		}
+		if _, err := r.GetTemplate(files[path]); err != nil {
+			t.Errorf("template %q not loaded: %v", files[path], err)
+		}
+	}
+	if _, ok := files[pkg+"tools/read.kt"]; ok {
+		t.Error("reserved tool 'read' must not get a Kotlin tool file")
+	}
+}
+
+func TestKotlinBuildGradle_VendorPins(t *testing.T) {
+	adl := minimalKotlinADL()
+	adl.Spec.Language.Kotlin.Vendor = &schema.VendorConfig{
+		Deps:    []string{"com.squareup.okio:okio@3.10.2"},
+		Devdeps: []string{"io.mockk:mockk@1.13.17"},
+	}
+
+	out := renderKotlin(t, "build.gradle.kts", adl)
+	for _, want := range []string{
+		`implementation("io.ktor:ktor-server-netty:`,
+		`implementation("com.squareup.okio:okio:3.10.2")`,
+		`testImplementation("io.mockk:mockk:1.13.17")`,
+		`testImplementation("org.jetbrains.kotlin:kotlin-test:`,
+		"jvmToolchain(21)",
+		`mainClass.set("com.example.agent.MainKt")`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("build.gradle.kts missing %q\n---\n%s", want, out)
+		}
+	}
+}
+
+func TestKotlinTemplates_Render(t *testing.T) {
+	adl := minimalKotlinADL()
+	adl.Spec.Agent = &schema.Agent{Provider: "openai", Model: "gpt-4o", SystemPrompt: "You help. It costs $5."}
+
+	r, err := NewRegistry("kotlin")
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+	for path, key := range r.GetFiles(adl) {
+		// Tool and service files render from a per-entry context; the
+		// generator tests cover them.
+		if !strings.HasSuffix(path, ".kt") || key == "tool.kt" || key == "service.kt" {
+			continue
+		}
+		out := renderKotlin(t, key, adl)
+		if strings.TrimSpace(out) == "" {
+			t.Errorf("%s (%s) rendered empty", path, key)
+		}
+		if !strings.Contains(out, "package com.example.agent\n") {
+			t.Errorf("%s should declare package com.example.agent\n---\n%s", path, out)
+		}
+	}
+
+	main := renderKotlin(t, "Main.kt", adl)
+	for _, want := range []string{"embeddedServer(Netty", "fun main()", `"message/stream"`} {
+		if !strings.Contains(main, want) {
+			t.Errorf("Main.kt missing %q\n---\n%s", want, main)
+		}
+	}
+	if config := renderKotlin(t, "Config.kt", adl); !strings.Contains(config, `\$5`) {
+		t.Errorf("Config.kt must escape '$' in string literals\n---\n%s", config)
+	}
+}
@@ -0,0 +1,160 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+
+	schema "github.com/inference-gateway/adl-cli/internal/schema"
+	"github.com/inference-gateway/adl-cli/internal/vendor"
+)
+
+func minimalPythonADL() *schema.ADL {
+	return &schema.ADL{
+		APIVersion: "adl.inference-gateway.com/v1",
+		Kind:       "Agent",
+		Metadata: schema.Metadata{
+			Name:        "python-agent",
+			Description: "test",
+			Version:     "0.1.0",
+		},
+		Spec: schema.Spec{
+			Capabilities: schema.Capabilities{Streaming: true},
+			Server:       schema.Server{Port: 8080},
+			Language: schema.Language{
+				Python: &schema.PythonConfig{
+					PackageName:   "python-agent",
+					PythonVersion: "3.13",
+				},
+			},
+		},
+	}
+}
+
+func renderPython(t *testing.T, key string, adl *schema.ADL) string {
+	t.Helper()
+	registry, err := NewRegistry("python")
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+	view, err := vendor.ResolveADL(adl)
+	if err != nil {
+		t.Fatalf("ResolveADL: %v", err)
+	}
+	out, err := NewWithRegistry("minimal", registry).ExecuteTemplate(key, Context{
+		ADL:      adl,
+		Language: "python",
+		Vendor:   view,
+	})
+	if err != nil {
+		t.Fatalf("ExecuteTemplate(%q): %v", key, err)
+	}
+	return out
+}
+
+func TestPythonModule(t *testing.T) {
+	cases := map[string]string{
+		"python-agent": "python_agent",
+		"My.Agent":     "my_agent",
+		"agent":        "agent",
+	}
+	for name, want := range cases {
+		if got := PythonModule(&schema.PythonConfig{PackageName: name}); got != want {
+			t.Errorf("PythonModule(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestRegistry_PythonFiles_Layout(t *testing.T) {
+	r, err := NewRegistry("python")
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+
+	adl := minimalPythonADL()
+	adl.Spec.Tools = []schema.Tool{
+		{ID: "read"},
+		{ID: "get-weather", Name: "get_weather", Description: "Weather"},
+	}
+	adl.Spec.Services = map[string]schema.Service{
+		"weather-api": {Type: "service", Interface: "WeatherAPI", Factory: "NewWeatherAPI", Description: "API"},
+	}
+
+	files := r.GetFiles(adl)
+	want := map[string]string{
+		"pyproject.toml":                           "pyproject.toml",
+		".python-version":                          "python-version",
+		"src/python_agent/__main__.py":             "main.py",
+		"src/python_agent/executor.py":             "executor.py",
+		"src/python_agent/tools/__init__.py":       "tools.init.py",
+		"src/python_agent/tools/get_weather.py":    "tool.py",
+		"src/python_agent/services/__init__.py":    "services.init.py",
+		"src/python_agent/services/weather_api.py": "service.py",
+		"tests/test_config.py":                     "test_config.py",
+		"Dockerfile":                               "docker/dockerfile.python",
+		".well-known/agent-card.json":              "config/agent.json",
+	}
+	for path, key := range want {
+		if got, ok := files[path]; !ok || got != key {
+			t.Errorf("files[%q] = %q (present=%v), want %q", path, got, ok, key)
+		}
+		if _, err := r.GetTemplate(files[path]); err != nil {
+			t.Errorf("template %q not loaded: %v", files[path], err)
+		}
+	}
+	if _, ok := files["src/python_agent/tools/read.py"]; ok {
+		t.Error("reserved tool 'read' must not get a Python tool module")
+	}
+}
+
+func TestPythonPyproject_VendorPins(t *testing.T) {
+	adl := minimalPythonADL()
+	adl.Spec.Language.Python.Vendor = &schema.VendorConfig{
+		Deps:    []string{"httpx@0.28.1", "pydantic@>=2.7"},
+		Devdeps: []string{"mypy@1.11.0"},
+	}
+
+	out := renderPython(t, "pyproject.toml", adl)
+	for _, want := range []string{
+		`name = "python-agent"`,
+		`requires-python = ">=3.13"`,
+		`"a2a-sdk[http-server]>=`,
+		`"httpx==0.28.1"`,
+		`"pydantic>=2.7"`,
+		`"mypy==1.11.0"`,
+		`"pytest>=8.0.0"`,
+		`python-agent = "python_agent.__main__:main"`,
+		`packages = ["src/python_agent"]`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("pyproject.toml missing %q\n---\n%s", want, out)
+		}
+	}
+}
+
+func TestPythonTemplates_Render(t *testing.T) {
+	adl := minimalPythonADL()
+	adl.Spec.Agent = &schema.Agent{Provider: "openai", Model: "gpt-4o", SystemPrompt: "You help."}
+
+	r, err := NewRegistry("python")
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+	for path, key := range r.GetFiles(adl) {
+		// Tool and service modules render from a per-entry context; the
+		// generator tests cover them.
+		if !strings.HasSuffix(path, ".py") || key == "tool.py" || key == "service.py" {
+			continue
+		}
+		out := renderPython(t, key, adl)
+		if strings.TrimSpace(out) == "" {
+			t.Errorf("%s (%s) rendered empty", path, key)
+		}
+	}
+
+	main := renderPython(t, "main.py", adl)
+	for _, want := range []string{"A2AStarletteApplication", "uvicorn.run", "def main()"} {
+		if !strings.Contains(main, want) {
+			t.Errorf("__main__.py missing %q\n---\n%s", want, main)
+		}
+	}
+}
@@ -30,14 +30,14 @@ func minimalRustADL() *schema.ADL {
 	}
 }
 
-func TestRegistry_getRustFiles_EnvExampleGatedOnDockerCompose(t *testing.T) {
+func TestRegistry_RustFiles_EnvExampleGatedOnDockerCompose(t *testing.T) {
 	r, err := NewRegistry("rust")
 	if err != nil {
 		t.Fatalf("NewRegistry: %v", err)
 	}
 
 	adl := minimalRustADL()
-	if _, ok := r.getRustFiles(adl)[".env.example"]; ok {
+	if _, ok := r.GetFiles(adl)[".env.example"]; ok {
 		t.Fatalf(".env.example unexpectedly emitted when sandbox.dockerCompose disabled")
 	}
 
@@ -46,19 +46,19 @@ func TestRegistry_getRustFiles_EnvExampleGatedOnDockerCompose(t *testing.T) {
 			DockerCompose: &schema.DockerComposeConfig{Enabled: true},
 		},
 	}
-	if _, ok := r.getRustFiles(adl)[".env.example"]; !ok {
+	if _, ok := r.GetFiles(adl)[".env.example"]; !ok {
 		t.Fatalf(".env.example missing when sandbox.dockerCompose.enabled=true")
 	}
 }
 
-func TestRegistry_getRustFiles_DockerComposeOnlyWhenEnabled(t *testing.T) {
+func TestRegistry_RustFiles_DockerComposeOnlyWhenEnabled(t *testing.T) {
 	r, err := NewRegistry("rust")
 	if err != nil {
 		t.Fatalf("NewRegistry: %v", err)
 	}
 
 	adl := minimalRustADL()
-	if _, ok := r.getRustFiles(adl)["docker-compose.yaml"]; ok {
+	if _, ok := r.GetFiles(adl)["docker-compose.yaml"]; ok {
 		t.Fatalf("docker-compose.yaml unexpectedly emitted when sandbox flag unset")
 	}
 
@@ -67,7 +67,7 @@ func TestRegistry_getRustFiles_DockerComposeOnlyWhenEnabled(t *testing.T) {
 			DockerCompose: &schema.DockerComposeConfig{Enabled: true},
 		},
 	}
-	if _, ok := r.getRustFiles(adl)["docker-compose.yaml"]; !ok {
+	if _, ok := r.GetFiles(adl)["docker-compose.yaml"]; !ok {
 		t.Fatalf("docker-compose.yaml missing when sandbox.dockerCompose.enabled=true")
 	}
 }
@@ -57,11 +57,11 @@ func TestHasBuiltinTool(t *testing.T) {
 	}
 }
 
-// TestRegistry_getGoFiles_TelemetryFile checks that tools/telemetry.go is
+// TestRegistry_GoFiles_TelemetryFile checks that tools/telemetry.go is
 // mapped only when telemetry is on AND at least one built-in tool exists - the
 // helper is used exclusively by built-in tool handlers, so it would be dead
 // code otherwise.
-func TestRegistry_getGoFiles_TelemetryFile(t *testing.T) {
+func TestRegistry_GoFiles_TelemetryFile(t *testing.T) {
 	cases := []struct {
 		name      string
 		telemetry bool
@@ -87,7 +87,7 @@ func TestRegistry_getGoFiles_TelemetryFile(t *testing.T) {
 				adl.Spec.Telemetry = &schema.TelemetryConfig{Enabled: true}
 			}
 
-			files := r.getGoFiles(adl)
+			files := r.GetFiles(adl)
 			key, ok := files["tools/telemetry.go"]
 			if ok != tc.want {
 				t.Fatalf("tools/telemetry.go present = %v, want %v", ok, tc.want)
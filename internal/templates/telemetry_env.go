@@ -44,13 +44,14 @@ type TelemetryEnvVar struct {
 //
 // Prometheus pull is Go-only: the TypeScript ADK does not support it yet, so its
 // OTEL_EXPORTER_PROMETHEUS_* defaults are skipped for TypeScript (the validator
-// warns on that combination separately).
+// warns on that combination separately). Rust, Python and Kotlin agents
+// ignore spec.telemetry, so they get no variables at all.
 func telemetryEnvVars(adl *schema.ADL) []TelemetryEnvVar {
 	if adl == nil || adl.Spec.Telemetry == nil || !adl.Spec.Telemetry.Enabled {
 		return nil
 	}
 	lang := DetectLanguageFromADL(adl)
-	if lang == "rust" {
+	if lang == "rust" || lang == "python" || lang == "kotlin" {
 		return nil
 	}
 
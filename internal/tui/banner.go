@@ -23,7 +23,7 @@ func Banner() string {
 
 	subtitle := lipgloss.NewStyle().
 		Foreground(colorAccent).
-		Render("Scaffold an A2A agent - generate Go, Rust, or TypeScript.")
+		Render("Scaffold an A2A agent - generate Go, Rust, TypeScript, Python, or Kotlin.")
 
 	body := lipgloss.JoinVertical(lipgloss.Left, heading, "", subtitle)
 